@@ -0,0 +1,179 @@
+package valtra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrBatchStopped is returned by ValidateAll when it stopped
+// at the first invalid item, as requested by
+// StopOnFirstFailure.
+var ErrBatchStopped = errors.New("valtra: batch stopped at first failure")
+
+// BatchOption configures how ValidateAll validates a batch of
+// items.
+type BatchOption func(*batchOptions)
+
+// batchOptions holds the configuration applied by BatchOption
+// values.
+type batchOptions struct {
+	workers  int
+	failFast bool
+	name     string
+	scratch  bool
+	intern   bool
+}
+
+// WithWorkers sets the number of items ValidateAll validates
+// concurrently. It defaults to runtime.GOMAXPROCS(0).
+func WithWorkers(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.workers = n
+	}
+}
+
+// StopOnFirstFailure makes ValidateAll stop validating items
+// once one of them is invalid.
+func StopOnFirstFailure() BatchOption {
+	return func(o *batchOptions) {
+		o.failFast = true
+	}
+}
+
+// WithItemName sets the name used for the items in error
+// messages, followed by their index, e.g. "rows[42]". It
+// defaults to "value".
+func WithItemName(name string) BatchOption {
+	return func(o *batchOptions) {
+		o.name = name
+	}
+}
+
+// WithScratch makes ValidateAll allocate the errors and
+// messages of failed items, and the names of all items, in
+// chunks shared by the items each worker validates, rather
+// than one by one. Batches of millions of items then make far
+// fewer allocations, keeping garbage collection cheap.
+//
+// A chunk stays in memory for as long as any error or name
+// allocated from it is referenced, so the option suits batches
+// whose results are used and dropped together, rather than
+// kept selectively.
+func WithScratch() BatchOption {
+	return func(o *batchOptions) {
+		o.scratch = true
+	}
+}
+
+// InternMessages makes ValidateAll intern the messages of the
+// errors of failed items, so that identical messages, such as
+// "email is required" for every row missing an email, share a
+// single string. Reports on huge batches that fail the same
+// way then use memory in proportion to the number of distinct
+// messages.
+//
+// Messages are interned with the unique package, so they are
+// freed once no error uses them. Messages that name the item,
+// as with WithItemName, differ for every item, and gain
+// nothing from interning.
+func InternMessages() BatchOption {
+	return func(o *batchOptions) {
+		o.intern = true
+	}
+}
+
+// ValidateAll applies the schema to all items concurrently,
+// using a pool of workers, and returns the resulting values
+// by index.
+//
+// It suits large batches, such as the rows of a CSV import,
+// which a sequential loop would validate on a single core.
+// The schema's rules must therefore be safe for concurrent
+// use, as the built-in ones are.
+//
+// If the context is cancelled, or StopOnFirstFailure is set
+// and an item is invalid, items that have not been validated
+// yet are skipped: their values are returned unvalidated,
+// along with the context's error or ErrBatchStopped. The
+// returned error is nil when all items were validated.
+//
+// Example:
+//
+//	rows, err := valtra.ValidateAll(ctx, records, rowSchema, valtra.WithItemName("rows"))
+//	if err != nil {
+//	    return err
+//	}
+//	for i, row := range rows {
+//	    if !row.IsValid() {
+//	        log.Printf("row %d: %v", i, row.Errors())
+//	    }
+//	}
+func ValidateAll[T any](ctx context.Context, items []T, schema Schema[T], opts ...BatchOption) ([]Value[T], error) {
+	o := batchOptions{workers: runtime.GOMAXPROCS(0), name: "value"}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	results := make([]Value[T], len(items))
+	var names *scratch
+	if o.scratch {
+		names = &scratch{chunks: true}
+	}
+	for i, item := range items {
+		if names != nil {
+			results[i] = Val(item, names.indexedName(o.name, i))
+		} else {
+			results[i] = Val(item, fmt.Sprintf("%s[%d]", o.name, i))
+		}
+	}
+
+	batchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stopped atomic.Bool
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range max(o.workers, 1) {
+		wg.Go(func() {
+			var s *scratch
+			if o.scratch || o.intern {
+				s = &scratch{chunks: o.scratch, intern: o.intern}
+			}
+
+			for i := range jobs {
+				results[i].scratch = s
+				results[i] = schema.Apply(results[i])
+				results[i].scratch = nil
+				if o.failFast && !results[i].IsValid() {
+					stopped.Store(true)
+					cancel()
+				}
+			}
+		})
+	}
+
+feed:
+	for i := range items {
+		select {
+		case jobs <- i:
+		case <-batchCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	switch {
+	case stopped.Load():
+		return results, ErrBatchStopped
+	case ctx.Err() != nil:
+		return results, ctx.Err()
+	}
+
+	return results, nil
+}
@@ -0,0 +1,149 @@
+package valtra_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"unsafe"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestValidateAll(t *testing.T) {
+	schema := valtra.NewSchema[int]().Validate(valtra.Min(0))
+
+	items := make([]int, 1000)
+	for i := range items {
+		items[i] = i
+	}
+	items[10], items[500] = -1, -2
+
+	t.Run("results are returned by index", func(t *testing.T) {
+		results, err := valtra.ValidateAll(context.Background(), items, schema, valtra.WithWorkers(4), valtra.WithItemName("rows"))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(results) != len(items) {
+			t.Fatalf("Expected %d results, got %d", len(items), len(results))
+		}
+
+		for i, v := range results {
+			if v.Value() != items[i] {
+				t.Fatalf("Expected value %d at index %d, got %d", items[i], i, v.Value())
+			}
+			if v.IsValid() != (i != 10 && i != 500) {
+				t.Errorf("Unexpected validity at index %d: %v", i, v.Errors())
+			}
+		}
+		if msg := results[10].Errors()[0].Error(); msg != "rows[10] cannot be smaller than 0" {
+			t.Errorf("Unexpected message: %q", msg)
+		}
+	})
+
+	t.Run("stop on first failure", func(t *testing.T) {
+		_, err := valtra.ValidateAll(context.Background(), items, schema, valtra.StopOnFirstFailure())
+		if !errors.Is(err, valtra.ErrBatchStopped) {
+			t.Errorf("Expected ErrBatchStopped, got %v", err)
+		}
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		results, err := valtra.ValidateAll(ctx, items, schema)
+		if !errors.Is(err, context.Canceled) || len(results) != len(items) {
+			t.Errorf("Expected context.Canceled with all values, got %v", err)
+		}
+	})
+
+	t.Run("empty batch", func(t *testing.T) {
+		results, err := valtra.ValidateAll(context.Background(), nil, schema)
+		if err != nil || len(results) != 0 {
+			t.Errorf("Unexpected result %v (error: %v)", results, err)
+		}
+	})
+}
+
+func TestWithScratch(t *testing.T) {
+	schema := valtra.NewSchema[int]().Validate(valtra.Min(0), valtra.Max(100, valtra.WithMessage("{name} is {value}, over {max}")))
+
+	items := make([]int, 5000)
+	for i := range items {
+		items[i] = i%3 - 1
+	}
+	items[7] = 1000
+
+	results, err := valtra.ValidateAll(context.Background(), items, schema, valtra.WithWorkers(4), valtra.WithScratch(), valtra.WithItemName("rows"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	for i, v := range results {
+		if v.Name() != fmt.Sprintf("rows[%d]", i) {
+			t.Fatalf("Unexpected name at index %d: %q", i, v.Name())
+		}
+		if items[i] < 0 {
+			if msg := v.FirstError().Error(); msg != fmt.Sprintf("rows[%d] cannot be smaller than 0", i) {
+				t.Fatalf("Unexpected message at index %d: %q", i, msg)
+			}
+		}
+	}
+	if msg := results[7].FirstError().Error(); msg != "rows[7] is 1000, over 100" {
+		t.Errorf("Unexpected message: %q", msg)
+	}
+
+	// Results no longer use the scratch once returned
+	if v := results[0].Validate(valtra.Max(-5)); v.Errors()[1].Error() != "rows[0] cannot be larger than -5" {
+		t.Errorf("Unexpected message: %v", v.Errors())
+	}
+}
+
+func BenchmarkValidateAll(b *testing.B) {
+	schema := valtra.NewSchema[int]().Validate(valtra.Min(0))
+	items := make([]int, 10000)
+	for i := range items {
+		items[i] = -i
+	}
+
+	for _, bm := range []struct {
+		name string
+		opts []valtra.BatchOption
+	}{
+		{"default", nil},
+		{"scratch", []valtra.BatchOption{valtra.WithScratch()}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := valtra.ValidateAll(context.Background(), items, schema, bm.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestInternMessages(t *testing.T) {
+	type row struct{ Email string }
+	schema := valtra.NewSchema[row]().Validate(
+		valtra.Field("email", func(r row) string { return r.Email }, valtra.Required[string]()),
+	)
+
+	results, err := valtra.ValidateAll(context.Background(), make([]row, 100), schema, valtra.WithWorkers(2), valtra.InternMessages())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	first := results[0].FirstError().Error()
+	for i, v := range results {
+		msg := v.FirstError().Error()
+		if msg != "email is required" {
+			t.Fatalf("Unexpected message at index %d: %q", i, msg)
+		}
+		if unsafe.StringData(msg) != unsafe.StringData(first) {
+			t.Fatalf("Expected the message at index %d to be shared", i)
+		}
+	}
+}
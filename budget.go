@@ -0,0 +1,130 @@
+package valtra
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Budget limits the work spent validating a single payload,
+// as a guard against adversarial input, such as deeply nested
+// documents, that would otherwise keep validation busy.
+//
+// The budget is shared by the value and everything its rules
+// validate in turn, such as the fields checked by Field.
+type Budget struct {
+	// MaxRules caps the number of validations and
+	// transformations run. Zero means no limit.
+	MaxRules int
+	// MaxDuration caps the wall-clock time spent, from when
+	// the budget is attached. Zero means no limit.
+	MaxDuration time.Duration
+}
+
+// budget tracks what has been spent of a Budget.
+type budget struct {
+	limits   Budget
+	deadline time.Time
+	rules    atomic.Int64
+	exceeded atomic.Bool
+}
+
+// newBudget starts spending the given budget.
+func newBudget(limits Budget) *budget {
+	b := &budget{limits: limits}
+	if limits.MaxDuration > 0 {
+		b.deadline = time.Now().Add(limits.MaxDuration)
+	}
+
+	return b
+}
+
+// spend spends a rule of the budget, reporting whether the
+// rule must be skipped because the budget is used up, and
+// whether the caller is the first to find out, and so should
+// report it. A nil budget is never used up.
+func (b *budget) spend() (skip, first bool) {
+	if b == nil {
+		return false, false
+	}
+	if b.exceeded.Load() {
+		return true, false
+	}
+
+	over := b.limits.MaxRules > 0 && b.rules.Add(1) > int64(b.limits.MaxRules) ||
+		!b.deadline.IsZero() && time.Now().After(b.deadline)
+	if !over {
+		return false, false
+	}
+
+	return true, b.exceeded.CompareAndSwap(false, true)
+}
+
+// budgetError returns the error for a value whose budget is
+// used up.
+func budgetError[T any](v Value[T]) error {
+	limits := v.budget.limits
+	return newError(v, "validation_budget_exceeded", map[string]any{"max_rules": limits.MaxRules, "max_duration": limits.MaxDuration}, nil)
+}
+
+// spend spends a rule of the value's budget, reporting false
+// if the budget is used up. The error is added to the value
+// that finds out first.
+func (v *Value[T]) spend() bool {
+	skip, first := v.budget.spend()
+	if first {
+		v.errs = append(v.errs, budgetError(*v))
+	}
+
+	return !skip
+}
+
+// WithBudget returns a copy of the value whose validation is
+// limited by the given budget.
+//
+// Once the budget is used up, all remaining validations and
+// transformations are skipped, and a single error with the
+// code "validation_budget_exceeded" is reported. The time is
+// checked between rules, so a single slow rule is not
+// interrupted; use ValidateCtx with a deadline for those.
+//
+// Example:
+//
+//	v := valtra.Val(doc, "document").
+//	    WithBudget(valtra.Budget{MaxRules: 10000, MaxDuration: 50 * time.Millisecond}).
+//	    Apply(documentSchema)
+func (v Value[T]) WithBudget(b Budget) Value[T] {
+	v.budget = newBudget(b)
+	return v
+}
+
+// WithBudget returns a schema that applies s within the given
+// budget, as with Value.WithBudget, starting each time it is
+// applied. Values that already have a budget keep theirs.
+//
+// Example:
+//
+//	var documentSchema = valtra.NewSchema[Document]().
+//	    Validate(valtra.Field("sections", func(d Document) []Section { return d.Sections }, sectionRules...)).
+//	    WithBudget(valtra.Budget{MaxRules: 10000})
+func (s Schema[T]) WithBudget(b Budget) Schema[T] {
+	within := func(apply func(Value[T]) Value[T]) func(Value[T]) Value[T] {
+		return func(v Value[T]) Value[T] {
+			if v.budget != nil {
+				return apply(v)
+			}
+
+			v.budget = newBudget(b)
+			v = apply(v)
+			v.budget = nil
+			return v
+		}
+	}
+
+	return Schema[T]{steps: []step[T]{{
+		apply:       within(s.Apply),
+		sanitize:    within(s.sanitize),
+		checkOnly:   within(s.checkOnly),
+		denormalize: s.denormalize,
+		info:        s.Describe(),
+	}}}
+}
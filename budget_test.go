@@ -0,0 +1,102 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestWithBudget(t *testing.T) {
+	exceeded := &valtra.ValidationError{Code: "validation_budget_exceeded"}
+
+	counting := func(calls *int) func(valtra.Value[int]) error {
+		return func(valtra.Value[int]) error {
+			*calls++
+			return nil
+		}
+	}
+
+	t.Run("rules within budget run", func(t *testing.T) {
+		var calls int
+		v := valtra.Val(1).WithBudget(valtra.Budget{MaxRules: 3}).Validate(counting(&calls), counting(&calls), counting(&calls))
+		if !v.IsValid() || calls != 3 {
+			t.Errorf("Expected 3 rules to run and pass, got %d calls and errors: %v", calls, v.Errors())
+		}
+	})
+
+	t.Run("rules beyond budget are skipped", func(t *testing.T) {
+		var calls int
+		v := valtra.Val(1, "count").WithBudget(valtra.Budget{MaxRules: 2}).
+			Validate(counting(&calls), counting(&calls), counting(&calls)).
+			Validate(counting(&calls))
+		if calls != 2 {
+			t.Errorf("Expected 2 rules to run, got %d", calls)
+		}
+		if len(v.Errors()) != 1 || !errors.Is(v.Err(), exceeded) {
+			t.Fatalf("Expected a single budget error, got %v", v.Errors())
+		}
+		if got := v.Err().Error(); got != "count took too much work to validate" {
+			t.Errorf("Unexpected message %q", got)
+		}
+	})
+
+	t.Run("nested rules share the budget", func(t *testing.T) {
+		type pair struct{ A, B string }
+
+		schema := valtra.NewSchema[pair]().Validate(
+			valtra.Field("a", func(p pair) string { return p.A }, valtra.Required[string](), valtra.MaxLengthString(5)),
+			valtra.Field("b", func(p pair) string { return p.B }, valtra.Required[string](), valtra.MaxLengthString(5)),
+		).WithBudget(valtra.Budget{MaxRules: 4})
+
+		v := valtra.Val(pair{"x", "y"}).Apply(schema)
+		if len(v.Errors()) != 1 || !errors.Is(v.Err(), exceeded) {
+			t.Errorf("Expected a single budget error, got %v", v.Errors())
+		}
+	})
+
+	t.Run("schemas start a budget each time", func(t *testing.T) {
+		schema := valtra.NewSchema[int]().Validate(valtra.Min(0), valtra.Max(10)).WithBudget(valtra.Budget{MaxRules: 2})
+
+		for range 3 {
+			if _, err := schema.Run(5); err != nil {
+				t.Fatalf("Expected validation to pass, got %v", err)
+			}
+		}
+	})
+
+	t.Run("time beyond budget stops validation", func(t *testing.T) {
+		slow := func(valtra.Value[int]) error {
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		}
+
+		var calls int
+		v := valtra.Val(1).WithBudget(valtra.Budget{MaxDuration: time.Millisecond}).Validate(slow, counting(&calls))
+		if calls != 0 || !errors.Is(v.Err(), exceeded) {
+			t.Errorf("Expected the rule after the slow one to be skipped, got %d calls and errors: %v", calls, v.Errors())
+		}
+	})
+
+	t.Run("schema rules are still described", func(t *testing.T) {
+		schema := valtra.NewSchema[int]().Rules(
+			valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18)),
+			valtra.Describe("max", map[string]any{"max": 10}, valtra.Max(10)),
+		).WithBudget(valtra.Budget{MaxRules: 10})
+
+		if info := schema.Describe(); len(info) != 2 || info[0].String() != "min=18" {
+			t.Errorf("Expected the wrapped schema's rules, got %v", info)
+		}
+		if len(schema.Lint()) != 1 {
+			t.Errorf("Expected Lint to see the wrapped rules, got %v", schema.Lint())
+		}
+	})
+
+	t.Run("budget covers transformations", func(t *testing.T) {
+		v := valtra.Val("  Bobby ").WithBudget(valtra.Budget{MaxRules: 1}).Transform(valtra.TrimSpace(), valtra.Lowercase())
+		if v.Value() != "Bobby" || !errors.Is(v.Err(), exceeded) {
+			t.Errorf("Expected only the first transformation to run, got %q and errors: %v", v.Value(), v.Errors())
+		}
+	})
+}
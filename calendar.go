@@ -0,0 +1,464 @@
+package valtra
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// rruleFrequencies lists the FREQ values of RFC 5545
+// recurrence rules.
+var rruleFrequencies = []string{"SECONDLY", "MINUTELY", "HOURLY", "DAILY", "WEEKLY", "MONTHLY", "YEARLY"}
+
+// rruleWeekdays lists the weekday values of RFC 5545
+// recurrence rules.
+var rruleWeekdays = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// rruleRanges holds the allowed ranges of the numeric list
+// parts of recurrence rules, and whether negative values are
+// allowed.
+var rruleRanges = map[string]struct {
+	min, max int
+	signed   bool
+}{
+	"BYSECOND":   {0, 60, false},
+	"BYMINUTE":   {0, 59, false},
+	"BYHOUR":     {0, 23, false},
+	"BYMONTHDAY": {1, 31, true},
+	"BYYEARDAY":  {1, 366, true},
+	"BYWEEKNO":   {1, 53, true},
+	"BYMONTH":    {1, 12, false},
+	"BYSETPOS":   {1, 366, true},
+}
+
+// RRule returns a validation that ensures the value is an
+// iCalendar recurrence rule as defined by RFC 5545, e.g.
+// "FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20251231T235959Z". An
+// optional "RRULE:" prefix is allowed.
+//
+// Besides the syntax of each part, it checks that FREQ is
+// present, that UNTIL and COUNT are not combined, and that
+// parts are only used with the frequencies they apply to
+// (e.g. BYWEEKNO only with YEARLY). The error message
+// explains which check failed.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Recurrence).Validate(valtra.RRule())
+func RRule(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if reason := checkRRule(v.value); reason != "" {
+			return newError(v, "rrule", map[string]any{"reason": reason}, opts)
+		}
+
+		return nil
+	}
+}
+
+// checkRRule checks a recurrence rule, returning the reason
+// it is invalid, or "" if it is valid.
+func checkRRule(rule string) string {
+	rule = strings.TrimPrefix(rule, "RRULE:")
+	if rule == "" {
+		return "empty rule"
+	}
+
+	parts := map[string]string{}
+	var names []string
+	for part := range strings.SplitSeq(rule, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return "malformed part"
+		}
+		if _, dup := parts[name]; dup {
+			return fmt.Sprintf("duplicate %s", name)
+		}
+		parts[name] = value
+		names = append(names, name)
+	}
+
+	freq, ok := parts["FREQ"]
+	if !ok {
+		return "missing FREQ"
+	}
+	if !slices.Contains(rruleFrequencies, freq) {
+		return "invalid FREQ"
+	}
+
+	// Check parts in order, so the first invalid one is reported
+	for _, name := range names {
+		value := parts[name]
+		var reason string
+		switch name {
+		case "FREQ":
+		case "UNTIL":
+			if !isICalDateTime(value) {
+				reason = "invalid UNTIL"
+			}
+		case "COUNT", "INTERVAL":
+			if n, err := strconv.Atoi(value); err != nil || n < 1 {
+				reason = fmt.Sprintf("%s must be a positive integer", name)
+			}
+		case "WKST":
+			if !slices.Contains(rruleWeekdays, value) {
+				reason = "invalid WKST"
+			}
+		case "BYDAY":
+			reason = checkRRuleByDay(value, freq, parts)
+		default:
+			r, ok := rruleRanges[name]
+			if !ok {
+				return fmt.Sprintf("unknown part %s", name)
+			}
+			reason = checkRRuleList(name, value, r.min, r.max, r.signed)
+		}
+
+		if reason != "" {
+			return reason
+		}
+	}
+
+	_, hasUntil := parts["UNTIL"]
+	_, hasCount := parts["COUNT"]
+	switch {
+	case hasUntil && hasCount:
+		return "UNTIL and COUNT cannot be combined"
+	case parts["BYWEEKNO"] != "" && freq != "YEARLY":
+		return "BYWEEKNO requires FREQ=YEARLY"
+	case parts["BYYEARDAY"] != "" && slices.Contains([]string{"DAILY", "WEEKLY", "MONTHLY"}, freq):
+		return fmt.Sprintf("BYYEARDAY cannot be used with FREQ=%s", freq)
+	case parts["BYMONTHDAY"] != "" && freq == "WEEKLY":
+		return "BYMONTHDAY cannot be used with FREQ=WEEKLY"
+	case parts["BYSETPOS"] != "" && !hasRRuleByPart(parts):
+		return "BYSETPOS requires another BYxxx part"
+	}
+
+	return ""
+}
+
+// checkRRuleByDay checks the BYDAY part of a recurrence rule.
+func checkRRuleByDay(value, freq string, parts map[string]string) string {
+	for day := range strings.SplitSeq(value, ",") {
+		if len(day) < 2 || !slices.Contains(rruleWeekdays, day[len(day)-2:]) {
+			return "invalid BYDAY"
+		}
+
+		ordinal := day[:len(day)-2]
+		if ordinal == "" {
+			continue
+		}
+		if n, err := strconv.Atoi(ordinal); err != nil || n == 0 || n < -53 || n > 53 {
+			return "invalid BYDAY"
+		}
+		if freq != "MONTHLY" && freq != "YEARLY" {
+			return fmt.Sprintf("BYDAY ordinals cannot be used with FREQ=%s", freq)
+		}
+		if freq == "YEARLY" && parts["BYWEEKNO"] != "" {
+			return "BYDAY ordinals cannot be combined with BYWEEKNO"
+		}
+	}
+
+	return ""
+}
+
+// checkRRuleList checks a comma-separated list of integers in
+// a recurrence rule against the allowed range.
+func checkRRuleList(name, value string, min, max int, signed bool) string {
+	for item := range strings.SplitSeq(value, ",") {
+		n, err := strconv.Atoi(item)
+		if err == nil && signed && n < 0 {
+			n = -n
+		}
+		if err != nil || n < min || n > max {
+			return "invalid " + name
+		}
+	}
+
+	return ""
+}
+
+// hasRRuleByPart reports whether the recurrence rule has any
+// BYxxx part other than BYSETPOS.
+func hasRRuleByPart(parts map[string]string) bool {
+	for name := range parts {
+		if strings.HasPrefix(name, "BY") && name != "BYSETPOS" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isICalDateTime reports whether the value is an iCalendar
+// DATE (e.g. "20251231") or DATE-TIME (e.g.
+// "20251231T235959Z").
+func isICalDateTime(value string) bool {
+	for _, layout := range []string{"20060102", "20060102T150405", "20060102T150405Z"} {
+		if _, err := time.Parse(layout, value); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// icalDurationRegex matches iCalendar DURATION values, e.g.
+// "PT1H30M" or "P1W".
+var icalDurationRegex = regexp.MustCompile(`^[+-]?P(?:[0-9]+W|[0-9]+D(?:T(?:[0-9]+H)?(?:[0-9]+M)?(?:[0-9]+S)?)?|T(?:[0-9]+H)?(?:[0-9]+M)?(?:[0-9]+S)?)$`)
+
+// icalProperty is a single content line of an iCalendar
+// file.
+type icalProperty struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// icalEvent holds the properties of a VEVENT component.
+type icalEvent struct {
+	index int
+	line  int
+	props map[string]icalProperty
+	count map[string]int
+}
+
+// ICalendar returns a validation that ensures the value is an
+// iCalendar (.ics) file as defined by RFC 5545, such as an
+// uploaded calendar export.
+//
+// The file must consist of a VCALENDAR with VERSION and
+// PRODID, and properly nested components. Each VEVENT must
+// have a UID, DTSTAMP and DTSTART with valid date-times, must
+// not combine DTEND with DURATION, must not end before it
+// starts, and must have a valid RRULE if it has one.
+//
+// Structural problems produce a single error. Otherwise every
+// invalid event produces its own error, identifying the event
+// by its position and explaining the problem, and the errors
+// are joined into a single error. If the value has a position
+// (see Value.At), the errors are positioned at the offending
+// line of the file.
+//
+// Options such as WithMessage can be provided as the
+// parameters, and apply to every error.
+//
+// Example:
+//
+//	valtra.Val(upload, "calendar").Validate(valtra.ICalendar())
+func ICalendar(opts ...Option) func(Value[[]byte]) error {
+	return func(v Value[[]byte]) error {
+		events, reason, line := parseICalendar(v.value)
+		if reason != "" {
+			return newError(atLine(v, line), "ics", map[string]any{"reason": reason}, opts)
+		}
+
+		var errs []error
+		for _, event := range events {
+			if reason := checkICalEvent(event); reason != "" {
+				errs = append(errs, newError(atLine(v, event.line), "ics_event", map[string]any{"event": event.index, "reason": reason}, opts))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// parseICalendar parses the components of an iCalendar file,
+// returning its events, or the reason it is malformed along
+// with the line of the problem (0 if it concerns the whole
+// file).
+func parseICalendar(data []byte) ([]icalEvent, string, int) {
+	var (
+		events   []icalEvent
+		stack    []string
+		calendar = map[string]bool{}
+		seen     bool
+	)
+	for _, line := range unfoldLines(data) {
+		if strings.TrimSpace(line.text) == "" {
+			continue
+		}
+
+		prop, ok := parseICalLine(line.text)
+		if !ok {
+			return nil, fmt.Sprintf("malformed line %d", line.line), line.line
+		}
+		if len(stack) == 0 && (seen || prop.name != "BEGIN" || prop.value != "VCALENDAR") {
+			return nil, "content outside VCALENDAR", line.line
+		}
+
+		switch prop.name {
+		case "BEGIN":
+			stack = append(stack, prop.value)
+			seen = true
+			if prop.value == "VEVENT" {
+				events = append(events, icalEvent{index: len(events) + 1, line: line.line, props: map[string]icalProperty{}, count: map[string]int{}})
+			}
+		case "END":
+			if stack[len(stack)-1] != prop.value {
+				return nil, fmt.Sprintf("unexpected END:%s on line %d", prop.value, line.line), line.line
+			}
+			stack = stack[:len(stack)-1]
+		default:
+			switch stack[len(stack)-1] {
+			case "VCALENDAR":
+				calendar[prop.name] = true
+			case "VEVENT":
+				event := &events[len(events)-1]
+				event.props[prop.name] = prop
+				event.count[prop.name]++
+			}
+		}
+	}
+
+	switch {
+	case !seen:
+		return nil, "missing VCALENDAR", 0
+	case len(stack) > 0:
+		return nil, fmt.Sprintf("unterminated %s", stack[len(stack)-1]), 0
+	case !calendar["VERSION"]:
+		return nil, "missing VERSION", 0
+	case !calendar["PRODID"]:
+		return nil, "missing PRODID", 0
+	}
+
+	return events, "", 0
+}
+
+// atLine returns a copy of the value positioned at the given
+// line of its file, keeping the file name. Values without a
+// position (see Value.At) are returned unchanged, so errors
+// are only positioned when the caller asks for it.
+func atLine[T any](v Value[T], line int) Value[T] {
+	if line > 0 && v.pos != (Position{}) {
+		v.pos = Position{File: v.pos.File, Line: line}
+	}
+
+	return v
+}
+
+// contentLine is an unfolded content line of an iCalendar or
+// vCard file, along with the 1-based line it starts on.
+type contentLine struct {
+	text string
+	line int
+}
+
+// unfoldLines splits iCalendar or vCard content into lines,
+// joining long lines that were folded onto continuation
+// lines starting with a space or tab.
+func unfoldLines(data []byte) []contentLine {
+	data = bytes.ReplaceAll(data, []byte("\r\n"), []byte("\n"))
+
+	var lines []contentLine
+	for i, text := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		if len(lines) > 0 && (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+
+		lines = append(lines, contentLine{text: text, line: i + 1})
+	}
+
+	return lines
+}
+
+// parseICalLine parses a single, unfolded content line, e.g.
+// "DTSTART;TZID=Europe/Sofia:20250601T100000".
+func parseICalLine(line string) (icalProperty, bool) {
+	line = strings.TrimSuffix(line, "\r")
+
+	head, value, ok := strings.Cut(line, ":")
+	if !ok || head == "" {
+		return icalProperty{}, false
+	}
+
+	name, rest, _ := strings.Cut(head, ";")
+	prop := icalProperty{name: strings.ToUpper(name), params: map[string]string{}, value: value}
+	if rest != "" {
+		for param := range strings.SplitSeq(rest, ";") {
+			key, val, ok := strings.Cut(param, "=")
+			if !ok {
+				return icalProperty{}, false
+			}
+			prop.params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+
+	return prop, true
+}
+
+// checkICalEvent checks the properties of an event, returning
+// the reason it is invalid, or "" if it is valid.
+func checkICalEvent(event icalEvent) string {
+	for _, name := range []string{"UID", "DTSTAMP", "DTSTART"} {
+		if event.count[name] == 0 {
+			return "missing " + name
+		}
+	}
+	for _, name := range []string{"UID", "DTSTAMP", "DTSTART", "DTEND", "DURATION"} {
+		if event.count[name] > 1 {
+			return "duplicate " + name
+		}
+	}
+
+	if !isICalDateTime(event.props["DTSTAMP"].value) {
+		return "invalid DTSTAMP"
+	}
+	start, ok := parseICalTime(event.props["DTSTART"])
+	if !ok {
+		return "invalid DTSTART"
+	}
+
+	end, hasEnd := event.props["DTEND"]
+	duration, hasDuration := event.props["DURATION"]
+	switch {
+	case hasEnd && hasDuration:
+		return "DTEND and DURATION cannot be combined"
+	case hasDuration && !icalDurationRegex.MatchString(duration.value):
+		return "invalid DURATION"
+	case hasEnd:
+		t, ok := parseICalTime(end)
+		if !ok {
+			return "invalid DTEND"
+		}
+		if t.Before(start) {
+			return "DTEND is before DTSTART"
+		}
+	}
+
+	if rrule, ok := event.props["RRULE"]; ok {
+		if reason := checkRRule(rrule.value); reason != "" {
+			return "invalid RRULE: " + reason
+		}
+	}
+
+	return ""
+}
+
+// parseICalTime parses a DATE or DATE-TIME property, in the
+// time zone given by its TZID parameter if it is known.
+func parseICalTime(prop icalProperty) (time.Time, bool) {
+	loc := time.UTC
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405", "20060102"} {
+		if t, err := time.ParseInLocation(layout, prop.value, loc); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
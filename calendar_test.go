@@ -0,0 +1,175 @@
+package valtra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestRRule(t *testing.T) {
+	t.Run("valid rules pass", func(t *testing.T) {
+		for _, rule := range []string{
+			"FREQ=DAILY",
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,WE;UNTIL=20251231T235959Z",
+			"FREQ=MONTHLY;BYDAY=-1FR;COUNT=10",
+			"FREQ=YEARLY;BYWEEKNO=20;BYDAY=MO",
+			"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			"FREQ=WEEKLY;INTERVAL=2;WKST=SU;UNTIL=20251231",
+		} {
+			v := valtra.Val(rule).Validate(valtra.RRule())
+			if !v.IsValid() {
+				t.Errorf("Expected validation to pass for %q, got errors: %v", rule, v.Errors())
+			}
+		}
+	})
+
+	t.Run("invalid rules fail with reason", func(t *testing.T) {
+		tests := map[string]string{
+			"":                                  "empty rule",
+			"BYDAY=MO":                          "missing FREQ",
+			"FREQ=FORTNIGHTLY":                  "invalid FREQ",
+			"FREQ=DAILY;COUNT=5;UNTIL=20251231": "UNTIL and COUNT cannot be combined",
+			"FREQ=DAILY;UNTIL=2025-12-31":       "invalid UNTIL",
+			"FREQ=WEEKLY;BYDAY=1MO":             "BYDAY ordinals cannot be used with FREQ=WEEKLY",
+			"FREQ=WEEKLY;BYDAY=XX":              "invalid BYDAY",
+			"FREQ=MONTHLY;BYWEEKNO=1":           "BYWEEKNO requires FREQ=YEARLY",
+			"FREQ=MONTHLY;BYMONTHDAY=32":        "invalid BYMONTHDAY",
+			"FREQ=DAILY;BYSETPOS=1":             "BYSETPOS requires another BYxxx part",
+			"FREQ=DAILY;FREQ=WEEKLY":            "duplicate FREQ",
+			"FREQ=DAILY;COLOUR=RED":             "unknown part COLOUR",
+			"FREQ=DAILY;INTERVAL=0":             "INTERVAL must be a positive integer",
+		}
+
+		for rule, reason := range tests {
+			v := valtra.Val(rule, "recurrence").Validate(valtra.RRule())
+			if v.IsValid() {
+				t.Errorf("Expected validation to fail for %q", rule)
+				continue
+			}
+
+			expected := "recurrence must be a valid recurrence rule (" + reason + ")"
+			if v.Errors()[0].Error() != expected {
+				t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+			}
+		}
+	})
+}
+
+// ics assembles an iCalendar file from the given lines.
+func ics(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func TestICalendar(t *testing.T) {
+	t.Run("valid calendar passes", func(t *testing.T) {
+		v := valtra.Val(ics(
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:-//Valtra//Test//EN",
+			"BEGIN:VEVENT",
+			"UID:1@example.com",
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART;TZID=Europe/Sofia:20250601T100000",
+			"DTEND;TZID=Europe/Sofia:20250601T110000",
+			"RRULE:FREQ=WEEKLY;BYDAY=SU",
+			"DESCRIPTION:A long description that has been",
+			"  folded onto two lines",
+			"BEGIN:VALARM",
+			"ACTION:DISPLAY",
+			"TRIGGER:-PT15M",
+			"END:VALARM",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:2@example.com",
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART;VALUE=DATE:20250602",
+			"DURATION:P1D",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		)).Validate(valtra.ICalendar())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("structural problems fail", func(t *testing.T) {
+		tests := map[string][]byte{
+			"missing VCALENDAR":                  []byte("\r\n"),
+			"missing PRODID":                     ics("BEGIN:VCALENDAR", "VERSION:2.0", "END:VCALENDAR"),
+			"unterminated VEVENT":                ics("BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:x", "BEGIN:VEVENT"),
+			"unexpected END:VCALENDAR on line 5": ics("BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:x", "BEGIN:VEVENT", "END:VCALENDAR"),
+			"malformed line 3":                   ics("BEGIN:VCALENDAR", "VERSION:2.0", "garbage", "END:VCALENDAR"),
+			"content outside VCALENDAR":          ics("BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:x", "END:VCALENDAR", "X-EXTRA:1"),
+		}
+
+		for reason, data := range tests {
+			v := valtra.Val(data, "calendar").Validate(valtra.ICalendar())
+			if v.IsValid() {
+				t.Errorf("Expected validation to fail with %q", reason)
+				continue
+			}
+
+			expected := "calendar must be a valid iCalendar file (" + reason + ")"
+			if v.Errors()[0].Error() != expected {
+				t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+			}
+		}
+	})
+
+	t.Run("each invalid event is reported", func(t *testing.T) {
+		v := valtra.Val(ics(
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:x",
+			"BEGIN:VEVENT",
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART:20250601T100000Z",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:2",
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART:20250601T100000Z",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:3",
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART:20250601T100000Z",
+			"DTEND:20250601T090000Z",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		), "calendar").Validate(valtra.ICalendar())
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail")
+		}
+
+		expected := "calendar event 1 is invalid (missing UID)\ncalendar event 3 is invalid (DTEND is before DTSTART)"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("positioned values report lines", func(t *testing.T) {
+		v := valtra.Val(ics(
+			"BEGIN:VCALENDAR",
+			"VERSION:2.0",
+			"PRODID:x",
+			"BEGIN:VEVENT",
+			"UID:1",
+			"DESCRIPTION:folded",
+			" continuation",
+			"DTSTAMP:20250101T000000Z",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"DTSTAMP:20250101T000000Z",
+			"DTSTART:20250601T100000Z",
+			"END:VEVENT",
+			"END:VCALENDAR",
+		), "calendar").At(valtra.Position{File: "team.ics"}).Validate(valtra.ICalendar())
+
+		expected := "team.ics:4: calendar event 1 is invalid (missing DTSTART)\nteam.ics:10: calendar event 2 is invalid (missing UID)"
+		if v.IsValid() || v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %v", expected, v.Errors())
+		}
+	})
+}
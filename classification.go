@@ -0,0 +1,126 @@
+package valtra
+
+import "fmt"
+
+// Classification is the sensitivity class of a value, which
+// decides how it may be handled.
+type Classification int
+
+const (
+	// ClassPublic marks a value that may be handled freely.
+	// This is the default.
+	ClassPublic Classification = iota
+	// ClassPII marks personal data, such as names and email
+	// addresses.
+	ClassPII
+	// ClassSecret marks secrets, such as passwords and tokens.
+	ClassSecret
+)
+
+// String returns the name of the classification.
+func (c Classification) String() string {
+	switch c {
+	case ClassPII:
+		return "pii"
+	case ClassSecret:
+		return "secret"
+	default:
+		return "public"
+	}
+}
+
+// Classify returns a copy of the value with the given
+// sensitivity class.
+//
+// Classified values never appear in error messages: the
+// {value} placeholder renders as Redacted, and errors carry
+// the classification for loggers to act on. They are also
+// redacted from captured snapshots, and a Collector's
+// HandlingPolicy can enforce further rules, such as masking
+// before Collect.
+//
+// Example:
+//
+//	ssn := valtra.Val(input.SSN, "ssn").Classify(valtra.ClassPII).
+//	    Validate(valtra.Match(`^\d{3}-\d{2}-\d{4}$`)).
+//	    Mask(valtra.Encrypt(keys)).
+//	    Collect(c)
+func (v Value[T]) Classify(class Classification) Value[T] {
+	v.class = class
+	return v
+}
+
+// Classification returns the value's sensitivity class.
+func (v Value[T]) Classification() Classification {
+	return v.class
+}
+
+// Mask applies the transformations, as with Transform, and
+// marks the value as masked if they all succeed, for handling
+// policies such as MaskBeforeCollect.
+//
+// The transformations should make the value safe to handle,
+// e.g. Encrypt, Tokenize or Pseudonymize.
+//
+// Example:
+//
+//	card := valtra.Val(input.Card, "card").Classify(valtra.ClassSecret).Mask(valtra.Tokenize(vault))
+func (v Value[T]) Mask(transformations ...func(Value[T]) (T, error)) Value[T] {
+	failed := len(v.errs)
+	v = v.Transform(transformations...)
+	if len(v.errs) == failed && !v.stopped() {
+		v.masked = true
+	}
+
+	return v
+}
+
+// Handling describes a classified value being handled, for
+// a HandlingPolicy to check.
+type Handling struct {
+	// Field is the name of the value.
+	Field string
+	// Class is the value's sensitivity class.
+	Class Classification
+	// Masked reports whether the value was masked with Mask.
+	Masked bool
+}
+
+// HandlingPolicy enforces handling rules for classified
+// values. It is called when a classified value is collected,
+// and returns an error if the handling breaks its rules,
+// which is then collected along with the value's errors.
+type HandlingPolicy func(Handling) error
+
+// WithHandlingPolicy sets the policy enforced when the
+// collector collects classified values. Prefixed collectors
+// inherit it.
+//
+// Example:
+//
+//	c := valtra.NewCollector(valtra.WithHandlingPolicy(valtra.MaskBeforeCollect(valtra.ClassSecret)))
+func WithHandlingPolicy(policy HandlingPolicy) CollectorOption {
+	return func(c *Collector) {
+		c.policy = policy
+	}
+}
+
+// MaskBeforeCollect returns a policy that requires values of
+// the given classes to be masked with Mask before they are
+// collected, so that they cannot reach storage or logs in the
+// clear.
+//
+// Violations are reported as errors that are not validation
+// errors, as they are bugs in the handler rather than bad
+// input.
+func MaskBeforeCollect(classes ...Classification) HandlingPolicy {
+	return func(h Handling) error {
+		for _, class := range classes {
+			if h.Class == class && !h.Masked {
+				return fmt.Errorf("valtra: %s is %s and must be masked before it is collected", h.Field, h.Class)
+			}
+		}
+
+		return nil
+	}
+}
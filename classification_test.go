@@ -0,0 +1,94 @@
+package valtra_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestClassify(t *testing.T) {
+	t.Run("classified values are kept out of messages", func(t *testing.T) {
+		v := valtra.Val("hunter2", "password").Classify(valtra.ClassSecret).
+			Validate(valtra.MinLengthString(10, valtra.WithMessage("{name} {value} is too short")))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.FirstError(), &ve) || ve.Classification != valtra.ClassSecret {
+			t.Fatalf("Expected a classified error, got %v", v.Errors())
+		}
+		if want := "password [REDACTED] is too short"; ve.Error() != want {
+			t.Errorf("Expected %q, got %q", want, ve.Error())
+		}
+	})
+
+	t.Run("public values are shown", func(t *testing.T) {
+		v := valtra.Val("bob", "name").Validate(valtra.MinLengthString(10, valtra.WithMessage("{value} is too short")))
+		if v.FirstError().Error() != "bob is too short" {
+			t.Errorf("Expected the value in the message, got %v", v.FirstError())
+		}
+	})
+
+	t.Run("classification survives conversion", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("x", "pin").Classify(valtra.ClassSecret), valtra.ParseInt())
+		if v.Classification() != valtra.ClassSecret || strings.Contains(v.FirstError().Error(), "x") {
+			t.Errorf("Expected a secret value with a redacted error, got %v, %v", v.Classification(), v.Errors())
+		}
+	})
+
+	t.Run("classified values are redacted from snapshots", func(t *testing.T) {
+		var snapshot valtra.Snapshot
+		c := valtra.NewCollector().Capture(&valtra.Capture{Rate: 1, Hook: func(s valtra.Snapshot) { snapshot = s }})
+		valtra.Val("Bob", "nickname").Classify(valtra.ClassPII).Validate(valtra.MinLengthString(5)).Collect(c)
+		c.Report()
+
+		if snapshot.Fields["nickname"] != valtra.Redacted {
+			t.Errorf("Expected the nickname to be redacted, got %v", snapshot.Fields)
+		}
+	})
+}
+
+func TestMaskBeforeCollect(t *testing.T) {
+	policy := valtra.WithHandlingPolicy(valtra.MaskBeforeCollect(valtra.ClassSecret))
+	redact := func(v valtra.Value[string]) (string, error) { return "****", nil }
+
+	t.Run("unmasked values break the policy", func(t *testing.T) {
+		c := valtra.NewCollector(policy)
+		valtra.Val("4111111111111111", "card").Classify(valtra.ClassSecret).Collect(c)
+
+		var ve *valtra.ValidationError
+		if c.IsValid() || errors.As(c.Errors()[0], &ve) {
+			t.Errorf("Expected a policy violation, got %v", c.Errors())
+		}
+	})
+
+	t.Run("masked values pass", func(t *testing.T) {
+		c := valtra.NewCollector(policy)
+		got := valtra.Val("4111111111111111", "card").Classify(valtra.ClassSecret).Mask(redact).Collect(c)
+
+		if !c.IsValid() || got != "****" {
+			t.Errorf("Expected the masked value, got %q (%v)", got, c.Errors())
+		}
+	})
+
+	t.Run("failed masking does not count", func(t *testing.T) {
+		failing := func(v valtra.Value[string]) (string, error) { return "", errors.New("vault unavailable") }
+
+		c := valtra.NewCollector(policy)
+		valtra.Val("4111111111111111", "card").Classify(valtra.ClassSecret).Mask(failing).Collect(c)
+
+		if len(c.Errors()) != 2 {
+			t.Errorf("Expected the masking error and a policy violation, got %v", c.Errors())
+		}
+	})
+
+	t.Run("other classes and prefixed collectors", func(t *testing.T) {
+		c := valtra.NewCollector(policy)
+		valtra.Val("bob@example.com", "email").Classify(valtra.ClassPII).Collect(c)
+		valtra.Val("hunter2", "password").Classify(valtra.ClassSecret).Collect(c.WithPrefix("user"))
+
+		if len(c.Errors()) != 1 || !strings.Contains(c.Errors()[0].Error(), "password is secret") {
+			t.Errorf("Expected only the password to break the policy, got %v", c.Errors())
+		}
+	})
+}
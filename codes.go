@@ -0,0 +1,289 @@
+package valtra
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// countryCodes holds the officially assigned ISO 3166-1
+// alpha-2 country codes.
+var countryCodes = codeSet(`
+	AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+	CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO
+	FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE
+	JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO
+	MP MQ MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW
+	PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM
+	TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW
+`)
+
+// currencyCodes holds the active ISO 4217 currency codes,
+// including funds and precious metals.
+var currencyCodes = codeSet(`
+	AED AFN ALL AMD AOA ARS AUD AWG AZN BAM BBD BDT BGN BHD BIF BMD BND BOB BOV BRL BSD BTN BWP BYN BZD CAD CDF
+	CHE CHF CHW CLF CLP CNY COP COU CRC CUP CVE CZK DJF DKK DOP DZD EGP ERN ETB EUR FJD FKP GBP GEL GHS GIP GMD
+	GNF GTQ GYD HKD HNL HTG HUF IDR ILS INR IQD IRR ISK JMD JOD JPY KES KGS KHR KMF KPW KRW KWD KYD KZT LAK LBP
+	LKR LRD LSL LYD MAD MDL MGA MKD MMK MNT MOP MRU MUR MVR MWK MXN MXV MYR MZN NAD NGN NIO NOK NPR NZD OMR PAB
+	PEN PGK PHP PKR PLN PYG QAR RON RSD RUB RWF SAR SBD SCR SDG SEK SGD SHP SLE SOS SRD SSP STN SVC SYP SZL THB
+	TJS TMT TND TOP TRY TTD TWD TZS UAH UGX USD USN UYI UYU UYW UZS VED VES VND VUV WST XAF XAG XAU XBA XBB XBC
+	XBD XCD XCG XDR XOF XPD XPF XPT XSU XTS XUA XXX YER ZAR ZMW ZWG
+`)
+
+// languageCodes holds the ISO 639-1 two-letter language codes.
+var languageCodes = codeSet(`
+	aa ab ae af ak am an ar as av ay az ba be bg bi bm bn bo br bs ca ce ch co cr cs cu cv cy da de dv dz ee el
+	en eo es et eu fa ff fi fj fo fr fy ga gd gl gn gu gv ha he hi ho hr ht hu hy hz ia id ie ig ii ik io is it
+	iu ja jv ka kg ki kj kk kl km kn ko kr ks ku kv kw ky la lb lg li ln lo lt lu lv mg mh mi mk ml mn mr ms mt
+	my na nb nd ne ng nl nn no nr nv ny oc oj om or os pa pi pl ps pt qu rm rn ro ru rw sa sc sd se sg si sk sl
+	sm sn so sq sr ss st su sv sw ta te tg th ti tk tl tn to tr ts tt tw ty ug uk ur uz ve vi vo wa wo xh yi yo
+	za zh zu
+`)
+
+// codeSet returns the set of whitespace-separated codes.
+func codeSet(codes string) map[string]struct{} {
+	set := map[string]struct{}{}
+	for code := range strings.FieldsSeq(codes) {
+		set[code] = struct{}{}
+	}
+
+	return set
+}
+
+// codeError returns the error for a code that is not in the
+// set, suggesting the code in the other case if that is in it,
+// e.g. "GB" for "gb".
+func codeError[T any](v Value[T], code, value string, set map[string]struct{}, opts []Option) error {
+	var params map[string]any
+	for _, s := range []string{strings.ToUpper(value), strings.ToLower(value)} {
+		if _, ok := set[s]; ok && s != value {
+			params = map[string]any{"suggestion": s}
+		}
+	}
+
+	return newError(v, code, params, opts)
+}
+
+// CountryCodeISO3166 returns a validation that ensures the
+// value is an officially assigned ISO 3166-1 alpha-2 country
+// code, such as "GB" or "BG".
+//
+// Codes must be upper case. A lower case code suggests the
+// upper case one, e.g. "country must be a valid ISO 3166-1
+// country code (did you mean GB?)" for "gb".
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Country, "country").Validate(valtra.CountryCodeISO3166())
+func CountryCodeISO3166(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if _, ok := countryCodes[v.value]; !ok {
+			return codeError(v, "country_code", v.value, countryCodes, opts)
+		}
+
+		return nil
+	}
+}
+
+// CurrencyISO4217 returns a validation that ensures the value
+// is an active ISO 4217 currency code, such as "EUR".
+//
+// Codes must be upper case; as with CountryCodeISO3166, a
+// lower case code suggests the upper case one.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Currency, "currency").Validate(valtra.CurrencyISO4217())
+func CurrencyISO4217(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if _, ok := currencyCodes[v.value]; !ok {
+			return codeError(v, "currency_code", v.value, currencyCodes, opts)
+		}
+
+		return nil
+	}
+}
+
+// LanguageBCP47 returns a validation that ensures the value is
+// a well-formed BCP 47 language tag, such as "en", "pt-BR" or
+// "zh-Hant-TW".
+//
+// Tags are checked subtag by subtag, case-insensitively: a
+// language (a known ISO 639-1 code, or any three letters),
+// optional extended language, script, region (a known ISO
+// 3166-1 code, or three digits), variant, extension and
+// private use subtags. Grandfathered tags such as "i-klingon",
+// and tags made only of private use subtags, are not allowed.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Locale, "locale").Validate(valtra.LanguageBCP47())
+func LanguageBCP47(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !isLanguageTag(v.value) {
+			return newError(v, "language_tag", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// isLanguageTag reports whether the tag is a well-formed BCP 47
+// language tag, following the "langtag" production of RFC 5646.
+func isLanguageTag(tag string) bool {
+	subtags := strings.Split(strings.ToLower(tag), "-")
+
+	// Language, with up to three extended language subtags
+	lang := subtags[0]
+	switch {
+	case len(lang) == 2 && isAlpha(lang):
+		if _, ok := languageCodes[lang]; !ok {
+			return false
+		}
+	case len(lang) == 3 && isAlpha(lang):
+	default:
+		return false
+	}
+	subtags = subtags[1:]
+	for i := 0; i < 3 && len(subtags) > 0 && len(subtags[0]) == 3 && isAlpha(subtags[0]); i++ {
+		subtags = subtags[1:]
+	}
+
+	// Script
+	if len(subtags) > 0 && len(subtags[0]) == 4 && isAlpha(subtags[0]) {
+		subtags = subtags[1:]
+	}
+
+	// Region
+	if len(subtags) > 0 {
+		region := subtags[0]
+		if len(region) == 2 && isAlpha(region) {
+			if _, ok := countryCodes[strings.ToUpper(region)]; !ok {
+				return false
+			}
+			subtags = subtags[1:]
+		} else if len(region) == 3 && strings.Trim(region, "0123456789") == "" {
+			subtags = subtags[1:]
+		}
+	}
+
+	// Variants
+	for len(subtags) > 0 && isVariantSubtag(subtags[0]) {
+		subtags = subtags[1:]
+	}
+
+	// Extensions and private use, the latter coming last
+	for len(subtags) > 0 {
+		singleton := subtags[0]
+		subtags = subtags[1:]
+		if len(singleton) != 1 || !isAlphanum(singleton) {
+			return false
+		}
+
+		minLen := 2
+		if singleton == "x" {
+			minLen = 1
+		}
+
+		n := 0
+		for len(subtags) > 0 && len(subtags[0]) >= minLen && len(subtags[0]) <= 8 && isAlphanum(subtags[0]) {
+			subtags = subtags[1:]
+			n++
+		}
+		if n == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isVariantSubtag reports whether the subtag is a BCP 47
+// variant: 5 to 8 alphanumerics, or a digit followed by 3
+// alphanumerics.
+func isVariantSubtag(s string) bool {
+	if !isAlphanum(s) {
+		return false
+	}
+
+	return len(s) >= 5 && len(s) <= 8 || len(s) == 4 && s[0] >= '0' && s[0] <= '9'
+}
+
+// isAlpha reports whether s is made of ASCII letters.
+func isAlpha(s string) bool {
+	for i := range len(s) {
+		if !isASCIILetter(s[i]) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// isAlphanum reports whether s is made of ASCII letters and
+// digits.
+func isAlphanum(s string) bool {
+	for i := range len(s) {
+		if !isASCIILetter(s[i]) && (s[i] < '0' || s[i] > '9') {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// timezones caches the names time.LoadLocation has found.
+// Unknown names are not cached, so arbitrary input cannot grow
+// the cache.
+var timezones sync.Map
+
+// Timezone returns a validation that ensures the value is an
+// IANA timezone name known to the tz database, such as
+// "Europe/London" or "UTC".
+//
+// Names are looked up with time.LoadLocation, so the system's
+// tz database is used unless the program embeds one by
+// importing time/tzdata. "Local" and the empty name are not
+// allowed. Known names are cached.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Timezone, "timezone").Validate(valtra.Timezone())
+func Timezone(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !isTimezone(v.value) {
+			return newError(v, "timezone", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// isTimezone reports whether the name is a known timezone.
+func isTimezone(name string) bool {
+	if name == "" || name == "Local" {
+		return false
+	}
+	if _, ok := timezones.Load(name); ok {
+		return true
+	}
+
+	if _, err := time.LoadLocation(name); err != nil {
+		return false
+	}
+	timezones.Store(name, struct{}{})
+
+	return true
+}
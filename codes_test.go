@@ -0,0 +1,61 @@
+package valtra_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestCodes(t *testing.T) {
+	tests := []struct {
+		name  string
+		rule  func(valtra.Value[string]) error
+		input string
+		valid bool
+	}{
+		{"country", valtra.CountryCodeISO3166(), "GB", true},
+		{"unassigned country", valtra.CountryCodeISO3166(), "XX", false},
+		{"lower case country", valtra.CountryCodeISO3166(), "gb", false},
+		{"alpha-3 country", valtra.CountryCodeISO3166(), "GBR", false},
+		{"currency", valtra.CurrencyISO4217(), "EUR", true},
+		{"unknown currency", valtra.CurrencyISO4217(), "ABC", false},
+		{"language", valtra.LanguageBCP47(), "en", true},
+		{"language and region", valtra.LanguageBCP47(), "pt-BR", true},
+		{"language, script and region", valtra.LanguageBCP47(), "zh-Hant-TW", true},
+		{"numeric region", valtra.LanguageBCP47(), "es-419", true},
+		{"variant", valtra.LanguageBCP47(), "de-CH-1996", true},
+		{"extension", valtra.LanguageBCP47(), "en-US-u-ca-buddhist", true},
+		{"private use", valtra.LanguageBCP47(), "en-x-a-private", true},
+		{"three letter language", valtra.LanguageBCP47(), "yue-HK", true},
+		{"unknown language", valtra.LanguageBCP47(), "qq", false},
+		{"unknown region", valtra.LanguageBCP47(), "en-XX", false},
+		{"single letter", valtra.LanguageBCP47(), "e", false},
+		{"trailing hyphen", valtra.LanguageBCP47(), "en-", false},
+		{"empty extension", valtra.LanguageBCP47(), "en-u", false},
+		{"grandfathered", valtra.LanguageBCP47(), "i-klingon", false},
+		{"only private use", valtra.LanguageBCP47(), "x-foo", false},
+		{"underscore", valtra.LanguageBCP47(), "en_GB", false},
+		{"timezone", valtra.Timezone(), "Europe/London", true},
+		{"utc", valtra.Timezone(), "UTC", true},
+		{"unknown timezone", valtra.Timezone(), "Mars/Olympus", false},
+		{"local", valtra.Timezone(), "Local", false},
+		{"empty timezone", valtra.Timezone(), "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.input).Validate(tt.rule)
+			if v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v for %q, got errors: %v", tt.valid, tt.input, v.Errors())
+			}
+		})
+	}
+
+	t.Run("lower case codes suggest the upper case one", func(t *testing.T) {
+		v := valtra.Val("gb", "country").Validate(valtra.CountryCodeISO3166())
+		want := "country must be a valid ISO 3166-1 country code (did you mean GB?)"
+		if len(v.Errors()) != 1 || v.Errors()[0].Error() != want {
+			t.Errorf("Expected %q, got %v", want, v.Errors())
+		}
+	})
+}
@@ -1,5 +1,12 @@
 package valtra
 
+import (
+	"errors"
+	"maps"
+	"slices"
+	"sync"
+)
+
 // Collector accumulates validation errors from multiple
 // Value instances.
 //
@@ -8,9 +15,60 @@ package valtra
 // at once, instead of manually checking each value result.
 //
 // Collectors are created with NewCollector and are updated
-// via the Collect method on a Value.
+// via the Collect method on a Value. They are safe for
+// concurrent use, so independent parts of an input can be
+// validated in parallel goroutines.
 type Collector struct {
+	mu sync.Mutex
+
 	errs []error
+	// owners holds the name of the field that produced each
+	// error in errs.
+	owners []string
+
+	// warnings holds the warnings of the collected values,
+	// which do not make the collector invalid.
+	warnings []error
+
+	capture  *Capture
+	captured []capturedField
+
+	// parent is the collector that a prefixed collector
+	// forwards to, along with the prefix it adds.
+	parent *Collector
+	prefix string
+
+	// maxErrors caps the number of errors kept, with 0
+	// meaning no limit.
+	maxErrors int
+
+	// policy enforces handling rules for classified values.
+	policy HandlingPolicy
+
+	// provenance holds the provenance of the collected values
+	// that recorded one, by name.
+	provenance map[string]Provenance
+}
+
+// CollectorOption configures a Collector created with
+// NewCollector.
+type CollectorOption func(*Collector)
+
+// MaxErrors limits the number of errors a collector keeps to
+// n. Errors collected once the limit is reached are
+// discarded, which bounds the memory used by pathological
+// inputs with many bad elements. The collector stays invalid
+// either way.
+//
+// A limit of 0 or less means no limit.
+//
+// Example:
+//
+//	c := valtra.NewCollector(valtra.MaxErrors(10))
+func MaxErrors(n int) CollectorOption {
+	return func(c *Collector) {
+		c.maxErrors = max(n, 0)
+	}
 }
 
 // NewCollector creates and returns a new Collector with
@@ -33,14 +91,200 @@ type Collector struct {
 //	if !c.IsValid() {
 //	    return c.Errors()
 //	}
-func NewCollector() *Collector {
-	return &Collector{errs: []error{}}
+//
+// Options such as MaxErrors can be provided to configure the
+// collector.
+func NewCollector(opts ...CollectorOption) *Collector {
+	c := &Collector{errs: []error{}}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Errors returns all accumulated validation errors.
 // Returns an empty slice if no errors were collected.
 func (c *Collector) Errors() []error {
-	return c.errs
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.errs)
+}
+
+// Err returns an error joining all accumulated validation
+// errors, or nil if no errors were collected.
+//
+// The result implements Unwrap() []error, so errors.As and
+// errors.Is search every collected error, e.g. to map them to
+// an API response further up the stack, or to pick out an
+// ErrInvalidRule or a remote validator's timeout.
+func (c *Collector) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return errors.Join(c.errs...)
+}
+
+// FirstError returns the first accumulated validation error,
+// or nil if no errors were collected.
+//
+// It suits APIs that only report a single problem at a time.
+func (c *Collector) FirstError() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.errs) == 0 {
+		return nil
+	}
+
+	return c.errs[0]
+}
+
+// Warnings returns the warnings of all collected values, such
+// as the failures of rules applied with Value.Warn. Warnings
+// do not make the collector invalid.
+//
+// Example:
+//
+//	user := valtra.Val(input.Username, "username").Warn(notDeprecatedUsername).Collect(c)
+//	for _, w := range c.Warnings() {
+//	    resp.Warnings = append(resp.Warnings, w.Error())
+//	}
+func (c *Collector) Warnings() []error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return slices.Clone(c.warnings)
+}
+
+// ErrorsByField returns all accumulated validation errors,
+// grouped by the name of the value that produced them.
+//
+// Errors from values collected through a prefixed collector
+// (see WithPrefix) are grouped under the prefixed name, e.g.
+// "address.city".
+//
+// Example:
+//
+//	for field, errs := range c.ErrorsByField() {
+//	    fmt.Println(field, errs)
+//	}
+func (c *Collector) ErrorsByField() map[string][]error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	byField := make(map[string][]error)
+	for i, err := range c.errs {
+		byField[c.owners[i]] = append(byField[c.owners[i]], err)
+	}
+
+	return byField
+}
+
+// WithPrefix returns a collector for a nested part of the
+// input, such as an embedded struct, whose collected values
+// are also added to c with their names prefixed, e.g.
+// "address.city" for a value named "city" collected with the
+// prefix "address".
+//
+// The prefix only affects the names used by ErrorsByField
+// and captured snapshots; error messages are unchanged. The
+// returned collector reports only its own errors.
+//
+// Example:
+//
+//	c := valtra.NewCollector()
+//	ac := c.WithPrefix("address")
+//	address := Address{
+//	    City: valtra.Val(input.Address.City, "city").Validate(valtra.Required[string]()).Collect(ac),
+//	}
+//	// c.ErrorsByField()["address.city"]
+func (c *Collector) WithPrefix(prefix string) *Collector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return &Collector{
+		errs:      []error{},
+		capture:   c.capture,
+		parent:    c,
+		prefix:    prefix + ".",
+		maxErrors: c.maxErrors,
+		policy:    c.policy,
+	}
+}
+
+// add adds the errors produced by the named field, forwarding
+// them to the parent collector, if any.
+func (c *Collector) add(field string, errs ...error) {
+	c.mu.Lock()
+	for _, err := range errs {
+		if c.maxErrors > 0 && len(c.errs) >= c.maxErrors {
+			break
+		}
+		c.errs = append(c.errs, err)
+		c.owners = append(c.owners, field)
+	}
+	c.mu.Unlock()
+
+	if c.parent != nil {
+		c.parent.add(c.prefix+field, errs...)
+	}
+}
+
+// warn adds the warnings of a collected value, forwarding
+// them to the parent collector, if any. Warnings are capped
+// by MaxErrors like errors are.
+func (c *Collector) warn(warnings ...error) {
+	c.mu.Lock()
+	for _, w := range warnings {
+		if c.maxErrors > 0 && len(c.warnings) >= c.maxErrors {
+			break
+		}
+		c.warnings = append(c.warnings, w)
+	}
+	c.mu.Unlock()
+
+	if c.parent != nil {
+		c.parent.warn(warnings...)
+	}
+}
+
+// capturing reports whether a Capture is attached to the
+// collector or one of its parents, i.e. whether values are
+// recorded at all, so that they are not boxed needlessly.
+func (c *Collector) capturing() bool {
+	c.mu.Lock()
+	capturing := c.capture != nil
+	c.mu.Unlock()
+
+	return capturing || c.parent != nil && c.parent.capturing()
+}
+
+// record records the value of the named field for captured
+// snapshots, if a Capture is attached.
+func (c *Collector) record(field string, value any, class Classification) {
+	c.mu.Lock()
+	if c.capture != nil {
+		c.captured = append(c.captured, capturedField{name: field, value: value, class: class})
+	}
+	c.mu.Unlock()
+
+	if c.parent != nil {
+		c.parent.record(c.prefix+field, value, class)
+	}
+}
+
+// enforce checks the handling of a classified value against
+// the collector's policy, collecting the violation, if any.
+func (c *Collector) enforce(h Handling) {
+	if c.policy == nil {
+		return
+	}
+
+	if err := c.policy(h); err != nil {
+		c.add(h.Field, err)
+	}
 }
 
 // IsValid returns true if no validation errors have been
@@ -49,5 +293,94 @@ func (c *Collector) Errors() []error {
 // This is a convenience method equivalent to checking
 // len(v.Errors()) == 0.
 func (c *Collector) IsValid() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	return len(c.errs) == 0
 }
+
+// Merge adds all errors (and warnings and captured values)
+// accumulated by other to the collector, keeping their field
+// names.
+//
+// It suits collecting independent parts of an input into
+// separate collectors, e.g. in parallel goroutines, and
+// combining them at the end. Merging a collector created from
+// c with WithPrefix, directly or not, does nothing, as its
+// values are already added to c.
+//
+// Example:
+//
+//	c := valtra.NewCollector()
+//	c.Merge(validateItems(input.Items))
+//	c.Merge(validateAddress(input.Address))
+func (c *Collector) Merge(other *Collector) {
+	if other == nil {
+		return
+	}
+	for p := other; p != nil; p = p.parent {
+		if p == c {
+			return
+		}
+	}
+
+	other.mu.Lock()
+	errs, owners := slices.Clone(other.errs), slices.Clone(other.owners)
+	warnings := slices.Clone(other.warnings)
+	captured := slices.Clone(other.captured)
+	provenance := maps.Clone(other.provenance)
+	other.mu.Unlock()
+
+	for i, err := range errs {
+		c.add(owners[i], err)
+	}
+	if len(warnings) > 0 {
+		c.warn(warnings...)
+	}
+	for _, f := range captured {
+		c.record(f.name, f.value, f.class)
+	}
+	for field, p := range provenance {
+		c.trace(field, p)
+	}
+}
+
+// Capture attaches a Capture to the collector, so that the
+// values of all collected fields are recorded and a sampled,
+// redacted snapshot is passed to the capture's hook when
+// Report is called on an invalid collector.
+//
+// It returns the collector to allow chaining.
+//
+// Example:
+//
+//	c := valtra.NewCollector().Capture(capture)
+//	user := User{
+//		Email: valtra.Val(input.Email, "email").Validate(valtra.Email()).Collect(c),
+//	}
+//	c.Report()
+func (c *Collector) Capture(cp *Capture) *Collector {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.capture = cp
+	return c
+}
+
+// Report passes a snapshot of the collected fields and
+// errors to the attached Capture's hook, if the collector is
+// invalid and the payload is sampled.
+//
+// It does nothing if no Capture is attached.
+func (c *Collector) Report() {
+	c.mu.Lock()
+	if c.capture == nil || c.capture.Hook == nil || len(c.errs) == 0 || !c.capture.sampled() {
+		c.mu.Unlock()
+		return
+	}
+
+	cp, snapshot := c.capture, c.capture.snapshot(c.captured, c.errs, c.owners)
+	c.mu.Unlock()
+
+	cp.Hook(snapshot)
+}
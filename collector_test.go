@@ -1,6 +1,9 @@
 package valtra_test
 
 import (
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/bobch27/valtra-go"
@@ -53,3 +56,201 @@ func TestCollector(t *testing.T) {
 		}
 	})
 }
+
+func TestCollectorErrorsByField(t *testing.T) {
+	t.Run("errors are grouped by value name", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("", "email").Validate(valtra.Required[string](), valtra.Email()).Collect(c)
+		valtra.Val(16, "age").Validate(valtra.Min(18)).Collect(c)
+		valtra.Val("bobby", "name").Validate(valtra.Required[string]()).Collect(c)
+
+		byField := c.ErrorsByField()
+		if len(byField) != 2 || len(byField["email"]) != 2 || len(byField["age"]) != 1 {
+			t.Errorf("Unexpected grouping: %v", byField)
+		}
+	})
+
+	t.Run("prefixed collector groups under prefix", func(t *testing.T) {
+		c := valtra.NewCollector()
+		ac := c.WithPrefix("address")
+		valtra.Val("", "city").Validate(valtra.Required[string]()).Collect(ac)
+		valtra.Val("", "name").Validate(valtra.Required[string]()).Collect(c)
+
+		if errs := c.ErrorsByField()["address.city"]; len(errs) != 1 || errs[0].Error() != "city is required" {
+			t.Errorf("Expected address.city error, got %v", c.ErrorsByField())
+		}
+		if len(c.Errors()) != 2 {
+			t.Errorf("Expected 2 errors in parent, got %d", len(c.Errors()))
+		}
+		if len(ac.Errors()) != 1 || len(ac.ErrorsByField()["city"]) != 1 {
+			t.Errorf("Expected prefixed collector to hold only its own errors, got %v", ac.ErrorsByField())
+		}
+	})
+
+	t.Run("prefixes nest", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("", "street").Validate(valtra.Required[string]()).Collect(c.WithPrefix("user").WithPrefix("address"))
+
+		if _, ok := c.ErrorsByField()["user.address.street"]; !ok {
+			t.Errorf("Expected user.address.street error, got %v", c.ErrorsByField())
+		}
+	})
+}
+
+func TestCollectorMerge(t *testing.T) {
+	t.Run("errors are merged with field names", func(t *testing.T) {
+		items := valtra.NewCollector()
+		valtra.Val(0, "quantity").Validate(valtra.Positive[int]()).Collect(items)
+
+		c := valtra.NewCollector()
+		valtra.Val("", "name").Validate(valtra.Required[string]()).Collect(c)
+		c.Merge(items)
+
+		if len(c.Errors()) != 2 || len(c.ErrorsByField()["quantity"]) != 1 {
+			t.Errorf("Unexpected merged errors: %v", c.ErrorsByField())
+		}
+	})
+
+	t.Run("merging itself or nil is a no-op", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("", "name").Validate(valtra.Required[string]()).Collect(c)
+		c.Merge(c)
+		c.Merge(nil)
+
+		if len(c.Errors()) != 1 {
+			t.Errorf("Expected 1 error, got %d", len(c.Errors()))
+		}
+	})
+
+	t.Run("merging a prefixed collector of its own is a no-op", func(t *testing.T) {
+		c := valtra.NewCollector()
+		lines := c.WithPrefix("address").WithPrefix("lines")
+		valtra.Val("", "first").Validate(valtra.Required[string]()).Collect(lines)
+		c.Merge(lines)
+
+		if len(c.Errors()) != 1 || len(c.ErrorsByField()["address.lines.first"]) != 1 {
+			t.Errorf("Expected the error once, got %v", c.ErrorsByField())
+		}
+	})
+}
+
+func TestCollectorWarnings(t *testing.T) {
+	c := valtra.NewCollector()
+	ac := c.WithPrefix("address")
+	valtra.Val("Main Street", "street").Warn(valtra.MaxLengthString(5)).Collect(ac)
+
+	other := valtra.NewCollector()
+	valtra.Val("bobby", "username").Warn(valtra.OneOfValues("admin")).Collect(other)
+	c.Merge(other)
+
+	if !c.IsValid() || len(c.Warnings()) != 2 || len(ac.Warnings()) != 1 {
+		t.Errorf("Unexpected warnings: %v, %v", c.Warnings(), ac.Warnings())
+	}
+}
+
+func TestCollectorConcurrency(t *testing.T) {
+	c := valtra.NewCollector()
+
+	var wg sync.WaitGroup
+	for i := range 50 {
+		wg.Go(func() {
+			valtra.Val(i, "item").Validate(valtra.Min(100)).Collect(c)
+			_ = c.IsValid()
+		})
+	}
+	wg.Wait()
+
+	if len(c.Errors()) != 50 {
+		t.Errorf("Expected 50 errors, got %d", len(c.Errors()))
+	}
+}
+
+func TestCollectorFirstError(t *testing.T) {
+	t.Run("no errors", func(t *testing.T) {
+		if err := valtra.NewCollector().FirstError(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+
+	t.Run("first of many errors", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("", "name").Validate(valtra.Required[string]()).Collect(c)
+		valtra.Val(0, "age").Validate(valtra.Positive[int]()).Collect(c)
+
+		if err := c.FirstError(); err == nil || err.Error() != "name is required" {
+			t.Errorf("Expected name error, got %v", err)
+		}
+	})
+}
+
+func TestMaxErrors(t *testing.T) {
+	t.Run("errors beyond the limit are discarded", func(t *testing.T) {
+		c := valtra.NewCollector(valtra.MaxErrors(3))
+		for i := range 10 {
+			valtra.Val(i, "item").Validate(valtra.Min(100)).Collect(c)
+		}
+
+		if len(c.Errors()) != 3 || c.IsValid() {
+			t.Errorf("Expected 3 errors, got %d", len(c.Errors()))
+		}
+	})
+
+	t.Run("prefixed collectors respect the limit", func(t *testing.T) {
+		c := valtra.NewCollector(valtra.MaxErrors(1))
+		ic := c.WithPrefix("items")
+		valtra.Val(0, "0").Validate(valtra.Positive[int]()).Collect(ic)
+		valtra.Val(0, "1").Validate(valtra.Positive[int]()).Collect(ic)
+
+		if len(c.Errors()) != 1 || len(ic.Errors()) != 1 {
+			t.Errorf("Expected 1 error each, got %d and %d", len(c.Errors()), len(ic.Errors()))
+		}
+	})
+
+	t.Run("zero means no limit", func(t *testing.T) {
+		c := valtra.NewCollector(valtra.MaxErrors(0))
+		for i := range 10 {
+			valtra.Val(i, "item").Validate(valtra.Min(100)).Collect(c)
+		}
+
+		if len(c.Errors()) != 10 {
+			t.Errorf("Expected 10 errors, got %d", len(c.Errors()))
+		}
+	})
+}
+
+func TestCollectorErr(t *testing.T) {
+	t.Run("no errors", func(t *testing.T) {
+		if err := valtra.NewCollector().Err(); err != nil {
+			t.Errorf("Expected nil, got %v", err)
+		}
+	})
+
+	t.Run("errors are joined and wrapped", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("", "name").Validate(valtra.Required[string]()).Collect(c)
+		valtra.Val(0, "age").Validate(valtra.Positive[int]()).Collect(c)
+
+		err := fmt.Errorf("signup: %w", c.Err())
+		if !errors.Is(err, &valtra.ValidationError{Code: "positive"}) {
+			t.Errorf("Expected positive error via errors.Is, got %v", err)
+		}
+		if err.Error() != "signup: name is required\nage must be positive" {
+			t.Errorf("Unexpected message: %q", err.Error())
+		}
+	})
+}
+
+func BenchmarkCollect(b *testing.B) {
+	rules := []func(valtra.Value[string]) error{valtra.Required[string](), valtra.MaxLengthString(50)}
+
+	b.ReportAllocs()
+	for b.Loop() {
+		c := valtra.NewCollector()
+		for range 30 {
+			valtra.Val("bobby", "username").Validate(rules...).Collect(c)
+		}
+		if !c.IsValid() {
+			b.Fatal("Expected the values to be valid")
+		}
+	}
+}
@@ -0,0 +1,555 @@
+package valtra
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unsafe"
+)
+
+// When returns a validation that applies the provided
+// validations only when cond is true.
+//
+// If more than one of the validations fails, their errors
+// are joined into a single error.
+//
+// Example:
+//
+//	valtra.Val(input.CompanyName).Validate(
+//	    valtra.When(input.AccountType == "business", valtra.Required[string]()),
+//	)
+func When[T any](cond bool, validations ...func(Value[T]) error) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if !cond {
+			return nil
+		}
+
+		return runAll(v, validations)
+	}
+}
+
+// WhenFunc returns a validation that applies the provided
+// validations only when the predicate returns true for
+// the value.
+//
+// If more than one of the validations fails, their errors
+// are joined into a single error.
+//
+// Example:
+//
+//	valtra.Val(input.Website).Validate(
+//	    valtra.WhenFunc(func(v valtra.Value[string]) bool { return v.Value() != "" }, valtra.MinLengthString(5)),
+//	)
+func WhenFunc[T any](predicate func(Value[T]) bool, validations ...func(Value[T]) error) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if !predicate(v) {
+			return nil
+		}
+
+		return runAll(v, validations)
+	}
+}
+
+// Unless returns a validation that applies the provided
+// validations only when cond is false.
+//
+// It is the inverse of When.
+//
+// Example:
+//
+//	valtra.Val(input.Phone).Validate(
+//	    valtra.Unless(input.Email != "", valtra.Required[string]()),
+//	)
+func Unless[T any](cond bool, validations ...func(Value[T]) error) func(Value[T]) error {
+	return When(!cond, validations...)
+}
+
+// UnlessFunc returns a validation that applies the provided
+// validations only when the predicate returns false for
+// the value.
+//
+// It is the inverse of WhenFunc.
+//
+// Example:
+//
+//	valtra.Val(input.Nickname).Validate(
+//	    valtra.UnlessFunc(func(v valtra.Value[string]) bool { return v.Value() == "" }, valtra.MaxLengthString(20)),
+//	)
+func UnlessFunc[T any](predicate func(Value[T]) bool, validations ...func(Value[T]) error) func(Value[T]) error {
+	return WhenFunc(func(v Value[T]) bool { return !predicate(v) }, validations...)
+}
+
+// FlagProvider reports whether feature flags are enabled. It
+// lets validations be rolled out gradually behind an existing
+// feature-flag system.
+type FlagProvider interface {
+	Enabled(flag string) bool
+}
+
+// IfEnabled returns a validation that applies the provided
+// validations only when the given feature flag is enabled.
+//
+// The flag is checked each time the validation runs, so
+// toggling it takes effect immediately.
+//
+// If more than one of the validations fails, their errors
+// are joined into a single error.
+//
+// Example:
+//
+//	valtra.Val(input.Email).Validate(
+//	    valtra.Email(),
+//	    valtra.IfEnabled(flags, "strict-email", valtra.Match(`^[^+]+@`)),
+//	)
+func IfEnabled[T any](provider FlagProvider, flag string, validations ...func(Value[T]) error) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if !provider.Enabled(flag) {
+			return nil
+		}
+
+		return runAll(v, validations)
+	}
+}
+
+// SampleEnforce returns a validation that enforces the rule
+// for a sample of values, of the given rate from 0 to 1, and
+// only warns about the rest (see Value.Warnings).
+//
+// It suits canarying a new, stricter rule on a share of
+// traffic before enforcing it everywhere. Values are sampled
+// by a hash of their contents (as printed by fmt for values
+// other than strings), so the same value is always treated the
+// same way, across restarts and instances. A rate outside 0 to
+// 1 is an invalid rule (see ErrInvalidRule).
+//
+// Warnings are recorded by Value.Validate, Value.ValidateCtx
+// and Schema.Validate, including through combinators such as
+// Field, And and Or. Called directly, the rule returns a
+// non-nil error carrying the warning for values outside the
+// sample, which SplitWarnings tells apart from a failure.
+//
+// Example:
+//
+//	valtra.Val(input.Username, "username").Validate(
+//	    valtra.SampleEnforce(0.05, valtra.Match(`^[a-z0-9_]+$`)),
+//	)
+func SampleEnforce[T any](rate float64, rule func(Value[T]) error) func(Value[T]) error {
+	if !(rate >= 0 && rate <= 1) {
+		return invalidRule[T]("SampleEnforce has a rate (%v) outside 0 to 1", rate)
+	}
+
+	return func(v Value[T]) error {
+		err := check(v, rule)
+		failure, warnings := SplitWarnings(err)
+		if failure == nil || sampled(v.value, rate) {
+			return err
+		}
+
+		return withWarnings(nil, append(warnings, asWarning(failure)))
+	}
+}
+
+// sampled reports whether the value falls within the sample
+// of the given rate, by its FNV-1a hash.
+func sampled(value any, rate float64) bool {
+	h := fnv.New64a()
+	if s, ok := value.(string); ok {
+		h.Write([]byte(s))
+	} else {
+		fmt.Fprint(h, value)
+	}
+
+	return float64(h.Sum64()>>11)/(1<<53) < rate
+}
+
+// Derive returns a validation that computes a derived value
+// from the value and applies the provided validations to it.
+//
+// The derived value keeps the original value's name, so any
+// errors remain attributed to the original field, along with
+// the rest of its state, such as its mode (see Strict) and
+// classification. If more than one of the validations fails,
+// their errors are joined into a single error.
+//
+// Example:
+//
+//	domain := func(email string) string { return email[strings.LastIndex(email, "@")+1:] }
+//	valtra.Val(input.Email, "email").Validate(
+//	    valtra.Derive(domain, valtra.NotInValues("mailinator.com")),
+//	)
+func Derive[T, U any](fn func(T) U, validations ...func(Value[U]) error) func(Value[T]) error {
+	return func(v Value[T]) error {
+		return runAll(rebind(v, fn(v.value)), validations)
+	}
+}
+
+// ForBytes returns a validation that applies the provided
+// string validations to a []byte value without copying it,
+// e.g. for the body of a fasthttp request or a
+// json.RawMessage.
+//
+// Validations that pass never see a copy. When one fails, the
+// parts of its error holding the value, such as its
+// parameters, are copied, so that it does not hold on to bytes
+// the caller may reuse. Errors of other types than
+// ValidationError cannot be copied, so they are replaced by an
+// error holding a copy of their message, which still matches
+// the errors they wrap with errors.Is. Each validation is run
+// once. Repairs made by rules with AutoFix are not applied, as
+// the bytes are not changed. As with Derive, errors are joined
+// into a single error.
+//
+// Example:
+//
+//	valtra.Val(ctx.PostBody(), "body").Validate(valtra.ForBytes(valtra.MaxLengthString(1024), valtra.JSON()))
+func ForBytes(validations ...func(Value[string]) error) func(Value[[]byte]) error {
+	return func(v Value[[]byte]) error {
+		view := rebind(v, unsafe.String(unsafe.SliceData(v.value), len(v.value)))
+
+		var errs, warnings []error
+		for _, fn := range validations {
+			failure, ws := SplitWarnings(check(view, fn))
+			warnings = append(warnings, ws...)
+			if failure != nil {
+				errs = append(errs, detach(failure))
+			}
+		}
+
+		return withWarnings(errors.Join(errs...), warnings)
+	}
+}
+
+// detach returns a copy of the error, with copies of the
+// strings it holds, so that it no longer refers to the bytes
+// of a ForBytes view. Errors other than a *ValidationError, or
+// joins of them, are rendered into a detachedError.
+func detach(err error) error {
+	switch e := err.(type) {
+	case *ValidationError:
+		copied := *e
+		copied.Message = strings.Clone(e.Message)
+		copied.value = detachParam(e.value)
+		if e.Params != nil {
+			copied.Params = make(map[string]any, len(e.Params))
+			for k, p := range e.Params {
+				copied.Params[k] = detachParam(p)
+			}
+		}
+		return &copied
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		copied := make([]error, len(errs))
+		for i, err := range errs {
+			copied[i] = detach(err)
+		}
+		return errors.Join(copied...)
+	default:
+		return &detachedError{msg: strings.Clone(err.Error()), err: err}
+	}
+}
+
+// detachedError is an error that ForBytes could not copy,
+// rendered with a copy of its message.
+type detachedError struct {
+	msg string
+	err error
+}
+
+// Error returns the copied message.
+func (e *detachedError) Error() string {
+	return e.msg
+}
+
+// Is reports whether the original error matches the target,
+// without exposing it to errors.As.
+func (e *detachedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+// detachParam returns a copy of a parameter or value of a
+// ValidationError, for detach, if it is or holds strings.
+func detachParam(p any) any {
+	switch x := p.(type) {
+	case string:
+		return strings.Clone(x)
+	case []string:
+		copied := make([]string, len(x))
+		for i, s := range x {
+			copied[i] = strings.Clone(s)
+		}
+		return copied
+	case error:
+		return detach(x)
+	case []error:
+		copied := make([]error, len(x))
+		for i, err := range x {
+			copied[i] = detach(err)
+		}
+		return copied
+	}
+
+	return p
+}
+
+// Field returns a validation that applies the provided
+// validations to a field of a struct value, under the
+// field's own name. The field's value keeps the rest of the
+// struct value's state, as with Derive.
+//
+// It lets a Schema of a struct report errors per field, e.g.
+// so an API can point clients at the offending properties.
+// If more than one of the validations fails, their errors are
+// joined into a single error.
+//
+// Example:
+//
+//	signupSchema := valtra.NewSchema[Signup]().Validate(
+//	    valtra.Field("email", func(s Signup) string { return s.Email }, valtra.Required[string](), valtra.Email()),
+//	    valtra.Field("age", func(s Signup) int { return s.Age }, valtra.Min(18)),
+//	)
+func Field[T, U any](name string, get func(T) U, validations ...func(Value[U]) error) func(Value[T]) error {
+	return func(v Value[T]) error {
+		field := rebind(v, get(v.value))
+		field.name = name
+
+		return runAll(field, validations)
+	}
+}
+
+// And returns a validation that applies all of the provided
+// validations, failing if any of them fails.
+//
+// It groups validations into one, e.g. as an alternative of
+// Or. If more than one of the validations fails, their errors
+// are joined into a single error.
+//
+// Example:
+//
+//	valtra.Val(input.Code).Validate(
+//	    valtra.Or(
+//	        valtra.And(valtra.HasPrefix("EU-"), valtra.MaxLengthString(10)),
+//	        valtra.UUID(),
+//	    ),
+//	)
+func And[T any](validations ...func(Value[T]) error) func(Value[T]) error {
+	return func(v Value[T]) error {
+		return runAll(v, validations)
+	}
+}
+
+// Or returns a validation that passes if any of the provided
+// validations passes.
+//
+// If all of them fail, the error combines their messages,
+// e.g. "phone is required, or email is required", and holds
+// their errors in the "errors" parameter. It passes if no
+// validations are provided.
+//
+// As the validations are variadic, options such as WithMessage
+// are provided with Configure, which passes them to the
+// combined error.
+//
+// Example:
+//
+//	contactSchema := valtra.NewSchema[Contact]().Validate(valtra.Or(
+//	    valtra.Field("phone", func(c Contact) string { return c.Phone }, valtra.Required[string]()),
+//	    valtra.Field("email", func(c Contact) string { return c.Email }, valtra.Required[string]()),
+//	))
+func Or[T any](validations ...func(Value[T]) error) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if len(validations) == 0 {
+			return nil
+		}
+
+		var warnings []error
+		errs := make([]error, 0, len(validations))
+		for _, fn := range validations {
+			result := check(v, fn)
+			err, ws := SplitWarnings(result)
+			if err == nil {
+				return result
+			}
+			warnings = append(warnings, ws...)
+			errs = append(errs, err)
+		}
+
+		return withWarnings(newError(v, "or", map[string]any{"errors": errs, "reasons": orReasons(errs)}, nil), warnings)
+	}
+}
+
+// orReasons joins the messages of the errors of an Or
+// validation's alternatives, for its "reasons" parameter.
+func orReasons(errs []error) string {
+	messages := make([]string, len(errs))
+	for i, err := range errs {
+		if ve, ok := err.(*ValidationError); ok {
+			messages[i] = ve.Message
+		} else {
+			messages[i] = err.Error()
+		}
+	}
+
+	return strings.Join(messages, ", or ")
+}
+
+// Not returns a validation that passes only if the provided
+// validation fails, using the given message when it passes
+// instead.
+//
+// Options such as WithCode can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Username, "username").Validate(
+//	    valtra.Not(valtra.Email(), "{name} cannot be an email address"),
+//	)
+func Not[T any](validation func(Value[T]) error, message string, opts ...Option) func(Value[T]) error {
+	opts = append([]Option{WithMessage(message)}, opts...)
+
+	return func(v Value[T]) error {
+		if failure, _ := SplitWarnings(check(v, validation)); failure != nil {
+			return nil
+		}
+
+		return newError(v, "not", nil, opts)
+	}
+}
+
+// Configure returns the validation with the given options,
+// such as WithMessage, WithCode or WithSeverity, applied to
+// its errors, for rules that take no options themselves, such
+// as Or.
+//
+// The options replace those the validation was created with.
+// Only errors of the validation's own rule are configured, not
+// those of the rules it combines, e.g. with And, and options
+// that change how a rule checks values, such as AutoFix, have
+// no effect.
+//
+// Example:
+//
+//	valtra.Val(input.Contact).Validate(valtra.Configure(
+//	    valtra.Or(valtra.Email(), valtra.PhoneNumber()),
+//	    valtra.WithMessage("enter an email address or phone number"),
+//	))
+func Configure[T any](validation func(Value[T]) error, opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		err := validation(v)
+		failure, warnings := err, []error(nil)
+		if w, ok := err.(*warningError); ok {
+			failure, warnings = w.err, w.warnings
+		}
+		if ve, ok := failure.(*ValidationError); ok {
+			return withWarnings(newError(v, ve.rule, ve.Params, opts), warnings)
+		}
+
+		return err
+	}
+}
+
+// check applies the validation to the value, as long as the
+// value's budget allows, with unfixed.
+func check[T any](v Value[T], fn func(Value[T]) error) error {
+	if skip, first := v.budget.spend(); skip {
+		if first {
+			return budgetError(v)
+		}
+		return nil
+	}
+
+	return unfixed(v, fn)
+}
+
+// unfixed applies the validation to the value. Repairs by
+// rules with AutoFix cannot be applied here, so such rules fail
+// as they would without the option.
+func unfixed[T any](v Value[T], fn func(Value[T]) error) error {
+	err := fn(v)
+	if fix, ok := err.(*fixError[T]); ok {
+		return fix.err
+	}
+
+	return err
+}
+
+// runAll applies every validation to the value with check
+// and joins the resulting errors, returning nil if all of them
+// pass. Their warnings are passed on with withWarnings.
+func runAll[T any](v Value[T], validations []func(Value[T]) error) error {
+	var errs, warnings []error
+	for _, fn := range validations {
+		failure, ws := SplitWarnings(check(v, fn))
+		warnings = append(warnings, ws...)
+		if failure != nil {
+			errs = append(errs, failure)
+		}
+	}
+
+	return withWarnings(errors.Join(errs...), warnings)
+}
+
+// warningError is returned by a rule with warnings about the
+// value, such as SampleEnforce outside its sample, along with
+// its failure, if any. It acts as that failure, or as a pass,
+// wherever the warnings cannot be recorded, while combinators
+// pass it on to Value.Validate and the other methods that
+// record them.
+type warningError struct {
+	err      error
+	warnings []error
+}
+
+// Error returns the message of the rule's failure, or of the
+// warnings if the value passed.
+func (e *warningError) Error() string {
+	if e.err == nil {
+		return errors.Join(e.warnings...).Error()
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns the rule's failure.
+func (e *warningError) Unwrap() error {
+	return e.err
+}
+
+// withWarnings returns the failure along with the warnings, as
+// a *warningError, or the failure alone if there are none.
+func withWarnings(err error, warnings []error) error {
+	if len(warnings) == 0 {
+		return err
+	}
+
+	return &warningError{err: err, warnings: warnings}
+}
+
+// SplitWarnings splits the result of calling a rule directly
+// into its failure, nil if the value passed, and the warnings
+// it carries.
+//
+// Rules that only warn about a value, such as SampleEnforce
+// outside its sample or Remote falling back, and rules with
+// AutoFix that repaired it, return a non-nil error even when
+// the value passes, so that Value.Validate can record the
+// warning or apply the repair. Code calling rules itself, e.g.
+// in a custom combinator, should pass their results through
+// SplitWarnings rather than compare them with nil. Repairs are
+// dropped, as only Value.Validate can apply them, so rules
+// with AutoFix fail here as they would without the option.
+//
+// Example:
+//
+//	failure, warnings := valtra.SplitWarnings(rule(valtra.Val(input.Name, "name")))
+func SplitWarnings(err error) (failure error, warnings []error) {
+	switch e := err.(type) {
+	case *warningError:
+		return e.err, e.warnings
+	case interface{ original() error }:
+		return e.original(), nil
+	}
+
+	return err, nil
+}
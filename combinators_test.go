@@ -0,0 +1,466 @@
+package valtra_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestWhen(t *testing.T) {
+	t.Run("rules run when condition is true", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.When(true, valtra.Required[string]()))
+		if v.IsValid() {
+			t.Error("Expected validation to fail when condition is true")
+		}
+	})
+
+	t.Run("rules skipped when condition is false", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.When(false, valtra.Required[string]()))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("multiple failures are joined into one error", func(t *testing.T) {
+		v := valtra.Val("ab").Validate(valtra.When(true,
+			valtra.MinLengthString(5),
+			valtra.MaxLengthString(1),
+		))
+		if len(v.Errors()) != 1 {
+			t.Errorf("Expected 1 joined error, got %d: %v", len(v.Errors()), v.Errors())
+		}
+	})
+}
+
+func TestWhenFunc(t *testing.T) {
+	notEmpty := func(v valtra.Value[string]) bool { return v.Value() != "" }
+
+	t.Run("rules run when predicate holds", func(t *testing.T) {
+		v := valtra.Val("ab").Validate(valtra.WhenFunc(notEmpty, valtra.MinLengthString(5)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail when predicate holds")
+		}
+	})
+
+	t.Run("rules skipped when predicate fails", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.WhenFunc(notEmpty, valtra.MinLengthString(5)))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestUnless(t *testing.T) {
+	t.Run("rules run when condition is false", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Unless(false, valtra.Required[string]()))
+		if v.IsValid() {
+			t.Error("Expected validation to fail when condition is false")
+		}
+	})
+
+	t.Run("rules skipped when condition is true", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Unless(true, valtra.Required[string]()))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestUnlessFunc(t *testing.T) {
+	isEmpty := func(v valtra.Value[string]) bool { return v.Value() == "" }
+
+	t.Run("rules run when predicate fails", func(t *testing.T) {
+		v := valtra.Val("a very long nickname").Validate(valtra.UnlessFunc(isEmpty, valtra.MaxLengthString(5)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail when predicate fails")
+		}
+	})
+
+	t.Run("rules skipped when predicate holds", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.UnlessFunc(isEmpty, valtra.MinLengthString(5)))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestForBytes(t *testing.T) {
+	rule := valtra.ForBytes(valtra.MaxLengthString(10), valtra.Alphanumeric())
+
+	t.Run("valid bytes pass without copying", func(t *testing.T) {
+		v := valtra.Val([]byte("bobby27"))
+		if allocs := testing.AllocsPerRun(100, func() { _ = rule(v) }); allocs != 0 {
+			t.Errorf("Expected no allocations, got %v", allocs)
+		}
+		if err := rule(v); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("invalid bytes fail", func(t *testing.T) {
+		v := valtra.Val([]byte("bobby_27 is too long"), "username").Validate(rule)
+
+		var joined interface{ Unwrap() []error }
+		if !errors.As(v.FirstError(), &joined) || len(joined.Unwrap()) != 2 {
+			t.Fatalf("Expected 2 joined errors, got %v", v.Errors())
+		}
+		if msg := joined.Unwrap()[1].Error(); msg != "username must contain only letters and digits" {
+			t.Errorf("Unexpected message: %q", msg)
+		}
+	})
+
+	t.Run("failing validations run once", func(t *testing.T) {
+		calls := 0
+		counted := func(v valtra.Value[string]) error {
+			calls++
+			return valtra.NewError(v, "counted", map[string]any{"got": v.Value()}, valtra.WithMessage("{name} is {got}"))
+		}
+
+		body := []byte("bobby")
+		err := valtra.ForBytes(counted)(valtra.Val(body, "username"))
+		copy(body, "alice")
+
+		if calls != 1 {
+			t.Errorf("Expected 1 run, got %d", calls)
+		}
+		var ve *valtra.ValidationError
+		if !errors.As(err, &ve) || ve.Params["got"] != "bobby" || ve.Error() != "username is bobby" {
+			t.Errorf("Expected the error to keep its own copy of the value, got %v", err)
+		}
+	})
+
+	t.Run("other errors are rendered from a copy", func(t *testing.T) {
+		errUnknownUser := errors.New("unknown user")
+		body := []byte("bobby")
+		calls := 0
+		err := valtra.ForBytes(func(v valtra.Value[string]) error {
+			calls++
+			return fmt.Errorf("%w: %s", errUnknownUser, v.Value())
+		})(valtra.Val(body))
+		copy(body, "alice")
+
+		if err == nil || err.Error() != "unknown user: bobby" {
+			t.Errorf("Expected the error to keep its own copy of the value, got %v", err)
+		}
+		if !errors.Is(err, errUnknownUser) {
+			t.Errorf("Expected the error to match the error it wraps, got %v", err)
+		}
+		if calls != 1 {
+			t.Errorf("Expected the validation to run once, got %d calls", calls)
+		}
+	})
+}
+
+func TestDerive(t *testing.T) {
+	domain := func(email string) string { return email[strings.LastIndex(email, "@")+1:] }
+
+	t.Run("derived value passes", func(t *testing.T) {
+		v := valtra.Val("bobby@example.com").Validate(valtra.Derive(domain, valtra.NotInValues("mailinator.com")))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("derived value fails with original name", func(t *testing.T) {
+		v := valtra.Val("bobby@mailinator.com", "email").Validate(valtra.Derive(domain, valtra.NotInValues("mailinator.com")))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail for derived value")
+		}
+		if !strings.HasPrefix(v.Errors()[0].Error(), "email ") {
+			t.Errorf("Expected error attributed to email, got %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("derived value keeps the original's state", func(t *testing.T) {
+		var derived valtra.Value[int]
+		capture := func(v valtra.Value[int]) error { derived = v; return nil }
+
+		valtra.Derive(func(s string) int { return len(s) }, capture)(valtra.OptionalVal[string](nil, "nickname").Classify(valtra.ClassSecret))
+		if derived.Present() || derived.Classification() != valtra.ClassSecret || derived.Name() != "nickname" {
+			t.Errorf("Expected an absent secret nickname, got present %v, class %v and name %q", derived.Present(), derived.Classification(), derived.Name())
+		}
+	})
+
+	t.Run("derived value of another type", func(t *testing.T) {
+		length := func(s string) int { return len(s) }
+		v := valtra.Val("hi").Validate(valtra.Derive(length, valtra.Min(3)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for derived length")
+		}
+	})
+}
+
+type flags map[string]bool
+
+func (f flags) Enabled(flag string) bool { return f[flag] }
+
+func TestIfEnabled(t *testing.T) {
+	t.Run("rules run when flag is enabled", func(t *testing.T) {
+		v := valtra.Val("a+b@example.com").Validate(
+			valtra.IfEnabled(flags{"strict-email": true}, "strict-email", valtra.Match(`^[^+]+@`)),
+		)
+		if v.IsValid() {
+			t.Error("Expected validation to fail when flag is enabled")
+		}
+	})
+
+	t.Run("rules skipped when flag is disabled", func(t *testing.T) {
+		v := valtra.Val("a+b@example.com").Validate(
+			valtra.IfEnabled(flags{}, "strict-email", valtra.Match(`^[^+]+@`)),
+		)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestSampleEnforce(t *testing.T) {
+	lower := valtra.Match(`^[a-z]+$`)
+
+	t.Run("rate 1 enforces every value", func(t *testing.T) {
+		v := valtra.Val("Bobby").Validate(valtra.SampleEnforce(1, lower))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+
+	t.Run("rate 0 only warns", func(t *testing.T) {
+		v := valtra.Val("Bobby", "username").Validate(valtra.SampleEnforce(0, lower))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+
+		var ve *valtra.ValidationError
+		if len(v.Warnings()) != 1 || !errors.As(v.Warnings()[0], &ve) || ve.Severity != valtra.SeverityWarning {
+			t.Errorf("Expected a single warning, got %v", v.Warnings())
+		}
+	})
+
+	t.Run("passing values pass", func(t *testing.T) {
+		v := valtra.Val("bobby").Validate(valtra.SampleEnforce(0.5, lower))
+		if !v.IsValid() || len(v.Warnings()) != 0 {
+			t.Errorf("Expected no errors or warnings, got %v and %v", v.Errors(), v.Warnings())
+		}
+	})
+
+	t.Run("sample is deterministic and close to the rate", func(t *testing.T) {
+		rule := valtra.SampleEnforce(0.2, valtra.Max(0))
+
+		enforced := 0
+		for i := 1; i <= 10000; i++ {
+			first := valtra.Val(i).Validate(rule).IsValid()
+			if again := valtra.Val(i).Validate(rule).IsValid(); again != first {
+				t.Fatalf("Expected %d to be sampled the same way each time", i)
+			}
+			if !first {
+				enforced++
+			}
+		}
+
+		if enforced < 1800 || enforced > 2200 {
+			t.Errorf("Expected about 2000 of 10000 values to be enforced, got %d", enforced)
+		}
+	})
+
+	t.Run("warnings pass through combinators", func(t *testing.T) {
+		warn := valtra.SampleEnforce(0, lower)
+		type user struct{ Name string }
+
+		tests := []struct {
+			name string
+			rule func(valtra.Value[user]) error
+		}{
+			{"field", valtra.Field("name", func(u user) string { return u.Name }, warn)},
+			{"and", valtra.Field("name", func(u user) string { return u.Name }, valtra.And(valtra.Required[string](), warn))},
+			{"when", valtra.Field("name", func(u user) string { return u.Name }, valtra.When(true, warn))},
+			{"or", valtra.Or(valtra.Field("name", func(u user) string { return u.Name }, warn))},
+			{"derive", valtra.Derive(func(u user) string { return u.Name }, warn)},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				v := valtra.Val(user{Name: "Bobby"}).Validate(tt.rule)
+				if !v.IsValid() || len(v.Warnings()) != 1 {
+					t.Errorf("Expected a single warning and no errors, got %v and %v", v.Warnings(), v.Errors())
+				}
+
+				v = valtra.NewSchema[user]().Validate(tt.rule).Apply(valtra.Val(user{Name: "Bobby"}))
+				if !v.IsValid() || len(v.Warnings()) != 1 {
+					t.Errorf("Expected the schema to record a single warning, got %v and %v", v.Warnings(), v.Errors())
+				}
+			})
+		}
+	})
+
+	t.Run("direct calls are split with SplitWarnings", func(t *testing.T) {
+		err := valtra.SampleEnforce(0, lower)(valtra.Val("Bobby"))
+		failure, warnings := valtra.SplitWarnings(err)
+		if err == nil || failure != nil || len(warnings) != 1 {
+			t.Errorf("Expected a warning but no failure, got %v and %v", failure, warnings)
+		}
+	})
+
+	t.Run("warnings of failing alternatives are kept", func(t *testing.T) {
+		v := valtra.Val("Bobby").Validate(valtra.Or(valtra.SampleEnforce(0, lower), valtra.MaxLengthString(3)))
+		if !v.IsValid() || len(v.Warnings()) != 1 {
+			t.Errorf("Expected the warned alternative to pass, got %v and %v", v.Errors(), v.Warnings())
+		}
+
+		v = valtra.Val("Bobby").Validate(valtra.Or(valtra.And(valtra.SampleEnforce(0, lower), valtra.MaxLengthString(3)), valtra.MaxLengthString(4)))
+		if v.IsValid() || len(v.Warnings()) != 1 {
+			t.Errorf("Expected an error and a warning, got %v and %v", v.Errors(), v.Warnings())
+		}
+	})
+
+	t.Run("failures alongside warnings are kept", func(t *testing.T) {
+		v := valtra.Val("Bobby").Validate(valtra.And(valtra.SampleEnforce(0, lower), valtra.MaxLengthString(3)))
+		if v.IsValid() || len(v.Warnings()) != 1 {
+			t.Errorf("Expected an error and a warning, got %v and %v", v.Errors(), v.Warnings())
+		}
+	})
+
+	t.Run("invalid rate is an invalid rule", func(t *testing.T) {
+		v := valtra.Val("bobby").Validate(valtra.SampleEnforce(1.5, lower))
+		if !errors.Is(v.Err(), valtra.ErrInvalidRule) {
+			t.Errorf("Expected ErrInvalidRule, got %v", v.Err())
+		}
+	})
+}
+
+func TestAnd(t *testing.T) {
+	code := valtra.And(valtra.HasPrefix("EU-"), valtra.MaxLengthString(6))
+
+	t.Run("passes when all pass", func(t *testing.T) {
+		if v := valtra.Val("EU-123").Validate(code); !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("fails when any fails", func(t *testing.T) {
+		if v := valtra.Val("EU-1234").Validate(code); v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+}
+
+func TestOr(t *testing.T) {
+	type contact struct {
+		Phone string
+		Email string
+	}
+
+	schema := valtra.NewSchema[contact]().Validate(valtra.Or(
+		valtra.Field("phone", func(c contact) string { return c.Phone }, valtra.Required[string]()),
+		valtra.Field("email", func(c contact) string { return c.Email }, valtra.Required[string]()),
+	))
+
+	t.Run("passes when any passes", func(t *testing.T) {
+		if _, err := schema.Run(contact{Email: "bobby@example.com"}); err != nil {
+			t.Errorf("Expected validation to pass, got %v", err)
+		}
+	})
+
+	t.Run("fails with a combined message", func(t *testing.T) {
+		_, err := schema.Run(contact{}, "contact")
+		if err == nil || err.Error() != "phone is required, or email is required" {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("options apply to the combined error", func(t *testing.T) {
+		rule := valtra.Configure(valtra.Or(valtra.Email(), valtra.PhoneNumber()),
+			valtra.WithCode("contact"), valtra.WithMessage("{name} must be an email address or phone number"))
+
+		err := rule(valtra.Val("bobby", "contact"))
+		var ve *valtra.ValidationError
+		if !errors.As(err, &ve) || ve.Code != "contact" || ve.Error() != "contact must be an email address or phone number" {
+			t.Errorf("Expected the configured error, got %v", err)
+		}
+		if errs, _ := ve.Params["errors"].([]error); len(errs) != 2 {
+			t.Errorf("Expected the alternatives' errors to be kept, got %v", ve.Params["errors"])
+		}
+	})
+
+	t.Run("combined alternatives are grouped with And", func(t *testing.T) {
+		rule := valtra.Or(valtra.And(valtra.HasPrefix("EU-"), valtra.MaxLengthString(6)), valtra.UUID())
+		if v := valtra.Val("EU-12").Validate(rule); !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+		if v := valtra.Val("US-12").Validate(rule); v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+}
+
+func TestConfigure(t *testing.T) {
+	t.Run("options apply to the rule's errors", func(t *testing.T) {
+		rule := valtra.Configure(valtra.Min(18), valtra.WithCode("too_young"), valtra.WithMessage("{name} must be {min} or over"))
+		err := rule(valtra.Val(16, "age"))
+
+		var ve *valtra.ValidationError
+		if !errors.As(err, &ve) || ve.Code != "too_young" || ve.Error() != "age must be 18 or over" {
+			t.Errorf("Expected the configured error, got %v", err)
+		}
+	})
+
+	t.Run("passing values pass", func(t *testing.T) {
+		if err := valtra.Configure(valtra.Min(18), valtra.WithCode("too_young"))(valtra.Val(30)); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestNot(t *testing.T) {
+	notEmail := valtra.Not(valtra.Email(), "{name} cannot be an email address")
+
+	t.Run("passes when the rule fails", func(t *testing.T) {
+		if v := valtra.Val("bobby").Validate(notEmail); !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("fails with the message when the rule passes", func(t *testing.T) {
+		v := valtra.Val("bobby@example.com", "username").Validate(notEmail)
+		if v.IsValid() || v.Errors()[0].Error() != "username cannot be an email address" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestField(t *testing.T) {
+	type signup struct {
+		Email string
+		Age   int
+	}
+
+	schema := valtra.NewSchema[signup]().Validate(
+		valtra.Field("email", func(s signup) string { return s.Email }, valtra.Required[string](), valtra.Email()),
+		valtra.Field("age", func(s signup) int { return s.Age }, valtra.Min(18)),
+	)
+
+	t.Run("valid fields pass", func(t *testing.T) {
+		if _, err := schema.Run(signup{Email: "bobby@example.com", Age: 30}); err != nil {
+			t.Errorf("Expected validation to pass, got %v", err)
+		}
+	})
+
+	t.Run("errors are named after the field", func(t *testing.T) {
+		v := valtra.Val(signup{Email: "bobby@example.com", Age: 16}, "signup").Apply(schema)
+		if len(v.Errors()) != 1 || v.Errors()[0].Error() != "age cannot be smaller than 18" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("fields keep the struct's state", func(t *testing.T) {
+		var field valtra.Value[int]
+		capture := func(v valtra.Value[int]) error { field = v; return nil }
+
+		valtra.Field("age", func(s signup) int { return s.Age }, capture)(valtra.OptionalVal[signup](nil).Classify(valtra.ClassSecret))
+		if field.Present() || field.Classification() != valtra.ClassSecret || field.Name() != "age" {
+			t.Errorf("Expected an absent secret age, got present %v, class %v and name %q", field.Present(), field.Classification(), field.Name())
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package valtra
+
+import (
+	"flag"
+	"fmt"
+	"os"
+)
+
+// Env creates a new Value[string] holding the environment
+// variable with the given name, named after it, for validating
+// service configuration at startup.
+//
+// An unset variable holds the empty string, so Required
+// reports it as missing. Use OptionalEnv for variables that
+// may be left unset. Errors from several variables can be
+// gathered with a Collector, to report them all at once.
+//
+// Example:
+//
+//	c := valtra.NewCollector()
+//	cfg := Config{
+//	    Port:     valtra.Convert(valtra.Env("PORT"), valtra.ParseInt()).Validate(valtra.Between(1, 65535)).Collect(c),
+//	    Database: valtra.Env("DATABASE_URL").Validate(valtra.Required[string](), valtra.URL()).Collect(c),
+//	}
+//	if err := c.Err(); err != nil {
+//	    log.Fatalf("invalid configuration: %v", err)
+//	}
+func Env(name string) Value[string] {
+	return Val(os.Getenv(name), name)
+}
+
+// OptionalEnv creates a new Value[string] holding the
+// environment variable with the given name, as with Env.
+//
+// If the variable is unset, the value is absent, as with
+// OptionalVal: all validations and transformations are
+// skipped. A variable set to the empty string is present.
+//
+// Example:
+//
+//	logLevel := valtra.OptionalEnv("LOG_LEVEL").Validate(valtra.OneOfValues("debug", "info", "warn")).Collect(c)
+func OptionalEnv(name string) Value[string] {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return OptionalVal[string](nil, name)
+	}
+
+	return Val(value, name)
+}
+
+// Flag creates a new Value[string] holding the value of the
+// flag with the given name in the flag set (or the default
+// command-line flags if fs is nil), named after it, e.g.
+// "-port".
+//
+// The flag set must have been parsed. Unset flags hold their
+// default value. A flag that is not defined adds an error to
+// the value's error list.
+//
+// Example:
+//
+//	flag.String("addr", ":8080", "listen address")
+//	flag.Parse()
+//
+//	addr := valtra.Flag(nil, "addr").Validate(valtra.Required[string]()).Collect(c)
+func Flag(fs *flag.FlagSet, name string) Value[string] {
+	if fs == nil {
+		fs = flag.CommandLine
+	}
+
+	f := fs.Lookup(name)
+	if f == nil {
+		v := Val("", "-"+name)
+		v.errs = append(v.errs, fmt.Errorf("valtra: flag -%s is not defined", name))
+		return v
+	}
+
+	return Val(f.Value.String(), "-"+name)
+}
@@ -0,0 +1,77 @@
+package valtra_test
+
+import (
+	"errors"
+	"flag"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestEnv(t *testing.T) {
+	t.Run("variables are named after themselves", func(t *testing.T) {
+		t.Setenv("VALTRA_PORT", "8080")
+
+		port, err := valtra.Convert(valtra.Env("VALTRA_PORT"), valtra.ParseInt()).Validate(valtra.Between(1, 65535)).Get()
+		if err != nil || port != 8080 {
+			t.Errorf("Expected 8080, got %d (%v)", port, err)
+		}
+	})
+
+	t.Run("errors from several variables are collected", func(t *testing.T) {
+		t.Setenv("VALTRA_PORT", "70000")
+
+		c := valtra.NewCollector()
+		valtra.Convert(valtra.Env("VALTRA_PORT"), valtra.ParseInt()).Validate(valtra.Between(1, 65535)).Collect(c)
+		valtra.Env("VALTRA_UNSET").Validate(valtra.Required[string]()).Collect(c)
+
+		var ve *valtra.ValidationError
+		if len(c.Errors()) != 2 || !errors.As(c.Errors()[1], &ve) || ve.Field != "VALTRA_UNSET" || ve.Code != "required" {
+			t.Errorf("Expected errors for both variables, got %v", c.Errors())
+		}
+	})
+}
+
+func TestOptionalEnv(t *testing.T) {
+	t.Run("unset variables are absent", func(t *testing.T) {
+		v := valtra.OptionalEnv("VALTRA_UNSET").Validate(valtra.Required[string]())
+		if v.Present() || !v.IsValid() {
+			t.Errorf("Expected an absent, valid value, got %v", v.Errors())
+		}
+	})
+
+	t.Run("empty variables are present", func(t *testing.T) {
+		t.Setenv("VALTRA_EMPTY", "")
+
+		v := valtra.OptionalEnv("VALTRA_EMPTY").Validate(valtra.Required[string]())
+		if !v.Present() || v.IsValid() {
+			t.Error("Expected a present value failing Required")
+		}
+	})
+}
+
+func TestFlag(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.String("addr", ":8080", "")
+	fs.Int("workers", 4, "")
+	if err := fs.Parse([]string{"-workers", "0"}); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("flags hold their values", func(t *testing.T) {
+		if v := valtra.Flag(fs, "addr"); v.Value() != ":8080" || v.Name() != "-addr" {
+			t.Errorf("Expected the default -addr, got %s=%q", v.Name(), v.Value())
+		}
+
+		v := valtra.Convert(valtra.Flag(fs, "workers"), valtra.ParseInt()).Validate(valtra.Min(1))
+		if v.IsValid() || v.Value() != 0 {
+			t.Errorf("Expected -workers=0 to fail, got %d (%v)", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("undefined flags fail", func(t *testing.T) {
+		if v := valtra.Flag(fs, "missing"); v.IsValid() {
+			t.Error("Expected an error for an undefined flag")
+		}
+	})
+}
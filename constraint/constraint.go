@@ -0,0 +1,70 @@
+// Package constraint defines the type constraints used by
+// valtra's generic rules, so libraries can write their own
+// generic validators accepting the same types as valtra's,
+// without copying the definitions.
+//
+// The constraints are stable: types will only be added to
+// them if the language gains new types of the same kind.
+//
+// Example:
+//
+//	func Even[T constraint.Integer]() func(valtra.Value[T]) error {
+//	    return func(v valtra.Value[T]) error {
+//	        if v.Value()%2 != 0 {
+//	            return errors.New("must be even")
+//	        }
+//	        return nil
+//	    }
+//	}
+package constraint
+
+// Ordered is a constraint that permits all numeric types
+// that support comparison operations (<, >, <=, >=), as
+// accepted by valtra.Min, valtra.Max and valtra.Between.
+type Ordered interface {
+	Integer | Float
+}
+
+// Integer is a constraint that permits all integer types, as
+// accepted by valtra.MultipleOf.
+type Integer interface {
+	Signed | Unsigned
+}
+
+// Signed is a constraint that permits all signed integer
+// types.
+type Signed interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64
+}
+
+// Unsigned is a constraint that permits all unsigned integer
+// types.
+type Unsigned interface {
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// Float is a constraint that permits all floating-point
+// types, as accepted by valtra.MaxDecimalPlaces.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Text is a constraint that permits strings and byte slices,
+// whose length is their size in bytes.
+type Text interface {
+	~string | ~[]byte
+}
+
+// Lengthed is a constraint that permits strings and slices of
+// E, so a generic validator can take the length of the value
+// with len.
+type Lengthed[E any] interface {
+	~string | ~[]E
+}
+
+// Comparable is a constraint that permits all types that can
+// be compared with == and !=, as accepted by valtra.Equals
+// and valtra.OneOf.
+type Comparable interface {
+	comparable
+}
@@ -0,0 +1,62 @@
+package constraint_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/constraint"
+)
+
+// even is a third-party validator written against the
+// constraint package.
+func even[T constraint.Integer]() func(valtra.Value[T]) error {
+	return func(v valtra.Value[T]) error {
+		if v.Value()%2 != 0 {
+			return errors.New("must be even")
+		}
+
+		return nil
+	}
+}
+
+// shorterThan checks the length of strings and slices alike.
+func shorterThan[T constraint.Lengthed[E], E any](n int) func(valtra.Value[T]) error {
+	return func(v valtra.Value[T]) error {
+		if len(v.Value()) >= n {
+			return errors.New("too long")
+		}
+
+		return nil
+	}
+}
+
+// between passes its constraint on to valtra.Between.
+func between[T constraint.Ordered](min, max T) func(valtra.Value[T]) error {
+	return valtra.Between(min, max)
+}
+
+func TestConstraints(t *testing.T) {
+	type count uint8
+
+	tests := []struct {
+		name  string
+		v     valtra.Validated
+		valid bool
+	}{
+		{"even integer", valtra.Val(4).Validate(even[int]()), true},
+		{"odd integer", valtra.Val(count(3)).Validate(even[count]()), false},
+		{"short string", valtra.Val("ab").Validate(shorterThan[string, byte](3)), true},
+		{"long slice", valtra.Val([]int{1, 2, 3}).Validate(shorterThan[[]int, int](3)), false},
+		{"within valtra bounds", valtra.Val(2.5).Validate(between(1.0, 3.0)), true},
+		{"outside valtra bounds", valtra.Val(count(9)).Validate(between[count](1, 3)), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.v.IsValid() != tt.valid {
+				t.Errorf("Expected valid to be %v", tt.valid)
+			}
+		})
+	}
+}
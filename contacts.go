@@ -0,0 +1,165 @@
+package valtra
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// vcardContact holds the properties of a single vCard.
+type vcardContact struct {
+	index int
+	line  int
+	props []icalProperty
+}
+
+// VCard returns a validation that ensures the value is a
+// vCard file (version 3.0 or 4.0, RFC 2426 and RFC 6350)
+// holding one or more contacts, such as a bulk contact
+// import.
+//
+// Each contact must have a VERSION of 3.0 or 4.0 (which must
+// be its first property in version 4.0) and an FN property
+// (and an N property in version 3.0).
+// Embedded email addresses are checked with Email, and
+// telephone numbers must be phone numbers once visual
+// separators such as spaces and dashes are removed, checked
+// with PhoneNumber if they are in international format.
+//
+// Structural problems produce a single error. Otherwise every
+// invalid contact produces its own error, identifying the
+// contact by its position and explaining the problem, and the
+// errors are joined into a single error. As with ICalendar,
+// errors of positioned values point at the offending line.
+//
+// Options such as WithMessage can be provided as the
+// parameters, and apply to every error.
+//
+// Example:
+//
+//	valtra.Val(upload, "contacts").Validate(valtra.VCard())
+func VCard(opts ...Option) func(Value[[]byte]) error {
+	return func(v Value[[]byte]) error {
+		contacts, reason, line := parseVCards(v.value)
+		if reason != "" {
+			return newError(atLine(v, line), "vcard", map[string]any{"reason": reason}, opts)
+		}
+
+		var errs []error
+		for _, contact := range contacts {
+			if reason := checkVCard(contact); reason != "" {
+				errs = append(errs, newError(atLine(v, contact.line), "vcard_contact", map[string]any{"contact": contact.index, "reason": reason}, opts))
+			}
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// parseVCards parses the contacts of a vCard file, returning
+// them, or the reason the file is malformed along with the
+// line of the problem (0 if it concerns the whole file).
+func parseVCards(data []byte) ([]vcardContact, string, int) {
+	var (
+		contacts []vcardContact
+		open     bool
+	)
+	for _, line := range unfoldLines(data) {
+		if strings.TrimSpace(line.text) == "" {
+			continue
+		}
+
+		prop, ok := parseICalLine(line.text)
+		if !ok {
+			return nil, fmt.Sprintf("malformed line %d", line.line), line.line
+		}
+
+		// Drop property groups, e.g. "item1.EMAIL"
+		if _, name, ok := strings.Cut(prop.name, "."); ok {
+			prop.name = name
+		}
+
+		switch {
+		case prop.name == "BEGIN" && strings.EqualFold(prop.value, "VCARD"):
+			if open {
+				return nil, fmt.Sprintf("nested BEGIN:VCARD on line %d", line.line), line.line
+			}
+			open = true
+			contacts = append(contacts, vcardContact{index: len(contacts) + 1, line: line.line})
+		case prop.name == "END" && strings.EqualFold(prop.value, "VCARD"):
+			if !open {
+				return nil, fmt.Sprintf("unexpected END:VCARD on line %d", line.line), line.line
+			}
+			open = false
+		case !open:
+			return nil, fmt.Sprintf("content outside VCARD on line %d", line.line), line.line
+		default:
+			contact := &contacts[len(contacts)-1]
+			contact.props = append(contact.props, prop)
+		}
+	}
+
+	switch {
+	case open:
+		return nil, "unterminated VCARD", 0
+	case len(contacts) == 0:
+		return nil, "no contacts", 0
+	}
+
+	return contacts, "", 0
+}
+
+// checkVCard checks the properties of a contact, returning
+// the reason it is invalid, or "" if it is valid.
+func checkVCard(contact vcardContact) string {
+	var version string
+	has := map[string]bool{}
+	for i, prop := range contact.props {
+		switch prop.name {
+		case "VERSION":
+			if version != "" {
+				return "duplicate VERSION"
+			}
+			version = prop.value
+			if version == "4.0" && i > 0 {
+				return "VERSION must be the first property"
+			}
+		case "EMAIL":
+			if Email()(Val(prop.value)) != nil {
+				return "invalid EMAIL"
+			}
+		case "TEL":
+			if !isVCardPhone(prop.value) {
+				return "invalid TEL"
+			}
+		}
+
+		has[prop.name] = true
+	}
+
+	switch {
+	case version == "":
+		return "missing VERSION"
+	case version != "3.0" && version != "4.0":
+		return "unsupported VERSION"
+	case !has["FN"]:
+		return "missing FN"
+	case version == "3.0" && !has["N"]:
+		return "missing N"
+	}
+
+	return ""
+}
+
+// isVCardPhone reports whether the value of a TEL property,
+// which may be a "tel:" URI in version 4.0, is a phone number.
+func isVCardPhone(value string) bool {
+	number, _, _ := strings.Cut(strings.TrimPrefix(value, "tel:"), ";")
+	number = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "").Replace(number)
+
+	if strings.HasPrefix(number, "+") {
+		return PhoneNumber()(Val(number)) == nil
+	}
+
+	return len(number) >= 3 && len(number) <= 15 && NumericString()(Val(number)) == nil
+}
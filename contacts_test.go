@@ -0,0 +1,92 @@
+package valtra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// vcard assembles a vCard file from the given lines.
+func vcard(lines ...string) []byte {
+	return []byte(strings.Join(lines, "\r\n") + "\r\n")
+}
+
+func TestVCard(t *testing.T) {
+	t.Run("valid contacts pass", func(t *testing.T) {
+		v := valtra.Val(vcard(
+			"BEGIN:VCARD",
+			"VERSION:4.0",
+			"FN:Bobby Donev",
+			"EMAIL;TYPE=work:hello@bobbydonev.com",
+			"TEL;VALUE=uri;TYPE=cell:tel:+44-20-7183-8750",
+			"END:VCARD",
+			"BEGIN:VCARD",
+			"VERSION:3.0",
+			"N:Doe;Jane;;;",
+			"FN:Jane Doe",
+			"item1.EMAIL:jane@example.com",
+			"TEL;TYPE=HOME:(555) 123-4567",
+			"END:VCARD",
+		)).Validate(valtra.VCard())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("structural problems fail", func(t *testing.T) {
+		tests := map[string][]byte{
+			"no contacts":                     []byte(""),
+			"unterminated VCARD":              vcard("BEGIN:VCARD", "VERSION:4.0", "FN:Bob"),
+			"content outside VCARD on line 1": vcard("FN:Bob"),
+			"nested BEGIN:VCARD on line 3":    vcard("BEGIN:VCARD", "VERSION:4.0", "BEGIN:VCARD"),
+			"unexpected END:VCARD on line 1":  vcard("END:VCARD"),
+		}
+
+		for reason, data := range tests {
+			v := valtra.Val(data, "contacts").Validate(valtra.VCard())
+			if v.IsValid() {
+				t.Errorf("Expected validation to fail with %q", reason)
+				continue
+			}
+
+			expected := "contacts must be a valid vCard file (" + reason + ")"
+			if v.Errors()[0].Error() != expected {
+				t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+			}
+		}
+	})
+
+	t.Run("each invalid contact is reported", func(t *testing.T) {
+		v := valtra.Val(vcard(
+			"BEGIN:VCARD",
+			"VERSION:4.0",
+			"FN:Bobby",
+			"EMAIL:not-an-email",
+			"END:VCARD",
+			"BEGIN:VCARD",
+			"VERSION:4.0",
+			"FN:Jane",
+			"END:VCARD",
+			"BEGIN:VCARD",
+			"VERSION:3.0",
+			"FN:John",
+			"END:VCARD",
+			"BEGIN:VCARD",
+			"FN:Ana",
+			"VERSION:4.0",
+			"TEL:call me",
+			"END:VCARD",
+		), "contacts").Validate(valtra.VCard())
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail")
+		}
+
+		expected := `contacts contact 1 is invalid (invalid EMAIL)` + "\n" +
+			"contacts contact 3 is invalid (missing N)\n" +
+			"contacts contact 4 is invalid (VERSION must be the first property)"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+}
@@ -0,0 +1,321 @@
+package valtra
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// SchemaMismatch describes a difference between the JSON
+// Schema of a valtra schema (see Schema.JSONSchema) and the
+// schema a specification, such as an OpenAPI document,
+// declares for the same values.
+type SchemaMismatch struct {
+	// Field is the path of the mismatched field, e.g.
+	// "address.city" or "tags[]" for the items of a list, or
+	// empty for the value as a whole.
+	Field string
+	// Keyword is the JSON Schema keyword that differs, e.g.
+	// "maximum", "required" or "properties".
+	Keyword string
+	// Valtra and Spec hold the keyword's values on each side,
+	// or nil where it is not set.
+	Valtra any
+	Spec   any
+	// Looser reports whether valtra accepts values that the
+	// spec rejects, the difference that lets invalid input
+	// through.
+	Looser bool
+}
+
+// String describes the mismatch, e.g. "age: maximum is 150 in
+// valtra but 130 in the spec".
+func (m SchemaMismatch) String() string {
+	field := m.Field
+	if field == "" {
+		field = "value"
+	}
+
+	format := func(v any) string {
+		if v == nil {
+			return "unset"
+		}
+
+		return formatParam(v)
+	}
+
+	switch {
+	case m.Keyword == "properties" && m.Valtra != nil:
+		return field + " is not in the spec"
+	case m.Keyword == "properties":
+		return field + " is only in the spec"
+	}
+
+	return fmt.Sprintf("%s: %s is %s in valtra but %s in the spec", field, m.Keyword, format(m.Valtra), format(m.Spec))
+}
+
+// jsonSchemaMinimums and jsonSchemaMaximums list the JSON
+// Schema keywords for lower and upper limits.
+var (
+	jsonSchemaMinimums = []string{"minimum", "minLength", "minItems", "minProperties"}
+	jsonSchemaMaximums = []string{"maximum", "maxLength", "maxItems", "maxProperties"}
+)
+
+// CompareOpenAPI compares the schema's JSON Schema with the
+// named component schema of an OpenAPI document, decoded from
+// JSON into a map, and returns their mismatches: differing
+// types and properties, missing and differing constraints, and
+// limits that are looser or stricter in valtra.
+//
+// Local references ("$ref": "#/components/schemas/Address")
+// in the document are followed. As with JSONSchema, only the
+// constraints declared by struct tags are compared. It returns
+// an error if the document has no such component.
+//
+// It suits keeping code and spec aligned in CI.
+//
+// Example:
+//
+//	mismatches, err := userSchema.CompareOpenAPI(openAPIDoc, "User")
+//	for _, m := range mismatches {
+//	    t.Error(m)
+//	}
+func (s Schema[T]) CompareOpenAPI(doc map[string]any, component string) ([]SchemaMismatch, error) {
+	spec, ok := jsonPointer(doc, "/components/schemas/"+component)
+	if !ok {
+		return nil, fmt.Errorf("valtra: OpenAPI document has no component schema %q", component)
+	}
+
+	c := schemaComparison{doc: doc}
+	c.compare("", s.JSONSchema(), spec)
+
+	return c.mismatches, nil
+}
+
+// schemaComparison collects the mismatches between two JSON
+// Schemas, resolving references against doc.
+type schemaComparison struct {
+	doc        map[string]any
+	mismatches []SchemaMismatch
+}
+
+// report records a mismatch.
+func (c *schemaComparison) report(field, keyword string, valtra, spec any, looser bool) {
+	c.mismatches = append(c.mismatches, SchemaMismatch{Field: field, Keyword: keyword, Valtra: valtra, Spec: spec, Looser: looser})
+}
+
+// compare compares valtra's schema of the field with the
+// spec's. Fields that valtra's schema does not describe, such
+// as recursive ones, are not compared.
+func (c *schemaComparison) compare(field string, schema map[string]any, spec any) {
+	if len(schema) == 0 {
+		return
+	}
+	specSchema := c.resolve(spec)
+
+	if t, specType := schema["type"], jsonSchemaType(specSchema["type"]); specType != nil && t != specType {
+		c.report(field, "type", t, specType, false)
+		return
+	}
+
+	for _, keyword := range jsonSchemaMinimums {
+		c.compareLimit(field, keyword, schema, specSchema, func(a, b float64) bool { return a < b })
+	}
+	for _, keyword := range jsonSchemaMaximums {
+		c.compareLimit(field, keyword, schema, specSchema, func(a, b float64) bool { return a > b })
+	}
+	for _, keyword := range []string{"format", "pattern", "allOf", "enum"} {
+		if v, sv := schema[keyword], specSchema[keyword]; (v != nil || sv != nil) && !reflect.DeepEqual(normaliseJSON(v), normaliseJSON(sv)) {
+			c.report(field, keyword, v, sv, v == nil)
+		}
+	}
+
+	c.compareProperties(field, schema, specSchema)
+	if items, ok := schema["items"].(map[string]any); ok && specSchema["items"] != nil {
+		c.compare(field+"[]", items, specSchema["items"])
+	}
+	if values, ok := schema["additionalProperties"].(map[string]any); ok {
+		if _, ok := specSchema["additionalProperties"].(map[string]any); ok {
+			c.compare(field+"[]", values, specSchema["additionalProperties"])
+		}
+	}
+}
+
+// compareLimit compares a limit keyword, using looser to
+// decide whether valtra's limit is looser than the spec's.
+func (c *schemaComparison) compareLimit(field, keyword string, schema, spec map[string]any, looser func(a, b float64) bool) {
+	v, ok := jsonNumber(schema[keyword])
+	sv, specOK := jsonNumber(spec[keyword])
+	switch {
+	case !ok && !specOK, ok && specOK && v == sv:
+	case !ok:
+		c.report(field, keyword, nil, sv, true)
+	case !specOK:
+		c.report(field, keyword, v, nil, false)
+	default:
+		c.report(field, keyword, v, sv, looser(v, sv))
+	}
+}
+
+// compareProperties compares the properties of two object
+// schemas, and which of them are required.
+func (c *schemaComparison) compareProperties(field string, schema, spec map[string]any) {
+	properties, _ := schema["properties"].(map[string]any)
+	specProperties, _ := spec["properties"].(map[string]any)
+	if properties == nil || specProperties == nil {
+		return
+	}
+
+	required := jsonStrings(schema["required"])
+	specRequired := jsonStrings(spec["required"])
+	for _, name := range slices.Sorted(maps.Keys(properties)) {
+		path := name
+		if field != "" {
+			path = field + "." + name
+		}
+
+		specProp, ok := specProperties[name]
+		if !ok {
+			c.report(path, "properties", "declared", nil, false)
+			continue
+		}
+
+		if r, sr := slices.Contains(required, name), slices.Contains(specRequired, name); r != sr {
+			c.report(path, "required", r, sr, sr)
+		}
+		prop, _ := properties[name].(map[string]any)
+		c.compare(path, prop, specProp)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(specProperties)) {
+		if _, ok := properties[name]; !ok {
+			path := name
+			if field != "" {
+				path = field + "." + name
+			}
+			c.report(path, "properties", nil, "declared", false)
+		}
+	}
+}
+
+// resolve returns the schema, following local references. It
+// gives up on chains of references longer than 32, which can
+// only come from cycles.
+func (c *schemaComparison) resolve(spec any) map[string]any {
+	schema, _ := spec.(map[string]any)
+	for range 32 {
+		ref, ok := schema["$ref"].(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return schema
+		}
+
+		target, _ := jsonPointer(c.doc, ref[1:])
+		schema, _ = target.(map[string]any)
+	}
+
+	return nil
+}
+
+// jsonPointer returns the value the JSON pointer (RFC 6901)
+// refers to in the document.
+func jsonPointer(doc any, pointer string) (any, bool) {
+	if pointer == "" {
+		return doc, true
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, false
+	}
+
+	value := doc
+	for token := range strings.SplitSeq(pointer[1:], "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		switch v := value.(type) {
+		case map[string]any:
+			var ok bool
+			if value, ok = v[token]; !ok {
+				return nil, false
+			}
+		case []any:
+			i, err := strconv.Atoi(token)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// jsonSchemaType returns the type of a spec schema, treating
+// ["string", "null"] (OpenAPI 3.1's nullable) as "string".
+func jsonSchemaType(t any) any {
+	types, ok := t.([]any)
+	if !ok {
+		return t
+	}
+
+	types = slices.DeleteFunc(slices.Clone(types), func(t any) bool { return t == "null" })
+	if len(types) == 1 {
+		return types[0]
+	}
+
+	return t
+}
+
+// jsonNumber returns a JSON number as a float64.
+func jsonNumber(v any) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	}
+
+	return 0, false
+}
+
+// jsonStrings returns the strings in a JSON array, such as
+// the "required" keyword's.
+func jsonStrings(v any) []string {
+	switch s := v.(type) {
+	case []string:
+		return s
+	case []any:
+		var strs []string
+		for _, e := range s {
+			if str, ok := e.(string); ok {
+				strs = append(strs, str)
+			}
+		}
+		return strs
+	}
+
+	return nil
+}
+
+// normaliseJSON returns the value as it would be decoded from
+// JSON, so that values built in Go compare equal to decoded
+// ones, e.g. []any{int64(1)} and []any{1.0}.
+func normaliseJSON(v any) any {
+	switch v := v.(type) {
+	case []any:
+		normalised := make([]any, len(v))
+		for i, e := range v {
+			normalised[i] = normaliseJSON(e)
+		}
+		return normalised
+	default:
+		if n, ok := jsonNumber(v); ok {
+			return n
+		}
+		return v
+	}
+}
@@ -0,0 +1,94 @@
+package valtra_test
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+const contractSpec = `{
+	"openapi": "3.1.0",
+	"components": {
+		"schemas": {
+			"User": {
+				"type": "object",
+				"required": ["email", "age"],
+				"properties": {
+					"email": {"type": "string", "format": "email"},
+					"age": {"type": "integer", "minimum": 18, "maximum": 130},
+					"role": {"type": "string", "enum": ["admin", "user"]},
+					"tags": {"type": "array", "items": {"type": "string", "maxLength": 10}},
+					"address": {"$ref": "#/components/schemas/Address"},
+					"nickname": {"type": ["string", "null"]},
+					"phone": {"type": "string"}
+				}
+			},
+			"Address": {
+				"type": "object",
+				"properties": {
+					"city": {"type": "string", "minLength": 1}
+				}
+			}
+		}
+	}
+}`
+
+func TestCompareOpenAPI(t *testing.T) {
+	type address struct {
+		City string `json:"city" valtra:"min=1"`
+	}
+	type user struct {
+		Email    string   `json:"email" valtra:"required"`
+		Age      int      `json:"age" valtra:"required,min=18,max=150"`
+		Role     string   `json:"role" valtra:"oneof=admin user"`
+		Tags     []string `json:"tags"`
+		Address  address  `json:"address"`
+		Nickname string   `json:"nickname"`
+		Internal bool     `json:"internal"`
+	}
+
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(contractSpec), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("mismatches are reported", func(t *testing.T) {
+		mismatches, err := valtra.NewSchema[user]().CompareOpenAPI(doc, "User")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		var got []string
+		for _, m := range mismatches {
+			got = append(got, m.String())
+		}
+		want := []string{
+			"age: maximum is 150 in valtra but 130 in the spec",
+			"email: format is unset in valtra but email in the spec",
+			"internal is not in the spec",
+			"tags[]: maxLength is unset in valtra but 10 in the spec",
+			"phone is only in the spec",
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(want, "\n"), strings.Join(got, "\n"))
+		}
+		if len(mismatches) > 0 && !mismatches[0].Looser {
+			t.Error("Expected the higher maximum to be looser")
+		}
+	})
+
+	t.Run("matching schemas have no mismatches", func(t *testing.T) {
+		mismatches, err := valtra.NewSchema[address]().CompareOpenAPI(doc, "Address")
+		if err != nil || len(mismatches) != 0 {
+			t.Errorf("Unexpected result: %v, %v", mismatches, err)
+		}
+	})
+
+	t.Run("missing components are errors", func(t *testing.T) {
+		if _, err := valtra.NewSchema[user]().CompareOpenAPI(doc, "Order"); err == nil {
+			t.Error("Expected an error")
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package valtra
+
+import "strings"
+
+// MaxCookieSize is the size in bytes that browsers are
+// required to support for a cookie's name and value combined
+// (RFC 6265, section 6.1). Larger cookies may be silently
+// dropped.
+const MaxCookieSize = 4096
+
+// cookieSeparators holds the characters RFC 2616 excludes
+// from tokens, and so from cookie names.
+const cookieSeparators = "()<>@,;:\\\"/[]?={} \t"
+
+// CookieName returns a validation that ensures the value is
+// a valid cookie name, i.e. a non-empty RFC 6265 token made
+// of printable ASCII characters other than separators.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("session_id").Validate(valtra.CookieName())
+func CookieName(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if v.value == "" || strings.ContainsFunc(v.value, func(r rune) bool {
+			return r <= ' ' || r >= 0x7f || strings.ContainsRune(cookieSeparators, r)
+		}) {
+			return newError(v, "cookie_name", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// CookieValueSafe returns a validation that ensures the value
+// can be stored in a cookie as is, without further encoding.
+//
+// The value must consist of RFC 6265 cookie octets (printable
+// ASCII excluding whitespace, double quotes, commas,
+// semicolons and backslashes), optionally wrapped in double
+// quotes, and cannot be larger than MaxCookieSize bytes.
+// Values that fail should be encoded, e.g. as URL-safe base64.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(token, "session").Validate(valtra.CookieValueSafe())
+func CookieValueSafe(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if len(v.value) > MaxCookieSize {
+			return newError(v, "cookie_size", map[string]any{"max": MaxCookieSize}, opts)
+		}
+
+		value := v.value
+		if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+			value = value[1 : len(value)-1]
+		}
+
+		if strings.ContainsFunc(value, func(r rune) bool {
+			return r <= ' ' || r >= 0x7f || strings.ContainsRune("\",;\\", r)
+		}) {
+			return newError(v, "cookie_value", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// CookieSameSite returns a validation that ensures the value
+// is a valid SameSite cookie attribute: "Strict", "Lax" or
+// "None", compared case-insensitively.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(cfg.SameSite, "same_site").Validate(valtra.CookieSameSite())
+func CookieSameSite(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		switch strings.ToLower(v.value) {
+		case "strict", "lax", "none":
+			return nil
+		}
+
+		return newError(v, "cookie_same_site", map[string]any{"values": []string{"Strict", "Lax", "None"}}, opts)
+	}
+}
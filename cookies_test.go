@@ -0,0 +1,79 @@
+package valtra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestCookieName(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"simple name", "session_id", true},
+		{"token characters", "__Host-sid.v2!", true},
+		{"empty", "", false},
+		{"space", "session id", false},
+		{"separator", "session=id", false},
+		{"control character", "session\x00", false},
+		{"non-ASCII", "séance", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.value).Validate(valtra.CookieName())
+			if v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got errors: %v", tt.valid, v.Errors())
+			}
+		})
+	}
+}
+
+func TestCookieValueSafe(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		valid bool
+	}{
+		{"base64url token", "dGhpcy1pcy1hLXRva2Vu_-", true},
+		{"empty", "", true},
+		{"quoted", `"abc123"`, true},
+		{"space", "a b", false},
+		{"semicolon", "a;b", false},
+		{"comma", "a,b", false},
+		{"inner quote", `a"b`, false},
+		{"backslash", `a\b`, false},
+		{"non-ASCII", "café", false},
+		{"too large", strings.Repeat("a", valtra.MaxCookieSize+1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.value).Validate(valtra.CookieValueSafe())
+			if v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got errors: %v", tt.valid, v.Errors())
+			}
+		})
+	}
+}
+
+func TestCookieSameSite(t *testing.T) {
+	for _, value := range []string{"Strict", "lax", "NONE"} {
+		t.Run(value, func(t *testing.T) {
+			v := valtra.Val(value).Validate(valtra.CookieSameSite())
+			if !v.IsValid() {
+				t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+			}
+		})
+	}
+
+	t.Run("unknown value", func(t *testing.T) {
+		v := valtra.Val("loose", "same_site").Validate(valtra.CookieSameSite())
+		if v.IsValid() || v.Errors()[0].Error() != "same_site must be one of: Strict, Lax, None" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package valtra
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"strings"
+)
+
+// KeyProvider looks up the AES keys used by Encrypt and
+// Decrypt, typically from a key management service (KMS).
+//
+// Keys are identified by an ID that is stored with each
+// ciphertext, so keys can be rotated: new values are sealed
+// with the current key, while older values remain readable
+// for as long as their key can be looked up.
+type KeyProvider interface {
+	// CurrentKey returns the ID and the key to encrypt with.
+	CurrentKey() (id string, key []byte, err error)
+	// Key returns the key with the given ID.
+	Key(id string) ([]byte, error)
+}
+
+// StaticKeys returns a KeyProvider serving the given keys,
+// keyed by ID, which encrypts with the key of the current ID.
+//
+// It suits tests and applications that load their keys from
+// configuration at startup.
+//
+// Example:
+//
+//	keys := valtra.StaticKeys("2025-01", map[string][]byte{
+//	    "2024-01": oldKey,
+//	    "2025-01": newKey,
+//	})
+func StaticKeys(current string, keys map[string][]byte) KeyProvider {
+	return staticKeys{current: current, keys: maps.Clone(keys)}
+}
+
+// staticKeys is the KeyProvider returned by StaticKeys.
+type staticKeys struct {
+	current string
+	keys    map[string][]byte
+}
+
+func (k staticKeys) CurrentKey() (string, []byte, error) {
+	key, err := k.Key(k.current)
+	return k.current, key, err
+}
+
+func (k staticKeys) Key(id string) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("valtra: unknown key %q", id)
+	}
+
+	return key, nil
+}
+
+// Encrypt returns a transformation that seals the value with
+// AES-GCM, using the provider's current key, so sensitive
+// fields can be encrypted in the same pipeline that validates
+// them, before they are persisted.
+//
+// Keys must be 16, 24 or 32 bytes long, for AES-128, AES-192
+// or AES-256, and key IDs must not contain a colon. The result
+// has the form "<key ID>:<base64url(nonce + ciphertext)>", and
+// the key ID is authenticated along with the value. Each call
+// uses a random nonce, so equal values encrypt differently.
+//
+// Errors from the provider or from an invalid key are returned
+// wrapped with context. As with any failed transformation, the
+// value is then left unchanged, so it must not be persisted
+// unless the value is valid.
+//
+// Example:
+//
+//	ssn := valtra.Val(input.SSN, "ssn").
+//	    Validate(valtra.Match(`^\d{3}-\d{2}-\d{4}$`)).
+//	    Transform(valtra.Encrypt(keys))
+func Encrypt(provider KeyProvider) func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		id, key, err := provider.CurrentKey()
+		if err != nil {
+			return "", fmt.Errorf("valtra: cannot get encryption key for %s: %w", v.name, err)
+		}
+		if strings.Contains(id, ":") {
+			return "", fmt.Errorf("valtra: invalid key ID %q", id)
+		}
+
+		aead, err := newGCM(key)
+		if err != nil {
+			return "", fmt.Errorf("valtra: invalid encryption key %q: %w", id, err)
+		}
+
+		nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(v.value)+aead.Overhead())
+		rand.Read(nonce)
+		sealed := aead.Seal(nonce, nonce, []byte(v.value), []byte(id))
+
+		return id + ":" + base64.RawURLEncoding.EncodeToString(sealed), nil
+	}
+}
+
+// Decrypt returns a transformation that opens a value sealed
+// by Encrypt, looking up its key by the ID stored with it.
+//
+// Values that are malformed, or fail authentication because
+// they were tampered with or sealed with another key, fail
+// with the code "decrypt". Errors from the provider are
+// returned as they are, wrapped with context.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	ssn, err := valtra.NewSchema[string]().Transform(valtra.Decrypt(keys)).Run(record.SSN, "ssn")
+func Decrypt(provider KeyProvider, opts ...Option) func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		id, encoded, ok := strings.Cut(v.value, ":")
+		sealed, err := base64.RawURLEncoding.DecodeString(encoded)
+		if !ok || err != nil {
+			return "", newError(v, "decrypt", nil, opts)
+		}
+
+		key, err := provider.Key(id)
+		if err != nil {
+			return "", fmt.Errorf("valtra: cannot get decryption key %q for %s: %w", id, v.name, err)
+		}
+
+		aead, err := newGCM(key)
+		if err != nil {
+			return "", fmt.Errorf("valtra: invalid decryption key %q: %w", id, err)
+		}
+		if len(sealed) < aead.NonceSize() {
+			return "", newError(v, "decrypt", nil, opts)
+		}
+
+		nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+		plaintext, err := aead.Open(nil, nonce, ciphertext, []byte(id))
+		if err != nil {
+			return "", newError(v, "decrypt", nil, opts)
+		}
+
+		return string(plaintext), nil
+	}
+}
+
+// Pseudonymize returns a transformation that replaces the
+// value with a stable pseudonym: the hex-encoded HMAC-SHA256
+// of the value, keyed with the salt.
+//
+// Equal values always get the same pseudonym, so identifiers
+// such as emails and user IDs can still be counted and joined
+// in analytics without being stored. The salt must be kept
+// secret, as anyone holding it can check guesses, and changing
+// it changes every pseudonym. Values are used as they are, so
+// normalise them first, e.g. with TrimSpace and Lowercase.
+//
+// Example:
+//
+//	userID := valtra.Val(event.Email, "email").
+//	    Transform(valtra.TrimSpace(), valtra.Lowercase()).
+//	    Validate(valtra.Email()).
+//	    Transform(valtra.Pseudonymize(salt))
+func Pseudonymize(salt []byte) func(Value[string]) (string, error) {
+	salt = bytes.Clone(salt)
+
+	return func(v Value[string]) (string, error) {
+		mac := hmac.New(sha256.New, salt)
+		mac.Write([]byte(v.value))
+
+		return hex.EncodeToString(mac.Sum(nil)), nil
+	}
+}
+
+// newGCM returns an AES-GCM cipher for the key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
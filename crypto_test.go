@@ -0,0 +1,119 @@
+package valtra_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestEncrypt(t *testing.T) {
+	oldKey, newKey := bytes.Repeat([]byte{1}, 16), bytes.Repeat([]byte{2}, 32)
+	keys := valtra.StaticKeys("new", map[string][]byte{"old": oldKey, "new": newKey})
+
+	t.Run("values round-trip", func(t *testing.T) {
+		sealed := valtra.Val("123-45-6789", "ssn").Transform(valtra.Encrypt(keys))
+		if !sealed.IsValid() || !strings.HasPrefix(sealed.Value(), "new:") || strings.Contains(sealed.Value(), "6789") {
+			t.Fatalf("Expected a ciphertext for the new key, got %q (%v)", sealed.Value(), sealed.Errors())
+		}
+
+		opened := valtra.Val(sealed.Value(), "ssn").Transform(valtra.Decrypt(keys))
+		if !opened.IsValid() || opened.Value() != "123-45-6789" {
+			t.Errorf("Expected the original value, got %q (%v)", opened.Value(), opened.Errors())
+		}
+	})
+
+	t.Run("equal values encrypt differently", func(t *testing.T) {
+		a := valtra.Val("secret").Transform(valtra.Encrypt(keys)).Value()
+		b := valtra.Val("secret").Transform(valtra.Encrypt(keys)).Value()
+		if a == b {
+			t.Errorf("Expected different ciphertexts, got %q twice", a)
+		}
+	})
+
+	t.Run("values sealed with older keys can be decrypted", func(t *testing.T) {
+		old := valtra.StaticKeys("old", map[string][]byte{"old": oldKey})
+		sealed := valtra.Val("secret").Transform(valtra.Encrypt(old)).Value()
+
+		opened := valtra.Val(sealed).Transform(valtra.Decrypt(keys))
+		if opened.Value() != "secret" {
+			t.Errorf("Expected %q, got %q (%v)", "secret", opened.Value(), opened.Errors())
+		}
+	})
+
+	t.Run("tampered values fail", func(t *testing.T) {
+		sealed := valtra.Val("secret").Transform(valtra.Encrypt(keys)).Value()
+		// The last character may carry unused bits, so modify
+		// one in the middle of the ciphertext.
+		mid := len(sealed) / 2
+		flipped := "A"
+		if sealed[mid] == 'A' {
+			flipped = "B"
+		}
+
+		tests := map[string]string{
+			"modified ciphertext": sealed[:mid] + flipped + sealed[mid+1:],
+			"swapped key ID":      "old" + strings.TrimPrefix(sealed, "new"),
+			"missing key ID":      strings.TrimPrefix(sealed, "new:"),
+			"not base64":          "new:!!!",
+			"too short":           "new:AAAA",
+		}
+
+		for name, input := range tests {
+			t.Run(name, func(t *testing.T) {
+				v := valtra.Val(input, "token").Transform(valtra.Decrypt(keys))
+
+				var ve *valtra.ValidationError
+				if len(v.Errors()) != 1 || !errors.As(v.Errors()[0], &ve) || ve.Code != "decrypt" {
+					t.Fatalf("Expected a decrypt error, got %v", v.Errors())
+				}
+				if want := "token could not be decrypted"; ve.Error() != want {
+					t.Errorf("Expected %q, got %q", want, ve.Error())
+				}
+			})
+		}
+	})
+
+	t.Run("provider errors are returned", func(t *testing.T) {
+		missing := valtra.StaticKeys("missing", nil)
+		v := valtra.Val("secret").Transform(valtra.Encrypt(missing))
+
+		var ve *valtra.ValidationError
+		if v.IsValid() || errors.As(v.Errors()[0], &ve) || v.Value() != "secret" {
+			t.Errorf("Expected a provider error with the value unchanged, got %q (%v)", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("invalid keys are reported", func(t *testing.T) {
+		short := valtra.StaticKeys("short", map[string][]byte{"short": []byte("too short")})
+		if v := valtra.Val("secret").Transform(valtra.Encrypt(short)); v.IsValid() {
+			t.Error("Expected an invalid key error")
+		}
+	})
+}
+
+func TestPseudonymize(t *testing.T) {
+	salt := []byte("secret salt")
+
+	t.Run("pseudonyms are stable HMACs", func(t *testing.T) {
+		a := valtra.Val("bob@example.com").Transform(valtra.Pseudonymize(salt)).Value()
+		b := valtra.Val("bob@example.com").Transform(valtra.Pseudonymize(salt)).Value()
+
+		// HMAC-SHA256("secret salt", "bob@example.com")
+		want := "5ede37cf4f8cfbd905ad8d433d9149a5f2c77f167b253c4321026203b098871e"
+		if a != want || b != want {
+			t.Errorf("Expected %q twice, got %q and %q", want, a, b)
+		}
+	})
+
+	t.Run("different values and salts give different pseudonyms", func(t *testing.T) {
+		a := valtra.Val("bob@example.com").Transform(valtra.Pseudonymize(salt)).Value()
+		b := valtra.Val("alice@example.com").Transform(valtra.Pseudonymize(salt)).Value()
+		c := valtra.Val("bob@example.com").Transform(valtra.Pseudonymize([]byte("other salt"))).Value()
+		if a == b || a == c {
+			t.Errorf("Expected distinct pseudonyms, got %q, %q and %q", a, b, c)
+		}
+	})
+}
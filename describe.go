@@ -0,0 +1,306 @@
+package valtra
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RuleInfo describes a validation rule, so tooling such as
+// admin UIs or documentation generators can list the rules
+// that apply to a value.
+type RuleInfo struct {
+	// Field is the name of the field the rule applies to, e.g.
+	// "address.city", or empty if it applies to the value as
+	// a whole.
+	Field string
+	// Name identifies the rule, e.g. "min".
+	Name string
+	// Params holds the rule's parameters, e.g. "min": 18.
+	Params map[string]any
+	// EffectiveFrom and Until bound the period in which the
+	// rule is applied (see Rule.EffectiveFrom and Rule.Until).
+	// A zero time leaves that end of the period open.
+	EffectiveFrom time.Time
+	Until         time.Time
+	// Country is the country whose values the rule applies to,
+	// if it is part of a pack selected by RulesByCountry, or
+	// "*" for the pack applied to other countries.
+	Country string
+}
+
+// ActiveAt reports whether the rule is applied at the given
+// time: from its EffectiveFrom time, inclusive, until its
+// Until time, exclusive.
+func (r RuleInfo) ActiveAt(t time.Time) bool {
+	return (r.EffectiveFrom.IsZero() || !t.Before(r.EffectiveFrom)) && (r.Until.IsZero() || t.Before(r.Until))
+}
+
+// String formats the rule as its name, followed by its
+// parameters: "required", "min=18" when its only parameter is
+// named after it, or "between(max=130, min=18)" otherwise.
+func (r RuleInfo) String() string {
+	switch param, ok := r.Params[r.Name]; {
+	case len(r.Params) == 0:
+		return r.Name
+	case len(r.Params) == 1 && ok:
+		return r.Name + "=" + formatParam(param)
+	}
+
+	params := make([]string, 0, len(r.Params))
+	for _, k := range slices.Sorted(maps.Keys(r.Params)) {
+		params = append(params, k+"="+formatParam(r.Params[k]))
+	}
+
+	return fmt.Sprintf("%s(%s)", r.Name, strings.Join(params, ", "))
+}
+
+// Rule is a validation along with the metadata describing it.
+//
+// Rules are created with Describe and FieldRule, and added to
+// schemas with Schema.Rules, which makes them retrievable with
+// Schema.Describe.
+type Rule[T any] struct {
+	check func(Value[T]) error
+	info  []RuleInfo
+}
+
+// Describe returns a Rule applying the given validation,
+// described by the given name and parameters.
+//
+// Example:
+//
+//	adult := valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18))
+func Describe[T any](name string, params map[string]any, validation func(Value[T]) error) Rule[T] {
+	return Rule[T]{
+		check: validation,
+		info:  []RuleInfo{{Name: name, Params: params}},
+	}
+}
+
+// FieldRule returns a Rule applying the given rules to a
+// field of a struct value, as Field does, with their metadata
+// attributed to the field.
+//
+// Example:
+//
+//	valtra.FieldRule("age", func(s Signup) int { return s.Age },
+//	    valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18)),
+//	)
+func FieldRule[T, U any](name string, get func(T) U, rules ...Rule[U]) Rule[T] {
+	validations := make([]func(Value[U]) error, len(rules))
+	var info []RuleInfo
+	for i, r := range rules {
+		validations[i] = r.check
+		for _, ri := range r.info {
+			if ri.Field != "" {
+				ri.Field = name + "." + ri.Field
+			} else {
+				ri.Field = name
+			}
+			info = append(info, ri)
+		}
+	}
+
+	return Rule[T]{check: Field(name, get, validations...), info: info}
+}
+
+// Check applies the rule to the value. It lets a rule be
+// passed to Value's Validate method, e.g. Validate(r.Check).
+func (r Rule[T]) Check(v Value[T]) error {
+	return r.check(v)
+}
+
+// Info returns the metadata describing the rule.
+func (r Rule[T]) Info() []RuleInfo {
+	return slices.Clone(r.info)
+}
+
+// EffectiveFrom returns a copy of the rule that is only
+// applied from the given time onwards, passing every value
+// before then. It lets rule changes that take effect on a
+// known date, such as a new VAT number format, be shipped
+// ahead of time.
+//
+// The time is checked each time the rule runs, and is kept in
+// the rule's metadata, so Schema.DescribeAt lists the rules
+// applied at a given time.
+//
+// Example:
+//
+//	newVAT := valtra.Describe("vat", nil, newVATFormat).EffectiveFrom(jan1)
+//	oldVAT := valtra.Describe("vat", nil, oldVATFormat).Until(jan1)
+//	var vatSchema = valtra.NewSchema[string]().Rules(oldVAT, newVAT)
+func (r Rule[T]) EffectiveFrom(t time.Time) Rule[T] {
+	info := slices.Clone(r.info)
+	for i := range info {
+		if info[i].EffectiveFrom.IsZero() || t.After(info[i].EffectiveFrom) {
+			info[i].EffectiveFrom = t
+		}
+	}
+
+	check := r.check
+	return Rule[T]{
+		check: func(v Value[T]) error {
+			if time.Now().Before(t) {
+				return nil
+			}
+
+			return check(v)
+		},
+		info: info,
+	}
+}
+
+// Until returns a copy of the rule that is only applied
+// before the given time, passing every value from then on.
+//
+// It is the counterpart of EffectiveFrom, and the two can be
+// combined to apply a rule for a limited period.
+func (r Rule[T]) Until(t time.Time) Rule[T] {
+	info := slices.Clone(r.info)
+	for i := range info {
+		if info[i].Until.IsZero() || t.Before(info[i].Until) {
+			info[i].Until = t
+		}
+	}
+
+	check := r.check
+	return Rule[T]{
+		check: func(v Value[T]) error {
+			if !time.Now().Before(t) {
+				return nil
+			}
+
+			return check(v)
+		},
+		info: info,
+	}
+}
+
+// Rules returns a new schema that applies the provided rules
+// after the schema's existing steps, as Validate does, while
+// keeping their metadata.
+func (s Schema[T]) Rules(rules ...Rule[T]) Schema[T] {
+	steps := slices.Clip(s.steps)
+	for _, r := range rules {
+		steps = append(steps, step[T]{validate: r.check, info: r.info})
+	}
+
+	return Schema[T]{steps: steps}
+}
+
+// Describe returns the metadata of the schema's rules, in the
+// order they are applied, including rules that are only
+// applied at other times (see DescribeAt).
+//
+// Only rules added with Rules are described: validations
+// added with Validate are plain functions, which carry no
+// metadata.
+//
+// Example:
+//
+//	for _, r := range signupSchema.Describe() {
+//	    fmt.Printf("%s: %s\n", r.Field, r) // age: min=18
+//	}
+func (s Schema[T]) Describe() []RuleInfo {
+	var info []RuleInfo
+	for _, st := range s.steps {
+		info = append(info, st.info...)
+	}
+
+	return info
+}
+
+// DescribeAt returns the metadata of the schema's rules that
+// are applied at the given time, leaving out those outside
+// their EffectiveFrom and Until period.
+//
+// Example:
+//
+//	active := vatSchema.DescribeAt(time.Now())
+func (s Schema[T]) DescribeAt(t time.Time) []RuleInfo {
+	var info []RuleInfo
+	for _, ri := range s.Describe() {
+		if ri.ActiveAt(t) {
+			info = append(info, ri)
+		}
+	}
+
+	return info
+}
+
+// DescribeStruct returns the metadata of the rules declared
+// by the `valtra` struct tags of a struct (or a pointer to
+// one), in field order. Fields are named as by
+// ValidateStruct.
+//
+// Parameters are keyed by the rule's name and kept as
+// strings, e.g. "min": "18" for a min=18 tag rule. Rules
+// without parameters, such as required, have none.
+//
+// Example:
+//
+//	valtra.DescribeStruct(User{}) // [{age min map[min:18]} ...]
+func DescribeStruct(s any) []RuleInfo {
+	t := reflect.TypeOf(s)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var info []RuleInfo
+	describeStruct(t, "", map[reflect.Type]bool{}, &info)
+	return info
+}
+
+// describeStruct adds the metadata of the tag rules of the
+// struct type's fields to info, prefixing their names with
+// the given prefix. Types being described are tracked in
+// seen, so recursive types are only described once.
+func describeStruct(t reflect.Type, prefix string, seen map[reflect.Type]bool, info *[]RuleInfo) {
+	if seen[t] {
+		return
+	}
+	seen[t] = true
+	defer delete(seen, t)
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("valtra")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+
+		name := prefix + structFieldName(sf)
+		for rule := range strings.SplitSeq(tag, ",") {
+			rule, param, hasParam := strings.Cut(strings.TrimSpace(rule), "=")
+			if rule == "" {
+				continue
+			}
+
+			ri := RuleInfo{Field: name, Name: rule}
+			if hasParam {
+				ri.Params = map[string]any{rule: param}
+			}
+			*info = append(*info, ri)
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct && ft != timeType {
+			if sf.Anonymous && tag == "" {
+				describeStruct(ft, prefix, seen, info)
+			} else {
+				describeStruct(ft, name+".", seen, info)
+			}
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package valtra_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestRuleInfoString(t *testing.T) {
+	tests := []struct {
+		info valtra.RuleInfo
+		want string
+	}{
+		{valtra.RuleInfo{Name: "required"}, "required"},
+		{valtra.RuleInfo{Name: "min", Params: map[string]any{"min": 18}}, "min=18"},
+		{valtra.RuleInfo{Name: "between", Params: map[string]any{"min": 18, "max": 130}}, "between(max=130, min=18)"},
+		{valtra.RuleInfo{Name: "one_of", Params: map[string]any{"values": []string{"a", "b"}}}, "one_of(values=a, b)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.info.String(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestSchemaDescribe(t *testing.T) {
+	type signup struct {
+		Email string
+		Age   int
+	}
+
+	schema := valtra.NewSchema[signup]().
+		Validate(func(v valtra.Value[signup]) error { return nil }).
+		Rules(
+			valtra.FieldRule("email", func(s signup) string { return s.Email },
+				valtra.Describe("required", nil, valtra.Required[string]()),
+			),
+			valtra.FieldRule("age", func(s signup) int { return s.Age },
+				valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18)),
+				valtra.Describe("max", map[string]any{"max": 130}, valtra.Max(130)),
+			),
+		)
+
+	t.Run("described rules are listed", func(t *testing.T) {
+		var got []string
+		for _, r := range schema.Describe() {
+			got = append(got, fmt.Sprintf("%s: %s", r.Field, r))
+		}
+
+		if want := "email: required; age: min=18; age: max=130"; strings.Join(got, "; ") != want {
+			t.Errorf("Expected %q, got %q", want, strings.Join(got, "; "))
+		}
+	})
+
+	t.Run("described rules still validate", func(t *testing.T) {
+		_, err := schema.Run(signup{Email: "bobby@example.com", Age: 16})
+		if err == nil || err.Error() != "age cannot be smaller than 18" {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+
+	t.Run("rules can be used with Validate", func(t *testing.T) {
+		adult := valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18))
+		if valtra.Val(16).Validate(adult.Check).IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if len(adult.Info()) != 1 || adult.Info()[0].Name != "min" {
+			t.Errorf("Unexpected info: %v", adult.Info())
+		}
+	})
+
+	t.Run("nested field rules are prefixed", func(t *testing.T) {
+		type order struct{ Buyer signup }
+
+		rule := valtra.FieldRule("buyer", func(o order) signup { return o.Buyer },
+			valtra.FieldRule("age", func(s signup) int { return s.Age },
+				valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18)),
+			),
+		)
+		if info := rule.Info(); len(info) != 1 || info[0].Field != "buyer.age" {
+			t.Errorf("Unexpected info: %v", info)
+		}
+	})
+}
+
+func TestRuleWindows(t *testing.T) {
+	past, future := time.Now().Add(-time.Hour), time.Now().Add(time.Hour)
+	oldFormat := valtra.Describe("vat", map[string]any{"vat": "old"}, valtra.Match(`^[0-9]{9}$`)).Until(future)
+	newFormat := valtra.Describe("vat", map[string]any{"vat": "new"}, valtra.Match(`^GB[0-9]{9}$`)).EffectiveFrom(future)
+	schema := valtra.NewSchema[string]().Rules(oldFormat, newFormat)
+
+	t.Run("only rules in their period are applied", func(t *testing.T) {
+		if _, err := schema.Run("123456789"); err != nil {
+			t.Errorf("Expected the old format to pass, got %v", err)
+		}
+		if _, err := schema.Run("GB123456789"); err == nil {
+			t.Error("Expected the new format to fail before it takes effect")
+		}
+	})
+
+	t.Run("rules that took effect are applied", func(t *testing.T) {
+		rule := valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18)).EffectiveFrom(past)
+		if valtra.Val(16).Validate(rule.Check).IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+
+	t.Run("active rules are described", func(t *testing.T) {
+		if got := schema.DescribeAt(time.Now()); len(got) != 1 || got[0].Params["vat"] != "old" {
+			t.Errorf("Unexpected rules now: %v", got)
+		}
+		if got := schema.DescribeAt(future.Add(time.Second)); len(got) != 1 || got[0].Params["vat"] != "new" {
+			t.Errorf("Unexpected rules later: %v", got)
+		}
+		if got := schema.Describe(); len(got) != 2 || !got[1].EffectiveFrom.Equal(future) {
+			t.Errorf("Unexpected rules: %v", got)
+		}
+	})
+
+	t.Run("combined windows are narrowed", func(t *testing.T) {
+		rule := valtra.Describe("required", nil, valtra.Required[string]()).EffectiveFrom(past).Until(future).Until(future.Add(time.Hour))
+		if info := rule.Info()[0]; !info.EffectiveFrom.Equal(past) || !info.Until.Equal(future) {
+			t.Errorf("Unexpected window: %v to %v", info.EffectiveFrom, info.Until)
+		}
+	})
+}
+
+func TestDescribeStruct(t *testing.T) {
+	type address struct {
+		City string `json:"city" valtra:"required"`
+	}
+	type user struct {
+		Age     int      `json:"age" valtra:"required,min=18,max=130"`
+		Address *address `json:"address"`
+		Skipped string   `valtra:"-"`
+	}
+
+	var got []string
+	for _, r := range valtra.DescribeStruct(&user{}) {
+		got = append(got, fmt.Sprintf("%s: %s", r.Field, r))
+	}
+
+	if want := "age: required; age: min=18; age: max=130; address.city: required"; strings.Join(got, "; ") != want {
+		t.Errorf("Expected %q, got %q", want, strings.Join(got, "; "))
+	}
+
+	t.Run("non-struct", func(t *testing.T) {
+		if info := valtra.DescribeStruct(42); info != nil {
+			t.Errorf("Expected nil, got %v", info)
+		}
+	})
+
+	t.Run("recursive types", func(t *testing.T) {
+		if info := valtra.DescribeStruct(node{}); len(info) != 0 {
+			t.Errorf("Expected no rules, got %v", info)
+		}
+	})
+}
@@ -0,0 +1,192 @@
+package valtra
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/mail"
+	"strings"
+	"unicode"
+)
+
+// StrictEmail returns an option that makes Email parse
+// addresses by the "addr-spec" rules of RFC 5322, using
+// net/mail, instead of its permissive pattern.
+//
+// Addresses must be bare, without a display name or comments.
+// Strict parsing allows some addresses the pattern rejects,
+// such as "user@localhost".
+//
+// Example:
+//
+//	valtra.Val(input.Email, "email").Validate(valtra.Email(valtra.StrictEmail()))
+func StrictEmail() Option {
+	return func(o *options) {
+		o.strictEmail = true
+	}
+}
+
+// DisposableDomains returns an option that makes Email reject
+// addresses whose domain isDisposable reports as belonging to
+// a disposable (throwaway) email provider, with the code
+// "disposable_email".
+//
+// The domain is lowercased, and available as the "domain"
+// parameter. Blocklists are not bundled, as they change too
+// often; isDisposable typically looks the domain up in one the
+// application maintains.
+//
+// Example:
+//
+//	valtra.Val(input.Email, "email").Validate(valtra.Email(valtra.DisposableDomains(blocklist.Contains)))
+func DisposableDomains(isDisposable func(domain string) bool) Option {
+	return func(o *options) {
+		o.disposable = isDisposable
+	}
+}
+
+// isEmail reports whether the address is valid, by RFC 5322
+// if strict is set, or else by emailRegex.
+func isEmail(address string, strict bool) bool {
+	if !strict {
+		if valid, ok := isASCIIEmail(address); ok {
+			return valid
+		}
+
+		return emailRegex.MatchString(address)
+	}
+
+	// ParseAddress also accepts display names, angle brackets,
+	// comments and surrounding whitespace, so rule those out.
+	parsed, err := mail.ParseAddress(address)
+	return err == nil && parsed.Name == "" && address == strings.TrimSpace(address) &&
+		!strings.HasPrefix(address, "<") && !strings.HasSuffix(address, ")")
+}
+
+// emailDomain returns the lowercased domain of the address.
+func emailDomain(address string) string {
+	return strings.ToLower(address[strings.LastIndexByte(address, '@')+1:])
+}
+
+// MXResolver looks up the DNS records EmailMX needs. It is
+// implemented by *net.Resolver.
+type MXResolver interface {
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+	LookupHost(ctx context.Context, host string) ([]string, error)
+}
+
+// EmailMX returns a context-aware validation that ensures the
+// value is a valid email address, as with Email, whose domain
+// accepts email.
+//
+// The domain accepts email if it has MX records, or, failing
+// that, an address record (the implicit MX of RFC 5321). A
+// "null MX" record, which declares that the domain accepts no
+// email, fails. A nil resolver uses net.DefaultResolver.
+//
+// DNS errors other than the domain not being found are
+// returned as they are, so wrapping the validation in Remote
+// bounds lookups with a timeout and treats such errors as
+// transient.
+//
+// Options such as WithMessage can be provided as the last
+// parameters, and are also passed to Email.
+//
+// Example:
+//
+//	valtra.Val(input.Email, "email").ValidateCtx(ctx, valtra.Remote(valtra.EmailMX(nil),
+//	    valtra.WithTimeout(2*time.Second),
+//	    valtra.WithFallback(nil),
+//	))
+func EmailMX(resolver MXResolver, opts ...Option) func(context.Context, Value[string]) error {
+	if resolver == nil {
+		resolver = net.DefaultResolver
+	}
+	email := Email(opts...)
+
+	return func(ctx context.Context, v Value[string]) error {
+		if err := check(v, email); err != nil {
+			return err
+		}
+
+		domain := emailDomain(v.value)
+		records, err := resolver.LookupMX(ctx, domain)
+		switch {
+		case isNotFound(err):
+			_, err = resolver.LookupHost(ctx, domain)
+			if isNotFound(err) {
+				return newError(v, "email_mx", map[string]any{"domain": domain}, opts)
+			}
+			return err
+		case err != nil:
+			return err
+		}
+
+		for _, mx := range records {
+			if mx.Host != "." && mx.Host != "" {
+				return nil
+			}
+		}
+
+		return newError(v, "email_mx", map[string]any{"domain": domain}, opts)
+	}
+}
+
+// isNotFound reports whether err is a DNS error for a name
+// that does not exist or has no records of the requested type.
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
+
+// isASCIIEmail reports whether the address matches emailRegex,
+// checking its bytes directly rather than running the regex.
+// It only handles ASCII addresses, reporting false for ok
+// otherwise, as most addresses are ASCII and the regex's
+// Unicode classes make it comparatively slow.
+func isASCIIEmail(address string) (valid, ok bool) {
+	for i := 0; i < len(address); i++ {
+		if address[i] > unicode.MaxASCII {
+			return false, false
+		}
+	}
+
+	// Domains cannot contain "@", so the last one ends the local
+	// part, which may contain more if it is quoted.
+	at := strings.LastIndexByte(address, '@')
+	if at < 0 {
+		return false, true
+	}
+	local, domain := address[:at], address[at+1:]
+
+	if strings.HasPrefix(local, `"`) {
+		// A quoted local part can contain anything, as long as
+		// quotes other than the enclosing ones are escaped.
+		if len(local) < 2 || !strings.HasSuffix(local, `"`) {
+			return false, true
+		}
+		inner := local[1 : len(local)-1]
+		for i := 0; i < len(inner); i++ {
+			if inner[i] == '"' && (i == 0 || inner[i-1] != '\\') {
+				return false, true
+			}
+		}
+	} else if local == "" || strings.IndexFunc(local, func(r rune) bool { return !isEmailByte(byte(r), "._%+-") }) >= 0 {
+		return false, true
+	}
+
+	// The domain ends with a dot and two or more letters.
+	dot := strings.LastIndexByte(domain, '.')
+	if dot <= 0 || len(domain)-dot-1 < 2 || !isAlpha(domain[dot+1:]) ||
+		strings.IndexFunc(domain[:dot], func(r rune) bool { return !isEmailByte(byte(r), ".-") }) >= 0 {
+		return false, true
+	}
+
+	return true, true
+}
+
+// isEmailByte reports whether c is an ASCII letter, digit, or
+// one of the given punctuation characters.
+func isEmailByte(c byte, punctuation string) bool {
+	return isASCIILetter(c) || '0' <= c && c <= '9' || strings.IndexByte(punctuation, c) >= 0
+}
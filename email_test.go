@@ -0,0 +1,182 @@
+package valtra_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// fakeResolver answers DNS lookups from maps, reporting
+// names missing from them as not found.
+type fakeResolver struct {
+	mx    map[string][]*net.MX
+	hosts map[string][]string
+	err   error
+}
+
+func (r fakeResolver) LookupMX(ctx context.Context, name string) ([]*net.MX, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if records, ok := r.mx[name]; ok {
+		return records, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (r fakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if addrs, ok := r.hosts[host]; ok {
+		return addrs, nil
+	}
+
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
+
+func TestEmailASCII(t *testing.T) {
+	// ASCII addresses are checked without the regex, which must
+	// give the same results
+	pattern := regexp.MustCompile(`^(?:"(?:[^"]|\\")*"|[\p{L}\p{N}\p{M}._%+-]+)@[\p{L}\p{N}\p{M}.-]+\.[\p{L}\p{M}]{2,}$`)
+	rule := valtra.Email()
+
+	var inputs []string
+	var grow func(prefix string)
+	grow = func(prefix string) {
+		inputs = append(inputs, prefix, prefix+"@a.aa", "a@"+prefix+".aa")
+		if len(prefix) < 5 {
+			for _, c := range `a1"@\.- ` {
+				grow(prefix + string(c))
+			}
+		}
+	}
+	grow("")
+
+	for _, input := range inputs {
+		if valid := rule(valtra.Val(input)) == nil; valid != pattern.MatchString(input) {
+			t.Errorf("Expected valid=%v for %q", !valid, input)
+		}
+	}
+}
+
+func TestStrictEmail(t *testing.T) {
+	tests := []struct {
+		input string
+		valid bool
+	}{
+		{"user@example.com", true},
+		{"user@localhost", true},
+		{`"john doe"@example.com`, true},
+		{"Bob <bob@example.com>", false},
+		{"bob@example.com (Bob)", false},
+		{"<bob@example.com>", false},
+		{" bob@example.com", false},
+		{"bob@@example.com", false},
+		{"bob", false},
+	}
+
+	for _, tt := range tests {
+		v := valtra.Val(tt.input).Validate(valtra.Email(valtra.StrictEmail()))
+		if v.IsValid() != tt.valid {
+			t.Errorf("Expected valid=%v for %q, got errors: %v", tt.valid, tt.input, v.Errors())
+		}
+	}
+}
+
+func TestDisposableDomains(t *testing.T) {
+	isDisposable := func(domain string) bool { return domain == "mailinator.com" }
+
+	t.Run("disposable domains fail", func(t *testing.T) {
+		v := valtra.Val("bob@Mailinator.com", "email").Validate(valtra.Email(valtra.DisposableDomains(isDisposable)))
+
+		var ve *valtra.ValidationError
+		if len(v.Errors()) != 1 || !errors.As(v.Errors()[0], &ve) ||
+			ve.Code != "disposable_email" || ve.Params["domain"] != "mailinator.com" {
+			t.Fatalf("Expected a disposable_email error, got %v", v.Errors())
+		}
+		if want := "email must not use a disposable email provider"; ve.Error() != want {
+			t.Errorf("Expected %q, got %q", want, ve.Error())
+		}
+	})
+
+	t.Run("other domains pass", func(t *testing.T) {
+		v := valtra.Val("bob@example.com").Validate(valtra.Email(valtra.DisposableDomains(isDisposable)))
+		if !v.IsValid() {
+			t.Errorf("Expected no errors, got %v", v.Errors())
+		}
+	})
+
+	t.Run("invalid addresses fail as usual", func(t *testing.T) {
+		v := valtra.Val("not an email").Validate(valtra.Email(valtra.DisposableDomains(isDisposable)))
+
+		var ve *valtra.ValidationError
+		if len(v.Errors()) != 1 || !errors.As(v.Errors()[0], &ve) || ve.Code != "email" {
+			t.Errorf("Expected an email error, got %v", v.Errors())
+		}
+	})
+}
+
+func TestEmailMX(t *testing.T) {
+	resolver := fakeResolver{
+		mx: map[string][]*net.MX{
+			"example.com": {{Host: "mx.example.com.", Pref: 10}},
+			"null.test":   {{Host: ".", Pref: 0}},
+		},
+		hosts: map[string][]string{"implicit.test": {"192.0.2.1"}},
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		code  string
+	}{
+		{"domain with mx records", "bob@Example.com", ""},
+		{"domain with only an address record", "bob@implicit.test", ""},
+		{"unknown domain", "bob@unknown.test", "email_mx"},
+		{"null mx", "bob@null.test", "email_mx"},
+		{"invalid address", "bob", "email"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.input).ValidateCtx(context.Background(), valtra.EmailMX(resolver))
+
+			var ve *valtra.ValidationError
+			switch {
+			case tt.code == "" && !v.IsValid():
+				t.Errorf("Expected no errors, got %v", v.Errors())
+			case tt.code != "" && (len(v.Errors()) != 1 || !errors.As(v.Errors()[0], &ve) || ve.Code != tt.code):
+				t.Errorf("Expected a %s error, got %v", tt.code, v.Errors())
+			}
+		})
+	}
+
+	t.Run("dns errors are returned for Remote to handle", func(t *testing.T) {
+		failing := fakeResolver{err: &net.DNSError{Err: "server misbehaving", IsTemporary: true}}
+		rule := valtra.Remote(valtra.EmailMX(failing), valtra.WithFallback(nil))
+
+		if v := valtra.Val("bob@example.com").ValidateCtx(context.Background(), valtra.EmailMX(failing)); v.IsValid() {
+			t.Error("Expected the DNS error to fail the validation")
+		}
+		if v := valtra.Val("bob@example.com").ValidateCtx(context.Background(), rule); !v.IsValid() {
+			t.Errorf("Expected the fallback to pass, got %v", v.Errors())
+		}
+	})
+}
+
+func BenchmarkEmail(b *testing.B) {
+	rule := valtra.Email()
+	for _, address := range []string{"bobby.donev+news@example.com", "böbby@exämple.com"} {
+		b.Run(address, func(b *testing.B) {
+			v := valtra.Val(address)
+			for b.Loop() {
+				if rule(v) != nil {
+					b.Fatal("Expected a valid address")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package valtra
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// JSON returns a validation that ensures the value is a
+// syntactically valid JSON document.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Config).Validate(valtra.JSON())
+func JSON(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !json.Valid([]byte(v.value)) {
+			return newError(v, "json", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// JSONLimits caps the size of a JSON document checked by
+// LimitedJSON. A zero limit means no limit.
+type JSONLimits struct {
+	// MaxDepth caps how deeply arrays and objects nest, e.g.
+	// [[1]] has a depth of 2.
+	MaxDepth int
+	// MaxKeys caps the number of object keys in the whole
+	// document.
+	MaxKeys int
+	// MaxStringBytes caps the total length of the document's
+	// strings, keys included, in bytes.
+	MaxStringBytes int
+}
+
+// LimitedJSON returns a validation that ensures the value is
+// a syntactically valid JSON document within the given
+// limits, for untrusted payloads that are decoded into
+// dynamic values such as map[string]any.
+//
+// The document is read token by token, and reading stops at
+// the first limit exceeded, so hostile documents are rejected
+// without being decoded. Exceeded limits are reported with the
+// codes "json_depth", "json_keys" and "json_string_bytes",
+// with the limit as the "max" parameter, and invalid JSON with
+// the code "json".
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(string(body), "body").Validate(valtra.LimitedJSON(valtra.JSONLimits{MaxDepth: 32, MaxKeys: 1000, MaxStringBytes: 1 << 20}))
+func LimitedJSON(limits JSONLimits, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		code, max := checkJSONLimits(v.value, limits)
+		switch code {
+		case "":
+			return nil
+		case "json":
+			return newError(v, code, nil, opts)
+		default:
+			return newError(v, code, map[string]any{"max": max}, opts)
+		}
+	}
+}
+
+// checkJSONLimits reads the JSON document, returning the code
+// of the first of the limits it exceeds along with the limit,
+// "json" if it is not valid JSON, or "" if it is valid and
+// within the limits.
+func checkJSONLimits(doc string, limits JSONLimits) (string, int) {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+
+	// Each open array is 'a', and each open object is 'k' or
+	// 'v', depending on whether a key or a value comes next
+	var open []byte
+	var keys, stringBytes int
+	var done bool
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF && done {
+			return "", 0
+		}
+		if err != nil || done {
+			return "json", 0
+		}
+
+		s, isString := tok.(string)
+		if isString {
+			stringBytes += len(s)
+			if limits.MaxStringBytes > 0 && stringBytes > limits.MaxStringBytes {
+				return "json_string_bytes", limits.MaxStringBytes
+			}
+		}
+		isKey := isString && len(open) > 0 && open[len(open)-1] == 'k'
+
+		switch {
+		case isKey:
+			keys++
+			if limits.MaxKeys > 0 && keys > limits.MaxKeys {
+				return "json_keys", limits.MaxKeys
+			}
+			open[len(open)-1] = 'v'
+			continue
+		case tok == json.Delim('['), tok == json.Delim('{'):
+			if tok == json.Delim('[') {
+				open = append(open, 'a')
+			} else {
+				open = append(open, 'k')
+			}
+			if limits.MaxDepth > 0 && len(open) > limits.MaxDepth {
+				return "json_depth", limits.MaxDepth
+			}
+			continue
+		case tok == json.Delim(']'), tok == json.Delim('}'):
+			open = open[:len(open)-1]
+		}
+
+		// A value is complete
+		switch {
+		case len(open) == 0:
+			done = true
+		case open[len(open)-1] == 'v':
+			open[len(open)-1] = 'k'
+		}
+	}
+}
+
+// Base64 returns a validation that ensures the value is
+// padded, standard base64 (RFC 4648) that decodes to at most
+// maxBytes bytes. A maxBytes of 0 disables the limit.
+//
+// The value is decoded as a stream and the decoded bytes are
+// discarded, so large inputs are checked in constant memory.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Attachment).Validate(valtra.Base64(5 << 20))
+func Base64(maxBytes int64, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		return checkBase64(v, base64.StdEncoding, "base64", maxBytes, opts)
+	}
+}
+
+// Base64URL returns a validation that ensures the value is
+// URL-safe base64 (RFC 4648), with or without padding, that
+// decodes to at most maxBytes bytes. A maxBytes of 0 disables
+// the limit.
+//
+// As with Base64, the value is decoded in constant memory.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Token).Validate(valtra.Base64URL(4096))
+func Base64URL(maxBytes int64, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		enc := base64.RawURLEncoding
+		if strings.HasSuffix(v.value, "=") {
+			enc = base64.URLEncoding
+		}
+
+		return checkBase64(v, enc, "base64url", maxBytes, opts)
+	}
+}
+
+// checkBase64 decodes the value with the given encoding, and
+// returns an error with the given code if it is malformed,
+// or a "too_large" error if it decodes to more than maxBytes.
+func checkBase64(v Value[string], enc *base64.Encoding, code string, maxBytes int64, opts []Option) error {
+	// Reject inputs containing newlines, which the decoder
+	// would otherwise silently skip
+	if strings.ContainsAny(v.value, "\r\n") {
+		return newError(v, code, nil, opts)
+	}
+
+	r := io.Reader(base64.NewDecoder(enc, strings.NewReader(v.value)))
+	if maxBytes > 0 {
+		r = io.LimitReader(r, maxBytes+1)
+	}
+
+	n, err := io.Copy(io.Discard, r)
+	if err != nil {
+		return newError(v, code, nil, opts)
+	}
+	if maxBytes > 0 && n > maxBytes {
+		return newError(v, "too_large", map[string]any{"max": maxBytes}, opts)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package valtra_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestJSON(t *testing.T) {
+	t.Run("valid JSON passes", func(t *testing.T) {
+		v := valtra.Val(`{"name": "bobby", "tags": [1, 2]}`).Validate(valtra.JSON())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("invalid JSON fails", func(t *testing.T) {
+		v := valtra.Val(`{"name": "bobby",}`).Validate(valtra.JSON())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid JSON")
+		}
+	})
+}
+
+func TestLimitedJSON(t *testing.T) {
+	limits := valtra.JSONLimits{MaxDepth: 3, MaxKeys: 4, MaxStringBytes: 20}
+
+	tests := []struct {
+		name string
+		doc  string
+		code string
+	}{
+		{"within limits", `{"name": "bobby", "tags": [["a"], {}], "age": 30}`, ""},
+		{"scalar", `"hello"`, ""},
+		{"empty containers", `[{}, [], {"a": {}}]`, ""},
+		{"too deep", `[[[[1]]]]`, "json_depth"},
+		{"too deep in objects", `{"a": {"b": {"c": {}}}}`, "json_depth"},
+		{"too many keys", `[{"a": 1, "b": 2}, {"c": 3, "d": 4, "e": 5}]`, "json_keys"},
+		{"keys in nested objects", `{"a": {"b": {"c": 1}}, "d": [{"e": 1}]}`, "json_keys"},
+		{"too much text", `["0123456789", "0123456789", "x"]`, "json_string_bytes"},
+		{"keys count as text", `{"0123456789": "0123456789", "x": 1}`, "json_string_bytes"},
+		{"invalid syntax", `{"name": "bobby",}`, "json"},
+		{"trailing value", `{} {}`, "json"},
+		{"unclosed", `[1, 2`, "json"},
+		{"empty", ``, "json"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.doc, "body").Validate(valtra.LimitedJSON(limits))
+			if tt.code == "" {
+				if !v.IsValid() {
+					t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+				}
+				return
+			}
+
+			var ve *valtra.ValidationError
+			if !errors.As(v.FirstError(), &ve) || ve.Code != tt.code {
+				t.Errorf("Expected code %q, got %v", tt.code, v.Errors())
+			}
+		})
+	}
+
+	t.Run("zero limits only check syntax", func(t *testing.T) {
+		v := valtra.Val(`[[[[["deep"]]]]]`).Validate(valtra.LimitedJSON(valtra.JSONLimits{}))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("limit in message", func(t *testing.T) {
+		v := valtra.Val(`[[[[1]]]]`, "body").Validate(valtra.LimitedJSON(limits))
+		if got := v.Err().Error(); got != "body must not be nested more than 3 levels deep" {
+			t.Errorf("Unexpected message %q", got)
+		}
+	})
+}
+
+func TestBase64(t *testing.T) {
+	t.Run("valid base64 passes", func(t *testing.T) {
+		v := valtra.Val(base64.StdEncoding.EncodeToString([]byte("hello?"))).Validate(valtra.Base64(0))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("malformed base64 fails", func(t *testing.T) {
+		for _, s := range []string{"aGVsbG8", "aGVs*G8=", "aGVs\nbG8="} {
+			v := valtra.Val(s).Validate(valtra.Base64(0))
+			if v.IsValid() {
+				t.Errorf("Expected validation to fail for %q", s)
+			}
+		}
+	})
+
+	t.Run("decoded size is limited", func(t *testing.T) {
+		encoded := base64.StdEncoding.EncodeToString([]byte(strings.Repeat("a", 11)))
+
+		v := valtra.Val(encoded).Validate(valtra.Base64(11))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass at the limit, got errors: %v", v.Errors())
+		}
+
+		v = valtra.Val(encoded, "attachment").Validate(valtra.Base64(10))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail above the limit")
+		}
+		expected := "attachment cannot be larger than 10 bytes"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestBase64URL(t *testing.T) {
+	data := []byte{0xfb, 0xff, 0xfe, 0x01}
+
+	t.Run("padded and unpadded base64url pass", func(t *testing.T) {
+		for _, s := range []string{base64.URLEncoding.EncodeToString(data), base64.RawURLEncoding.EncodeToString(data)} {
+			v := valtra.Val(s).Validate(valtra.Base64URL(0))
+			if !v.IsValid() {
+				t.Errorf("Expected validation to pass for %q, got errors: %v", s, v.Errors())
+			}
+		}
+	})
+
+	t.Run("standard base64 alphabet fails", func(t *testing.T) {
+		v := valtra.Val(base64.StdEncoding.EncodeToString(data)).Validate(valtra.Base64URL(0))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for standard alphabet")
+		}
+	})
+}
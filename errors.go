@@ -0,0 +1,185 @@
+package valtra
+
+import "strconv"
+
+// Position identifies a location in a source file, such as
+// a configuration file or an uploaded CSV. Line and Column
+// are 1-based, with 0 meaning unknown.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// IsValid reports whether the position has a line.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String formats the position as "file:line:column", leaving
+// out whatever is unknown, e.g. "file:line", "line:column" or
+// "file". It returns "-" if the position is entirely unknown.
+func (p Position) String() string {
+	s := p.File
+	if p.IsValid() {
+		if s != "" {
+			s += ":"
+		}
+		s += strconv.Itoa(p.Line)
+		if p.Column > 0 {
+			s += ":" + strconv.Itoa(p.Column)
+		}
+	}
+	if s == "" {
+		s = "-"
+	}
+
+	return s
+}
+
+// ValidationError describes a single failed validation or
+// transformation.
+//
+// All built-in validations return a *ValidationError, so
+// callers can use errors.As to inspect which value failed,
+// which rule failed, and with which parameters.
+type ValidationError struct {
+	// Field is the name of the value that failed.
+	Field string
+	// Code identifies the rule that failed (e.g. "required").
+	// It is also the key used to look up message templates.
+	Code string
+	// Params holds the rule's parameters (e.g. "min" for Min),
+	// which can be referenced from message templates.
+	Params map[string]any
+	// Message is the rendered, human-readable error message.
+	Message string
+	// Severity describes how serious the failure is.
+	Severity Severity
+	// Position is the location of the value in its source
+	// file, if known (see Value.At).
+	Position Position
+	// Classification is the sensitivity class of the value
+	// (see Value.Classify).
+	Classification Classification
+
+	// rule is the rule's default code, which may differ from
+	// Code when a custom code was provided.
+	rule string
+	// custom is the custom message provided by the caller, if
+	// any, which takes precedence over translated messages.
+	custom string
+	// value is the value that failed, available to message
+	// templates through the {value} placeholder.
+	value any
+	// redacted reports whether the value, and parameters
+	// holding parts of it, are kept out of the message.
+	redacted bool
+	// scratch is the scratch the message is built in while
+	// the error is created, if any.
+	scratch *scratch
+}
+
+// Error returns the rendered error message, prefixed with the
+// error's position if it has one, e.g.
+// "config.env:3: port must be a whole number".
+func (e *ValidationError) Error() string {
+	if e.Position == (Position{}) {
+		return e.Message
+	}
+
+	return e.Position.String() + ": " + e.Message
+}
+
+// Is reports whether the error matches the target, allowing
+// errors.Is to be used with a *ValidationError as a pattern.
+// The target matches if its Code is set and equal to the
+// error's, and its Field is either empty or equal too.
+//
+// Example:
+//
+//	if errors.Is(err, &valtra.ValidationError{Code: "required"}) {
+//	    // a required value is missing
+//	}
+func (e *ValidationError) Is(target error) bool {
+	t, ok := target.(*ValidationError)
+	if !ok || t.Code == "" {
+		return false
+	}
+
+	return t.Code == e.Code && (t.Field == "" || t.Field == e.Field)
+}
+
+// NewError returns a *ValidationError for the given value,
+// as the built-in validations do, so custom rules, such as
+// those of rule packs (see Use), report codes whose messages
+// are rendered from templates and can be localised.
+//
+// Options such as WithMessage are applied as for the built-in
+// validations.
+//
+// Example:
+//
+//	func Even() func(valtra.Value[int]) error {
+//	    return func(v valtra.Value[int]) error {
+//	        if v.Value()%2 != 0 {
+//	            return valtra.NewError(v, "even", nil)
+//	        }
+//	        return nil
+//	    }
+//	}
+func NewError[T any](v Value[T], code string, params map[string]any, opts ...Option) error {
+	return newError(v, code, params, opts)
+}
+
+// newError creates a *ValidationError for the given value,
+// rule code and parameters, configured by the given options.
+//
+// If a custom message is provided, it is used as the message
+// template. Otherwise the message template for the code is
+// looked up in the active locale (falling back to the default
+// English messages).
+//
+// Templates can reference {name}, {value} and any of the
+// rule's parameters, e.g. {min}.
+func newError[T any](v Value[T], code string, params map[string]any, opts []Option) error {
+	o := applyOptions(opts)
+
+	var e *ValidationError
+	if v.scratch != nil {
+		e = v.scratch.newError()
+		e.scratch = v.scratch
+	} else {
+		e = &ValidationError{}
+	}
+	e.Field = v.name
+	e.Code = code
+	e.Params = params
+	e.Severity = o.severity
+	e.Position = v.pos
+	e.rule = code
+	e.value = v.value
+
+	// Keep classified and redacted values out of messages
+	e.Classification = v.class
+	if redacts(v.name, v.class, o) {
+		e.redacted = true
+		e.value = Redacted
+	}
+
+	// Use custom error code, if provided
+	if o.code != "" {
+		e.Code = o.code
+	}
+
+	// Use custom error message, if provided
+	if o.message != "" {
+		e.custom = o.message
+		e.Message = interpolate(e.custom, e)
+	} else {
+		e.Message = render(e, activeLocale())
+	}
+
+	e.scratch = nil
+	return e
+}
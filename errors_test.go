@@ -0,0 +1,130 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestValidationErrorIs(t *testing.T) {
+	err := valtra.Val("", "email").Validate(valtra.Required[string]()).Err()
+
+	tests := []struct {
+		name   string
+		target error
+		match  bool
+	}{
+		{"same code", &valtra.ValidationError{Code: "required"}, true},
+		{"same code and field", &valtra.ValidationError{Code: "required", Field: "email"}, true},
+		{"other field", &valtra.ValidationError{Code: "required", Field: "name"}, false},
+		{"other code", &valtra.ValidationError{Code: "email"}, false},
+		{"empty code", &valtra.ValidationError{Field: "email"}, false},
+		{"other error", errors.New("required"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errors.Is(err, tt.target) != tt.match {
+				t.Errorf("Expected errors.Is to be %v", tt.match)
+			}
+		})
+	}
+}
+
+func TestPosition(t *testing.T) {
+	tests := []struct {
+		pos  valtra.Position
+		want string
+	}{
+		{valtra.Position{File: "users.csv", Line: 7, Column: 3}, "users.csv:7:3"},
+		{valtra.Position{File: "users.csv", Line: 7}, "users.csv:7"},
+		{valtra.Position{Line: 7, Column: 3}, "7:3"},
+		{valtra.Position{File: "users.csv"}, "users.csv"},
+		{valtra.Position{}, "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.pos.String(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPositionedErrors(t *testing.T) {
+	pos := valtra.Position{File: "users.csv", Line: 7, Column: 3}
+
+	t.Run("errors carry the value's position", func(t *testing.T) {
+		v := valtra.Val("nope", "email").At(pos).Validate(valtra.Email())
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Err(), &ve) || ve.Position != pos {
+			t.Fatalf("Expected error at %v, got %v", pos, v.Err())
+		}
+		if ve.Message != "email must be in correct email format" || ve.Error() != "users.csv:7:3: "+ve.Message {
+			t.Errorf("Unexpected message %q (error %q)", ve.Message, ve.Error())
+		}
+	})
+
+	t.Run("positions are kept across conversions", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("x", "age").At(pos), valtra.ParseInt())
+		if v.Err() == nil || v.Err().Error() != "users.csv:7:3: age must be a whole number" {
+			t.Errorf("Unexpected error: %v", v.Err())
+		}
+	})
+
+	t.Run("collectors render positions", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("", "name").At(valtra.Position{File: "users.csv", Line: 2}).Validate(valtra.Required[string]()).Collect(c)
+		valtra.Val(-1, "age").Validate(valtra.NonNegative[int]()).Collect(c)
+
+		if c.Err().Error() != "users.csv:2: name is required\nage cannot be negative" {
+			t.Errorf("Unexpected errors: %q", c.Err().Error())
+		}
+	})
+}
+
+func TestErrUnwrap(t *testing.T) {
+	type address struct{ City string }
+
+	c := valtra.NewCollector()
+	valtra.Val("", "email").Validate(valtra.Required[string]()).Collect(c)
+	valtra.Val(3, "age").Validate(valtra.Between(10, 1)).Collect(c)
+	valtra.Val(address{}, "address").Validate(
+		valtra.Field("city", func(a address) string { return a.City }, valtra.Required[string]()),
+	).Collect(c.WithPrefix("billing."))
+
+	err := c.Err()
+	if _, ok := err.(interface{ Unwrap() []error }); !ok {
+		t.Fatalf("Expected Err to implement Unwrap() []error, got %T", err)
+	}
+
+	t.Run("finds sentinel errors", func(t *testing.T) {
+		if !errors.Is(err, valtra.ErrInvalidRule) {
+			t.Errorf("Expected errors.Is to find ErrInvalidRule in %v", err)
+		}
+	})
+
+	t.Run("finds nested field errors", func(t *testing.T) {
+		if !errors.Is(err, &valtra.ValidationError{Code: "required", Field: "city"}) {
+			t.Errorf("Expected errors.Is to find the nested city error in %v", err)
+		}
+	})
+
+	t.Run("finds typed errors", func(t *testing.T) {
+		var ve *valtra.ValidationError
+		if !errors.As(err, &ve) || ve.Field != "email" {
+			t.Errorf("Expected errors.As to find the email error first, got %v", ve)
+		}
+	})
+
+	t.Run("value errors unwrap", func(t *testing.T) {
+		err := valtra.Val("", "email").Validate(valtra.Required[string](), valtra.Email()).Err()
+		errs := err.(interface{ Unwrap() []error }).Unwrap()
+		if len(errs) != 2 || !errors.Is(err, &valtra.ValidationError{Code: "email"}) {
+			t.Errorf("Expected both errors to be unwrapped, got %v", errs)
+		}
+	})
+}
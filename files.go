@@ -0,0 +1,578 @@
+package valtra
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// pdfPageRegex matches page objects (but not the /Pages tree
+// nodes) inside a PDF body.
+var pdfPageRegex = regexp.MustCompile(`/Type\s*/Page(?:[^a-zA-Z]|$)`)
+
+// PDFDocument returns a validation that ensures the value
+// is a structurally sound PDF document.
+//
+// The document must start with a PDF header and end with an
+// end-of-file marker. A maxPages or maxSize (in bytes) of 0
+// disables the respective limit. When noJavaScript is true,
+// documents embedding JavaScript actions are rejected.
+//
+// Page counting and JavaScript detection inspect uncompressed
+// objects only, so they are a basic safety check rather than a
+// full PDF parse: names inside compressed streams, such as
+// object streams, are not seen. Escapes in names, as in
+// /J#61vaScript, are decoded.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(upload).Validate(valtra.PDFDocument(20, 10<<20, true))
+func PDFDocument(maxPages int, maxSize int, noJavaScript bool, opts ...Option) func(Value[[]byte]) error {
+	return func(v Value[[]byte]) error {
+		switch {
+		case maxSize > 0 && len(v.value) > maxSize:
+			return newError(v, "max_size", map[string]any{"max": maxSize}, opts)
+		case !bytes.HasPrefix(v.value, []byte("%PDF-")) || !hasPDFTrailer(v.value):
+			return newError(v, "pdf", nil, opts)
+		case maxPages > 0 && len(pdfPageRegex.FindAllIndex(v.value, maxPages+1)) > maxPages:
+			return newError(v, "max_pages", map[string]any{"max": maxPages}, opts)
+		case noJavaScript && hasPDFJavaScript(v.value):
+			return newError(v, "no_javascript", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// hasPDFJavaScript reports whether the document has a
+// /JavaScript or /JS name, used to embed JavaScript actions,
+// once the #xx escapes in its names are decoded.
+func hasPDFJavaScript(doc []byte) bool {
+	for i := 0; i < len(doc); i++ {
+		if doc[i] != '/' {
+			continue
+		}
+
+		end := i + 1
+		for end < len(doc) && !isPDFDelimiter(doc[end]) {
+			end++
+		}
+		name := doc[i+1 : end]
+		if bytes.IndexByte(name, '#') >= 0 {
+			name = decodePDFName(name)
+		}
+		if string(name) == "JavaScript" || string(name) == "JS" {
+			return true
+		}
+		i = end - 1
+	}
+
+	return false
+}
+
+// isPDFDelimiter reports whether c ends a PDF name, as
+// whitespace or a delimiter character.
+func isPDFDelimiter(c byte) bool {
+	return strings.IndexByte("\x00\t\n\f\r ()<>[]{}/%", c) >= 0
+}
+
+// decodePDFName decodes the #xx escapes of a PDF name, leaving
+// malformed ones as they are.
+func decodePDFName(name []byte) []byte {
+	decoded := make([]byte, 0, len(name))
+	for i := 0; i < len(name); i++ {
+		if name[i] == '#' && i+2 < len(name) {
+			if b, err := hex.DecodeString(string(name[i+1 : i+3])); err == nil {
+				decoded = append(decoded, b[0])
+				i += 2
+				continue
+			}
+		}
+		decoded = append(decoded, name[i])
+	}
+
+	return decoded
+}
+
+// hasPDFTrailer reports whether the end-of-file marker is
+// present within the last kilobyte of the document.
+func hasPDFTrailer(doc []byte) bool {
+	tail := doc
+	if len(tail) > 1024 {
+		tail = tail[len(tail)-1024:]
+	}
+
+	return bytes.Contains(tail, []byte("%%EOF"))
+}
+
+// magicSignatures lists file signatures that
+// http.DetectContentType does not recognise, most notably
+// executables that would otherwise be reported as
+// "application/octet-stream".
+var magicSignatures = []struct {
+	prefix      []byte
+	contentType string
+}{
+	{[]byte("MZ"), "application/x-msdownload"},
+	{[]byte("\x7fELF"), "application/x-executable"},
+	{[]byte("\xfe\xed\xfa\xce"), "application/x-mach-binary"},
+	{[]byte("\xfe\xed\xfa\xcf"), "application/x-mach-binary"},
+	{[]byte("\xce\xfa\xed\xfe"), "application/x-mach-binary"},
+	{[]byte("\xcf\xfa\xed\xfe"), "application/x-mach-binary"},
+	{[]byte("#!"), "text/x-shellscript"},
+}
+
+// DetectContentType sniffs the content type of the given
+// data from its magic bytes.
+//
+// It recognises everything http.DetectContentType does,
+// plus common executable formats and shell scripts. Media
+// type parameters (such as charset) are stripped.
+func DetectContentType(data []byte) string {
+	for _, sig := range magicSignatures {
+		if bytes.HasPrefix(data, sig.prefix) {
+			return sig.contentType
+		}
+	}
+
+	detected := http.DetectContentType(data)
+	if mediaType, _, err := mime.ParseMediaType(detected); err == nil {
+		return mediaType
+	}
+
+	return detected
+}
+
+// DeclaredContentType is the WithMeta key of the content type
+// a value is declared to have, such as the Content-Type header
+// of an upload, which DetectedContentType checks against the
+// content.
+//
+// Example:
+//
+//	valtra.Val(body, "avatar").
+//	    WithMeta(valtra.DeclaredContentType{}, r.Header.Get("Content-Type")).
+//	    Validate(valtra.DetectedContentTypes("image/png", "image/jpeg"))
+type DeclaredContentType struct{}
+
+// DetectedContentType returns a validation that ensures the
+// content type sniffed from the value's magic bytes is one of
+// the allowed media types, and that it agrees with the type
+// the value is declared to have (see DeclaredContentType), if
+// any.
+//
+// It guards upload endpoints against files whose declared
+// type (or file extension) disagrees with their content, such
+// as executables disguised as images. See DetectContentType
+// for the detection rules. Sniffing cannot tell most text
+// formats apart, so content sniffed as text/plain, another
+// text type or application/octet-stream agrees with any
+// declared type. An empty allowed list is an invalid rule (see
+// ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(upload).Validate(valtra.DetectedContentType([]string{"image/png", "image/jpeg"}))
+func DetectedContentType(allowed []string, opts ...Option) func(Value[[]byte]) error {
+	if len(allowed) == 0 {
+		return invalidRule[[]byte]("DetectedContentType allows no content types")
+	}
+
+	return func(v Value[[]byte]) error {
+		detected := DetectContentType(v.value)
+		if !slices.ContainsFunc(allowed, func(a string) bool { return strings.EqualFold(a, detected) }) {
+			return newError(v, "content_type", map[string]any{"detected": detected, "allowed": allowed}, opts)
+		}
+
+		declared, _ := v.Meta(DeclaredContentType{}).(string)
+		if mediaType, _, err := mime.ParseMediaType(declared); err == nil {
+			declared = mediaType
+		}
+		generic := detected == "application/octet-stream" || strings.HasPrefix(detected, "text/")
+		if declared != "" && !generic && !strings.EqualFold(declared, detected) {
+			return newError(v, "content_mismatch", map[string]any{"detected": detected, "declared": declared}, opts)
+		}
+
+		return nil
+	}
+}
+
+// DetectedContentTypes is DetectedContentType with the allowed
+// media types given as arguments.
+//
+// Example:
+//
+//	valtra.Val(upload).Validate(valtra.DetectedContentTypes("image/png", "image/jpeg"))
+func DetectedContentTypes(allowed ...string) func(Value[[]byte]) error {
+	return DetectedContentType(allowed)
+}
+
+// exifBlock describes an EXIF metadata block embedded in an
+// image: the byte range of the enclosing JPEG segment or PNG
+// chunk, and the TIFF-encoded EXIF payload itself.
+type exifBlock struct {
+	start, end int
+	tiff       []byte
+}
+
+// exifBlocks locates the EXIF blocks in a JPEG or PNG image.
+// Data in other formats has no blocks. It reports false if
+// the image structure is malformed.
+func exifBlocks(data []byte) ([]exifBlock, bool) {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xff\xd8")):
+		return jpegEXIFBlocks(data)
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return pngEXIFBlocks(data)
+	}
+
+	return nil, true
+}
+
+// jpegEXIFBlocks walks the marker segments of a JPEG image
+// up to the start of the compressed image data, collecting
+// the APP1 segments that hold EXIF data.
+func jpegEXIFBlocks(data []byte) ([]exifBlock, bool) {
+	var blocks []exifBlock
+	for i := 2; i < len(data); {
+		if data[i] != 0xff || i+1 >= len(data) {
+			return nil, false
+		}
+
+		marker := data[i+1]
+		switch {
+		case marker == 0xff: // fill byte
+			i++
+			continue
+		case marker == 0xd9: // end of image
+			return blocks, true
+		case marker == 0x01 || marker >= 0xd0 && marker <= 0xd7: // standalone markers
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, false
+		}
+		end := i + 2 + int(binary.BigEndian.Uint16(data[i+2:]))
+		if end < i+4 || end > len(data) {
+			return nil, false
+		}
+
+		payload := data[i+4 : end]
+		if marker == 0xe1 && bytes.HasPrefix(payload, []byte("Exif\x00\x00")) {
+			blocks = append(blocks, exifBlock{start: i, end: end, tiff: payload[6:]})
+		}
+		if marker == 0xda { // start of scan, compressed data follows
+			return blocks, true
+		}
+
+		i = end
+	}
+
+	return blocks, true
+}
+
+// pngEXIFBlocks walks the chunks of a PNG image, collecting
+// the eXIf chunks.
+func pngEXIFBlocks(data []byte) ([]exifBlock, bool) {
+	var blocks []exifBlock
+	for i := 8; i < len(data); {
+		if i+12 > len(data) {
+			return nil, false
+		}
+		n := binary.BigEndian.Uint32(data[i:])
+		if uint64(n) > uint64(len(data)-i-12) {
+			return nil, false
+		}
+
+		end := i + 12 + int(n)
+		switch string(data[i+4 : i+8]) {
+		case "eXIf":
+			blocks = append(blocks, exifBlock{start: i, end: end, tiff: data[i+8 : end-4]})
+		case "IEND":
+			return blocks, true
+		}
+
+		i = end
+	}
+
+	return blocks, true
+}
+
+// hasGPSInfo reports whether the first image file directory
+// of the given TIFF-encoded EXIF payload links to a GPS
+// directory (tag 0x8825).
+func hasGPSInfo(tiff []byte) bool {
+	if len(tiff) < 8 {
+		return false
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:4]) {
+	case "II*\x00":
+		order = binary.LittleEndian
+	case "MM\x00*":
+		order = binary.BigEndian
+	default:
+		return false
+	}
+
+	ifd := uint64(order.Uint32(tiff[4:]))
+	if ifd+2 > uint64(len(tiff)) {
+		return false
+	}
+
+	entries := tiff[ifd+2:]
+	for range order.Uint16(tiff[ifd:]) {
+		if len(entries) < 12 {
+			break
+		}
+		if order.Uint16(entries) == 0x8825 {
+			return true
+		}
+		entries = entries[12:]
+	}
+
+	return false
+}
+
+// StripEXIF returns a transformation that removes all EXIF
+// metadata (camera details, timestamps, location, etc.) from
+// JPEG and PNG images. The image data itself is untouched,
+// and values in other formats are returned unchanged.
+//
+// Options such as WithMessage can be provided as the
+// parameters, and apply to the error returned for malformed
+// images.
+//
+// Example:
+//
+//	valtra.Val(upload).Transform(valtra.StripEXIF())
+func StripEXIF(opts ...Option) func(Value[[]byte]) ([]byte, error) {
+	return func(v Value[[]byte]) ([]byte, error) {
+		blocks, ok := exifBlocks(v.value)
+		if !ok {
+			return v.value, newError(v, "image", nil, opts)
+		}
+		if len(blocks) == 0 {
+			return v.value, nil
+		}
+
+		stripped := make([]byte, 0, len(v.value))
+		last := 0
+		for _, b := range blocks {
+			stripped = append(stripped, v.value[last:b.start]...)
+			last = b.end
+		}
+
+		return append(stripped, v.value[last:]...), nil
+	}
+}
+
+// NoGPSMetadata returns a validation that ensures a JPEG or
+// PNG image does not embed GPS location data in its EXIF
+// metadata. Values in other formats pass.
+//
+// It suits upload endpoints bound by privacy policies; to
+// accept such images but drop their metadata instead, use
+// StripEXIF.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(upload).Validate(valtra.NoGPSMetadata())
+func NoGPSMetadata(opts ...Option) func(Value[[]byte]) error {
+	return func(v Value[[]byte]) error {
+		blocks, ok := exifBlocks(v.value)
+		if !ok {
+			return newError(v, "image", nil, opts)
+		}
+
+		for _, b := range blocks {
+			if hasGPSInfo(b.tiff) {
+				return newError(v, "gps_metadata", nil, opts)
+			}
+		}
+
+		return nil
+	}
+}
+
+// SafeSVG returns a validation that ensures the value is an
+// SVG image free of active content, since SVG uploads (e.g.
+// avatars) are a frequent cross-site scripting vector.
+//
+// Images are rejected if they contain script or foreignObject
+// elements, event handler attributes such as onload, entity
+// declarations, processing instructions other than the XML
+// declaration (such as xml-stylesheet), animations that set
+// links or javascript: URLs, or references to external
+// resources, whether through href attributes or url() and
+// @import in any attribute or style sheet. References to
+// fragments within the image ("#id") and embedded raster data
+// URIs are allowed.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(avatar).Validate(valtra.SafeSVG())
+func SafeSVG(opts ...Option) func(Value[[]byte]) error {
+	return func(v Value[[]byte]) error {
+		if reason := checkSVG(v.value); reason != "" {
+			return newError(v, "svg", map[string]any{"reason": reason}, opts)
+		}
+
+		return nil
+	}
+}
+
+// checkSVG tokenizes the given SVG document, returning the
+// reason it is unsafe, or an empty string if it is safe.
+func checkSVG(doc []byte) string {
+	dec := xml.NewDecoder(bytes.NewReader(doc))
+
+	depth, inStyle := 0, false
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "malformed XML"
+		}
+
+		switch tok := tok.(type) {
+		case xml.ProcInst:
+			if tok.Target != "xml" {
+				return "processing instruction " + tok.Target
+			}
+		case xml.Directive:
+			if bytes.Contains(tok, []byte("ENTITY")) {
+				return "entity declaration"
+			}
+		case xml.StartElement:
+			name := strings.ToLower(tok.Name.Local)
+			switch {
+			case depth == 0 && name != "svg":
+				return "not an SVG document"
+			case name == "script":
+				return "script element"
+			case name == "foreignobject":
+				return "foreignObject element"
+			case svgAnimations[name] && animatesSVGRef(tok.Attr):
+				return "animated reference"
+			}
+
+			for _, attr := range tok.Attr {
+				switch attrName := strings.ToLower(attr.Name.Local); {
+				case strings.HasPrefix(attrName, "on"):
+					return "event handler attribute " + attr.Name.Local
+				case attrName == "href" && !isLocalSVGRef(attr.Value), hasExternalCSS(attr.Value):
+					return "external reference"
+				}
+			}
+
+			depth++
+			inStyle = name == "style"
+		case xml.EndElement:
+			depth--
+			inStyle = false
+		case xml.CharData:
+			if inStyle && hasExternalCSS(string(tok)) {
+				return "external reference"
+			}
+		}
+	}
+
+	if depth != 0 || len(bytes.TrimSpace(doc)) == 0 {
+		return "malformed XML"
+	}
+
+	return ""
+}
+
+// svgAnimations lists the (lowercased) SVG animation
+// elements, which can change attributes such as href after the
+// image has been checked.
+var svgAnimations = map[string]bool{
+	"set":              true,
+	"animate":          true,
+	"animatetransform": true,
+	"animatemotion":    true,
+}
+
+// animatesSVGRef reports whether the attributes of an
+// animation element animate an href, or set a javascript: URL.
+func animatesSVGRef(attrs []xml.Attr) bool {
+	for _, attr := range attrs {
+		switch strings.ToLower(attr.Name.Local) {
+		case "attributename":
+			if name := strings.ToLower(strings.TrimSpace(attr.Value)); name == "href" || name == "xlink:href" {
+				return true
+			}
+		case "to", "values", "from", "by":
+			value := strings.Map(func(r rune) rune {
+				if unicode.IsSpace(r) || unicode.IsControl(r) {
+					return -1
+				}
+				return unicode.ToLower(r)
+			}, attr.Value)
+			if strings.Contains(value, "javascript:") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isLocalSVGRef reports whether an href points within the
+// image itself, either to a fragment or to embedded raster
+// image data.
+func isLocalSVGRef(ref string) bool {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+
+	return strings.HasPrefix(ref, "#") ||
+		strings.HasPrefix(ref, "data:image/") && !strings.HasPrefix(ref, "data:image/svg")
+}
+
+// hasExternalCSS reports whether the given CSS imports other
+// stylesheets or references non-fragment URLs.
+func hasExternalCSS(css string) bool {
+	css = strings.ToLower(css)
+	if strings.Contains(css, "@import") {
+		return true
+	}
+
+	for {
+		i := strings.Index(css, "url(")
+		if i < 0 {
+			return false
+		}
+
+		css = css[i+len("url("):]
+		if !isLocalSVGRef(strings.Trim(strings.TrimSpace(css), `"'`)) {
+			return true
+		}
+	}
+}
@@ -0,0 +1,302 @@
+package valtra_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func pdf(pages int, extra string) []byte {
+	var b strings.Builder
+	b.WriteString("%PDF-1.7\n1 0 obj << /Type /Pages /Count 1 >> endobj\n")
+	for range pages {
+		b.WriteString("2 0 obj << /Type /Page /Parent 1 0 R >> endobj\n")
+	}
+	b.WriteString(extra)
+	b.WriteString("trailer << /Root 1 0 R >>\n%%EOF\n")
+	return []byte(b.String())
+}
+
+func TestPDFDocument(t *testing.T) {
+	t.Run("valid document passes", func(t *testing.T) {
+		v := valtra.Val(pdf(2, "")).Validate(valtra.PDFDocument(5, 0, true))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("non-PDF content fails", func(t *testing.T) {
+		v := valtra.Val([]byte("MZ\x90\x00not a pdf")).Validate(valtra.PDFDocument(0, 0, false))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-PDF content")
+		}
+	})
+
+	t.Run("too many pages fails", func(t *testing.T) {
+		v := valtra.Val(pdf(3, "")).Validate(valtra.PDFDocument(2, 0, false))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for too many pages")
+		}
+	})
+
+	t.Run("too large fails", func(t *testing.T) {
+		v := valtra.Val(pdf(1, "")).Validate(valtra.PDFDocument(0, 10, false))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for oversized document")
+		}
+	})
+
+	t.Run("embedded JavaScript fails", func(t *testing.T) {
+		doc := pdf(1, "3 0 obj << /S /JavaScript /JS (app.alert(1)) >> endobj\n")
+		v := valtra.Val(doc).Validate(valtra.PDFDocument(0, 0, true))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for embedded JavaScript")
+		}
+	})
+
+	t.Run("JavaScript names are matched once decoded", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			extra string
+			valid bool
+		}{
+			{"escaped JavaScript", "3 0 obj << /S /J#61vaScript /JS (app.alert(1)) >> endobj\n", false},
+			{"escaped JS", "3 0 obj << /S /Launch /#4A#53 (app.alert(1)) >> endobj\n", false},
+			{"JS before a delimiter", "3 0 obj <</OpenAction<</JS(app.alert(1))>>>> endobj\n", false},
+			{"longer names", "3 0 obj << /JSON /JavaScripts /J#5 >> endobj\n", true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				v := valtra.Val(pdf(1, tt.extra)).Validate(valtra.PDFDocument(0, 0, true))
+				if v.IsValid() != tt.valid {
+					t.Errorf("Expected valid to be %v, got errors: %v", tt.valid, v.Errors())
+				}
+			})
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Upload a PDF"
+		v := valtra.Val([]byte("hello")).Validate(valtra.PDFDocument(0, 0, false, valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestDetectedContentType(t *testing.T) {
+	png := []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+	t.Run("allowed content type passes", func(t *testing.T) {
+		v := valtra.Val(png).Validate(valtra.DetectedContentTypes("image/png"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("disguised executable fails", func(t *testing.T) {
+		exe := []byte("MZ\x90\x00\x03\x00\x00\x00")
+		v := valtra.Val(exe).Validate(valtra.DetectedContentType([]string{"image/png", "application/octet-stream"}))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for executable content")
+		}
+	})
+
+	t.Run("media type parameters are ignored", func(t *testing.T) {
+		v := valtra.Val([]byte("hello world")).Validate(valtra.DetectedContentTypes("text/plain"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("content must match the declared type", func(t *testing.T) {
+		jpeg := []byte("\xff\xd8\xff\xe0\x00\x10JFIF\x00")
+		rule := valtra.DetectedContentType([]string{"image/png", "image/jpeg", "text/plain"})
+
+		tests := []struct {
+			name     string
+			data     []byte
+			declared string
+			valid    bool
+		}{
+			{"matching type", png, "image/png", true},
+			{"matching type with parameters", png, "Image/PNG; q=1", true},
+			{"other allowed type", jpeg, "image/png", false},
+			{"text sniffed as plain text", []byte("a,b\n1,2\n"), "text/csv", true},
+			{"no declared type", jpeg, "", true},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				v := valtra.Val(tt.data).WithMeta(valtra.DeclaredContentType{}, tt.declared).Validate(rule)
+				if v.IsValid() != tt.valid {
+					t.Errorf("Expected valid to be %v, got errors: %v", tt.valid, v.Errors())
+				}
+			})
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Only PNG images are allowed"
+		v := valtra.Val([]byte("hello")).Validate(valtra.DetectedContentType([]string{"image/png"}, valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestDetectContentType(t *testing.T) {
+	t.Run("detects ELF executables", func(t *testing.T) {
+		if ct := valtra.DetectContentType([]byte("\x7fELF\x02\x01")); ct != "application/x-executable" {
+			t.Errorf("Expected application/x-executable, got %q", ct)
+		}
+	})
+
+	t.Run("falls back to standard detection", func(t *testing.T) {
+		if ct := valtra.DetectContentType([]byte("%PDF-1.7")); ct != "application/pdf" {
+			t.Errorf("Expected application/pdf, got %q", ct)
+		}
+	})
+}
+
+// tiff builds a little-endian EXIF payload whose first image
+// file directory holds a single entry with the given tag.
+func tiff(tag uint16) []byte {
+	b := []byte("II*\x00\x08\x00\x00\x00\x01\x00")
+	b = binary.LittleEndian.AppendUint16(b, tag)
+	return append(b, make([]byte, 14)...)
+}
+
+func jpeg(exif []byte) []byte {
+	b := []byte("\xff\xd8")
+	if exif != nil {
+		payload := append([]byte("Exif\x00\x00"), exif...)
+		b = append(b, 0xff, 0xe1)
+		b = binary.BigEndian.AppendUint16(b, uint16(len(payload)+2))
+		b = append(b, payload...)
+	}
+	return append(b, "\xff\xda\x00\x02scan-data\xff\xd9"...)
+}
+
+func png(exif []byte) []byte {
+	chunk := func(typ string, data []byte) []byte {
+		c := binary.BigEndian.AppendUint32(nil, uint32(len(data)))
+		c = append(c, typ...)
+		c = append(c, data...)
+		return append(c, 0, 0, 0, 0)
+	}
+
+	b := append([]byte("\x89PNG\r\n\x1a\n"), chunk("IHDR", make([]byte, 13))...)
+	if exif != nil {
+		b = append(b, chunk("eXIf", exif)...)
+	}
+	return append(b, chunk("IEND", nil)...)
+}
+
+func TestStripEXIF(t *testing.T) {
+	t.Run("EXIF removed from JPEG", func(t *testing.T) {
+		v := valtra.Val(jpeg(tiff(0x8825))).Transform(valtra.StripEXIF())
+		if !v.IsValid() || !bytes.Equal(v.Value(), jpeg(nil)) {
+			t.Errorf("Expected EXIF to be stripped, got %q (errors: %v)", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("EXIF removed from PNG", func(t *testing.T) {
+		v := valtra.Val(png(tiff(0x8825))).Transform(valtra.StripEXIF())
+		if !v.IsValid() || !bytes.Equal(v.Value(), png(nil)) {
+			t.Errorf("Expected EXIF to be stripped, got %q (errors: %v)", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("other formats unchanged", func(t *testing.T) {
+		v := valtra.Val([]byte("GIF89a")).Transform(valtra.StripEXIF())
+		if !v.IsValid() || string(v.Value()) != "GIF89a" {
+			t.Errorf("Expected value to be unchanged, got %q", v.Value())
+		}
+	})
+
+	t.Run("malformed JPEG fails", func(t *testing.T) {
+		v := valtra.Val([]byte("\xff\xd8\xff\xe1\xff\xff")).Transform(valtra.StripEXIF())
+		if v.IsValid() {
+			t.Error("Expected transformation to fail for truncated segment")
+		}
+	})
+}
+
+func TestNoGPSMetadata(t *testing.T) {
+	tests := []struct {
+		name  string
+		image []byte
+		valid bool
+	}{
+		{"JPEG without EXIF", jpeg(nil), true},
+		{"JPEG without GPS", jpeg(tiff(0x010f)), true},
+		{"JPEG with GPS", jpeg(tiff(0x8825)), false},
+		{"PNG without GPS", png(tiff(0x010f)), true},
+		{"PNG with GPS", png(tiff(0x8825)), false},
+		{"malformed PNG", []byte("\x89PNG\r\n\x1a\n\x00\x00\x10\x00eXIf"), false},
+		{"other format", []byte("GIF89a"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.image).Validate(valtra.NoGPSMetadata())
+			if v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got errors: %v", tt.valid, v.Errors())
+			}
+		})
+	}
+}
+
+func TestSafeSVG(t *testing.T) {
+	const ns = `xmlns="http://www.w3.org/2000/svg" xmlns:xlink="http://www.w3.org/1999/xlink"`
+
+	tests := []struct {
+		name  string
+		svg   string
+		valid bool
+	}{
+		{"plain shapes", `<svg ` + ns + `><circle cx="5" cy="5" r="4" fill="red"/></svg>`, true},
+		{"fragment references", `<svg ` + ns + `><defs><g id="a"/></defs><use xlink:href="#a" style="fill: url(#grad)"/></svg>`, true},
+		{"embedded raster image", `<svg ` + ns + `><image href="data:image/png;base64,iVBORw0KGgo="/></svg>`, true},
+		{"script element", `<svg ` + ns + `><script>alert(1)</script></svg>`, false},
+		{"foreignObject element", `<svg ` + ns + `><foreignObject><div/></foreignObject></svg>`, false},
+		{"event handler", `<svg ` + ns + ` onload="alert(1)"></svg>`, false},
+		{"external href", `<svg ` + ns + `><use xlink:href="https://evil.example/a.svg#x"/></svg>`, false},
+		{"javascript href", `<svg ` + ns + `><a href="javascript:alert(1)"><text>x</text></a></svg>`, false},
+		{"embedded SVG data", `<svg ` + ns + `><image href="data:image/svg+xml;base64,PHN2Zz4="/></svg>`, false},
+		{"external CSS url", `<svg ` + ns + `><rect style="fill: url('https://evil.example/x')"/></svg>`, false},
+		{"external presentation url", `<svg ` + ns + `><rect fill="url(https://evil.example/x)"/></svg>`, false},
+		{"local presentation url", `<svg ` + ns + `><rect fill="url(#grad)"/></svg>`, true},
+		{"animated href", `<svg ` + ns + `><a><set attributeName="href" to="javascript:alert(1)"/><text>x</text></a></svg>`, false},
+		{"animated xlink:href", `<svg ` + ns + `><a><animate attributeName="xlink:href" values="#a;https://evil.example"/></a></svg>`, false},
+		{"animated javascript URL", `<svg ` + ns + `><a><animateTransform attributeName="transform" values="java script:alert(1)"/></a></svg>`, false},
+		{"plain animation", `<svg ` + ns + `><rect><animate attributeName="x" from="0" to="10" dur="1s"/></rect></svg>`, true},
+		{"CSS import", `<svg ` + ns + `><style>@import "https://evil.example/x.css";</style></svg>`, false},
+		{"XML declaration", `<?xml version="1.0" encoding="UTF-8"?><svg ` + ns + `/>`, true},
+		{"style sheet instruction", `<?xml-stylesheet href="http://evil.example/x.css"?><svg ` + ns + `/>`, false},
+		{"entity declaration", `<!DOCTYPE svg [<!ENTITY x SYSTEM "file:///etc/passwd">]><svg ` + ns + `>&x;</svg>`, false},
+		{"not an SVG", `<html><body/></html>`, false},
+		{"malformed", `<svg ` + ns + `><circle>`, false},
+		{"empty", ``, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val([]byte(tt.svg)).Validate(valtra.SafeSVG())
+			if v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got errors: %v", tt.valid, v.Errors())
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package valtra
+
+import "strings"
+
+// AutoFix returns an option that lets a rule repair the value
+// instead of failing, for the rules that support it:
+//
+//   - Email trims whitespace and lowercases the domain
+//   - URL and URLWithSchemes trim whitespace and add a missing
+//     "https://" (or the first allowed scheme, if https is not
+//     allowed)
+//   - Hostname trims whitespace, lowercases the hostname and
+//     removes a trailing dot
+//
+// A repair is only made if the repaired value passes the rule.
+// It replaces the value, and is recorded as a warning with the
+// code "fixed" (see Value.Warnings), holding the repaired value
+// in its "fixed" parameter.
+//
+// Repairs are made by Value.Validate and Schema.Validate. Where
+// the rule is run some other way, e.g. through Field, it fails
+// as it would without the option. Called directly, the rule
+// returns a non-nil error when it repairs a value, even a
+// valid one, to carry the repair to Value.Validate; pass its
+// result through SplitWarnings to tell whether the value
+// itself is valid.
+//
+// Example:
+//
+//	v := valtra.Val(" Bobby@Example.COM", "email").Validate(valtra.Email(valtra.AutoFix()))
+//	v.Value()    // "Bobby@example.com"
+//	v.Warnings() // [email was corrected]
+func AutoFix() Option {
+	return func(o *options) {
+		o.autoFix = true
+	}
+}
+
+// fixError is returned by a rule that repaired the value.
+//
+// It carries the repaired value and the warning, along with
+// the rule's result for the original value (nil if it was
+// valid, but could be normalised), so that it acts as that
+// result wherever the repair cannot be applied.
+type fixError[T any] struct {
+	value   T
+	warning error
+	err     error
+}
+
+// Error returns the message of the rule's original failure,
+// or of the warning if the original value was valid.
+func (e *fixError[T]) Error() string {
+	if e.err == nil {
+		return e.warning.Error()
+	}
+
+	return e.err.Error()
+}
+
+// Unwrap returns the rule's original failure.
+func (e *fixError[T]) Unwrap() error {
+	return e.err
+}
+
+// original returns the rule's result for the original value,
+// for SplitWarnings.
+func (e *fixError[T]) original() error {
+	return e.err
+}
+
+// withAutoFix returns the rule, wrapped so that it repairs
+// values with the repair function if AutoFix is among the
+// options.
+func withAutoFix[T comparable](rule func(Value[T]) error, repair func(T) T, opts []Option) func(Value[T]) error {
+	if !applyOptions(opts).autoFix {
+		return rule
+	}
+
+	return func(v Value[T]) error {
+		err := rule(v)
+
+		fixed := v
+		fixed.value = repair(v.value)
+		if fixed.value == v.value || rule(fixed) != nil {
+			return err
+		}
+
+		warning := newError(v, "fixed", map[string]any{"fixed": fixed.value}, []Option{WithSeverity(SeverityWarning)})
+		return &fixError[T]{value: fixed.value, warning: warning, err: err}
+	}
+}
+
+// repairEmail trims the email address and lowercases its
+// domain. The local part is left alone, as it may be case
+// sensitive.
+func repairEmail(email string) string {
+	email = strings.TrimSpace(email)
+
+	at := strings.LastIndexByte(email, '@')
+	if at < 0 {
+		return email
+	}
+
+	return email[:at+1] + strings.ToLower(email[at+1:])
+}
+
+// repairURL returns a function that trims the URL and adds the
+// given scheme if it has none.
+func repairURL(scheme string) func(string) string {
+	return func(rawURL string) string {
+		rawURL = strings.TrimSpace(rawURL)
+		if rawURL == "" || strings.Contains(rawURL, "://") {
+			return rawURL
+		}
+
+		return scheme + "://" + strings.TrimPrefix(rawURL, "//")
+	}
+}
+
+// repairHostname trims and lowercases the hostname, and
+// removes a trailing dot.
+func repairHostname(host string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(host)), ".")
+}
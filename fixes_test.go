@@ -0,0 +1,101 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestAutoFix(t *testing.T) {
+	t.Run("fixable values are repaired with a warning", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			rule  func(valtra.Value[string]) error
+			input string
+			want  string
+		}{
+			{"email", valtra.Email(valtra.AutoFix()), " Bobby@Example.COM ", "Bobby@example.com"},
+			{"url", valtra.URL(valtra.AutoFix()), "example.com/webhook", "https://example.com/webhook"},
+			{"url with other scheme", valtra.URLWithSchemes([]string{"wss"}, valtra.AutoFix()), "example.com", "wss://example.com"},
+			{"hostname", valtra.Hostname(valtra.AutoFix()), "API.Example.com.", "api.example.com"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				v := valtra.Val(tt.input, "field").Validate(tt.rule)
+				if !v.IsValid() || v.Value() != tt.want {
+					t.Fatalf("Expected %q, got %q (errors: %v)", tt.want, v.Value(), v.Errors())
+				}
+
+				var ve *valtra.ValidationError
+				if len(v.Warnings()) != 1 || !errors.As(v.Warnings()[0], &ve) ||
+					ve.Code != "fixed" || ve.Severity != valtra.SeverityWarning || ve.Params["fixed"] != tt.want {
+					t.Errorf("Unexpected warnings: %v", v.Warnings())
+				}
+			})
+		}
+	})
+
+	t.Run("valid values are left alone", func(t *testing.T) {
+		v := valtra.Val("bobby@example.com").Validate(valtra.Email(valtra.AutoFix()))
+		if !v.IsValid() || len(v.Warnings()) != 0 {
+			t.Errorf("Expected no errors or warnings, got %v, %v", v.Errors(), v.Warnings())
+		}
+	})
+
+	t.Run("unfixable values fail", func(t *testing.T) {
+		v := valtra.Val("not an email").Validate(valtra.Email(valtra.AutoFix()))
+		if v.IsValid() || len(v.Warnings()) != 0 || v.Value() != "not an email" {
+			t.Errorf("Expected failure without a fix, got %q, %v", v.Value(), v.Warnings())
+		}
+	})
+
+	t.Run("values are not fixed without the option", func(t *testing.T) {
+		v := valtra.Val(" bobby@example.com").Validate(valtra.Email())
+		if v.IsValid() {
+			t.Error("Expected validation to fail without AutoFix")
+		}
+	})
+
+	t.Run("schemas apply fixes", func(t *testing.T) {
+		schema := valtra.NewSchema[string]().Validate(valtra.URL(valtra.AutoFix()), valtra.MaxLengthString(30))
+		got, err := schema.Run("example.com")
+		if err != nil || got != "https://example.com" {
+			t.Errorf("Expected https://example.com, got %q (error: %v)", got, err)
+		}
+	})
+
+	t.Run("fixes cannot apply through Field", func(t *testing.T) {
+		type signup struct{ Email string }
+		v := valtra.Val(signup{Email: "bobby@EXAMPLE.com"}).Validate(
+			valtra.Field("email", func(s signup) string { return s.Email }, valtra.Email(valtra.AutoFix())),
+		)
+		if !v.IsValid() {
+			t.Errorf("Expected the original valid email to pass, got %v", v.Errors())
+		}
+	})
+
+	t.Run("direct calls are split with SplitWarnings", func(t *testing.T) {
+		rule := valtra.Email(valtra.AutoFix())
+
+		tests := []struct {
+			name  string
+			value string
+			valid bool
+		}{
+			{"valid but repairable", "bobby@EXAMPLE.com", true},
+			{"invalid but repairable", " bobby@example.com", false},
+			{"invalid", "bobby", false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				failure, warnings := valtra.SplitWarnings(rule(valtra.Val(tt.value, "email")))
+				if (failure == nil) != tt.valid || len(warnings) != 0 {
+					t.Errorf("Expected valid to be %v without warnings, got %v and %v", tt.valid, failure, warnings)
+				}
+			})
+		}
+	})
+}
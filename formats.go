@@ -0,0 +1,224 @@
+package valtra
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ulidPattern matches a ULID: 26 Crockford base32 characters,
+// whose first character cannot exceed 7.
+const ulidPattern = `^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`
+
+// The byte classes used by fixed-format matchers, as bits of
+// the entries of byteClasses.
+const (
+	classHex uint8 = 1 << iota
+	classCrockford
+	classOctal
+)
+
+// byteClasses maps each byte to the classes it belongs to.
+var byteClasses = func() (classes [256]uint8) {
+	for c := range 256 {
+		switch {
+		case '0' <= c && c <= '7':
+			classes[c] = classHex | classCrockford | classOctal
+		case '8' <= c && c <= '9', 'a' <= c && c <= 'f', 'A' <= c && c <= 'F':
+			classes[c] = classHex | classCrockford
+		}
+		if isASCIILetter(byte(c)) && !strings.ContainsRune("ILOUilou", rune(c)) {
+			classes[c] |= classCrockford
+		}
+	}
+
+	return classes
+}()
+
+// fixedFormat matches strings of a fixed length, such as
+// UUIDs, by checking each byte against the class or literal
+// expected at its position. It is a precomputed form of the
+// regex for the format, which it matches several times
+// faster.
+type fixedFormat struct {
+	// classes holds the classes allowed at each position, or 0
+	// where the literal at the same position is expected.
+	classes  []uint8
+	literals string
+}
+
+// newFixedFormat compiles the layout of a format, in which the
+// bytes that are keys of placeholders stand for their classes
+// and all other bytes for themselves.
+func newFixedFormat(layout string, placeholders map[byte]uint8) fixedFormat {
+	f := fixedFormat{classes: make([]uint8, len(layout)), literals: layout}
+	for i := range len(layout) {
+		f.classes[i] = placeholders[layout[i]]
+	}
+
+	return f
+}
+
+// match reports whether s has the format.
+func (f fixedFormat) match(s string) bool {
+	if len(s) != len(f.classes) {
+		return false
+	}
+
+	for i := range len(s) {
+		if class := f.classes[i]; class != 0 {
+			if byteClasses[s[i]]&class == 0 {
+				return false
+			}
+		} else if s[i] != f.literals[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// The formats matched by UUID, ULID and MACAddress.
+var (
+	uuidFormat = newFixedFormat("xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx", map[byte]uint8{'x': classHex})
+	ulidFormat = newFixedFormat("7"+strings.Repeat("c", 25), map[byte]uint8{'7': classOctal, 'c': classCrockford})
+	macFormats = []fixedFormat{
+		newFixedFormat("xx:xx:xx:xx:xx:xx", map[byte]uint8{'x': classHex}),
+		newFixedFormat("xx-xx-xx-xx-xx-xx", map[byte]uint8{'x': classHex}),
+	}
+)
+
+// isHex reports whether s is a non-empty string of
+// hexadecimal digits.
+func isHex(s string) bool {
+	for i := range len(s) {
+		if byteClasses[s[i]]&classHex == 0 {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// UUID returns a validation that ensures the value is a UUID
+// in its canonical textual form, of any version.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("f47ac10b-58cc-4372-a567-0e02b2c3d479").Validate(valtra.UUID())
+func UUID(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !uuidFormat.match(v.value) {
+			return newError(v, "uuid", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// UUIDVersion returns a validation that ensures the value is
+// an RFC 9562 UUID of the given version (1 to 8). Other
+// versions make it an invalid rule (see ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("f47ac10b-58cc-4372-a567-0e02b2c3d479").Validate(valtra.UUIDVersion(4))
+func UUIDVersion(version int, opts ...Option) func(Value[string]) error {
+	if version < 1 || version > 8 {
+		return invalidRule[string]("UUIDVersion has a version (%d) outside 1 to 8", version)
+	}
+	versionDigit := strconv.FormatInt(int64(version), 16)
+
+	return func(v Value[string]) error {
+		if !uuidFormat.match(v.value) ||
+			v.value[14:15] != versionDigit ||
+			!isUUIDVariant(v.value[19]) {
+			return newError(v, "uuid_version", map[string]any{"version": version}, opts)
+		}
+
+		return nil
+	}
+}
+
+// isUUIDVariant reports whether the given variant digit
+// denotes an RFC 9562 UUID.
+func isUUIDVariant(c byte) bool {
+	switch c {
+	case '8', '9', 'a', 'b', 'A', 'B':
+		return true
+	}
+
+	return false
+}
+
+// ULID returns a validation that ensures the value is a ULID
+// (Universally Unique Lexicographically Sortable Identifier).
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("01ARZ3NDEKTSV4RRFFQ69G5FAV").Validate(valtra.ULID())
+func ULID(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !ulidFormat.match(v.value) {
+			return newError(v, "ulid", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// HexColor returns a validation that ensures the value is a
+// CSS hex colour: "#" followed by 3, 4, 6 or 8 hexadecimal
+// digits, e.g. "#1e90ff" or "#1e90ff80" with an alpha channel.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.BrandColour, "brand_colour").Validate(valtra.HexColor())
+func HexColor(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		switch digits := strings.TrimPrefix(v.value, "#"); {
+		case len(digits) == len(v.value), len(digits) != 3 && len(digits) != 4 && len(digits) != 6 && len(digits) != 8, !isHex(digits):
+			return newError(v, "hex_color", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// Hex returns a validation that ensures the value is a
+// string of hexadecimal digits of the given length.
+//
+// A length of 0 allows hexadecimal strings of any (non-zero)
+// length, and a negative length is an invalid rule (see
+// ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("9f86d081884c7d65").Validate(valtra.Hex(16))
+func Hex(length int, opts ...Option) func(Value[string]) error {
+	if invalid := negativeLength[string]("Hex", length); invalid != nil {
+		return invalid
+	}
+
+	return func(v Value[string]) error {
+		if !isHex(v.value) || (length > 0 && len(v.value) != length) {
+			return newError(v, "hex", map[string]any{"length": length}, opts)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,197 @@
+package valtra_test
+
+import (
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestUUID(t *testing.T) {
+	t.Run("valid UUID passes", func(t *testing.T) {
+		v := valtra.Val("F47AC10B-58CC-4372-A567-0E02B2C3D479").Validate(valtra.UUID())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("malformed UUID fails", func(t *testing.T) {
+		v := valtra.Val("f47ac10b58cc4372a5670e02b2c3d479").Validate(valtra.UUID())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for malformed UUID")
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Invalid ID"
+		v := valtra.Val("nope").Validate(valtra.UUID(valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestUUIDVersion(t *testing.T) {
+	t.Run("matching version passes", func(t *testing.T) {
+		v := valtra.Val("f47ac10b-58cc-4372-a567-0e02b2c3d479").Validate(valtra.UUIDVersion(4))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("different version fails", func(t *testing.T) {
+		v := valtra.Val("f47ac10b-58cc-1372-a567-0e02b2c3d479").Validate(valtra.UUIDVersion(4))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for version 1 UUID")
+		}
+	})
+
+	t.Run("invalid variant fails", func(t *testing.T) {
+		v := valtra.Val("f47ac10b-58cc-4372-c567-0e02b2c3d479").Validate(valtra.UUIDVersion(4))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-RFC variant")
+		}
+	})
+}
+
+func TestULID(t *testing.T) {
+	t.Run("valid ULID passes", func(t *testing.T) {
+		v := valtra.Val("01ARZ3NDEKTSV4RRFFQ69G5FAV").Validate(valtra.ULID())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("ULID with invalid character fails", func(t *testing.T) {
+		v := valtra.Val("01ARZ3NDEKTSV4RRFFQ69G5FAU").Validate(valtra.ULID())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for ULID containing U")
+		}
+	})
+
+	t.Run("overflowing ULID fails", func(t *testing.T) {
+		v := valtra.Val("81ARZ3NDEKTSV4RRFFQ69G5FAV").Validate(valtra.ULID())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for overflowing ULID")
+		}
+	})
+}
+
+func TestHex(t *testing.T) {
+	t.Run("hex of given length passes", func(t *testing.T) {
+		v := valtra.Val("9f86d081884c7d65").Validate(valtra.Hex(16))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("hex of wrong length fails", func(t *testing.T) {
+		v := valtra.Val("9f86").Validate(valtra.Hex(16))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for wrong length")
+		}
+	})
+
+	t.Run("any length when zero", func(t *testing.T) {
+		v := valtra.Val("abc").Validate(valtra.Hex(0))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("non-hex fails", func(t *testing.T) {
+		v := valtra.Val("xyz").Validate(valtra.Hex(0))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-hex string")
+		}
+	})
+}
+
+func TestHexColor(t *testing.T) {
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{"#fff", true},
+		{"#FFF8", true},
+		{"#1e90ff", true},
+		{"#1e90ff80", true},
+		{"1e90ff", false},
+		{"#1e90f", false},
+		{"#1e90fg", false},
+		{"#", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if v := valtra.Val(tt.value).Validate(valtra.HexColor()); v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, v.Errors())
+			}
+		})
+	}
+}
+
+// fixedFormatCases holds, for the validators of fixed formats,
+// a valid example and the regex (or function) they replace.
+var fixedFormatCases = []struct {
+	name    string
+	rule    func(valtra.Value[string]) error
+	example string
+	match   func(string) bool
+}{
+	{"uuid", valtra.UUID(), "f47ac10b-58cc-4372-a567-0e02b2c3d479",
+		regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`).MatchString},
+	{"ulid", valtra.ULID(), "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		regexp.MustCompile(`^[0-7][0-9A-HJKMNP-TV-Za-hjkmnp-tv-z]{25}$`).MatchString},
+	{"hex", valtra.Hex(0), "9f86d081884c7d65",
+		regexp.MustCompile(`^[0-9a-fA-F]+$`).MatchString},
+	{"mac", valtra.MACAddress(), "00:00:5e:00:53:01",
+		func(s string) bool { _, err := net.ParseMAC(s); return err == nil }},
+}
+
+func TestFixedFormats(t *testing.T) {
+	// Replacing each byte of a valid example with each of these
+	// must give the same result as the regex
+	const replacements = "0179aAfFgGiIlLoOuUzZ-:. "
+
+	for _, tc := range fixedFormatCases {
+		t.Run(tc.name, func(t *testing.T) {
+			inputs := []string{"", tc.example, tc.example[1:], tc.example + "0"}
+			for i := range len(tc.example) {
+				for j := range len(replacements) {
+					inputs = append(inputs, tc.example[:i]+replacements[j:j+1]+tc.example[i+1:])
+				}
+			}
+
+			for _, input := range inputs {
+				if valid := tc.rule(valtra.Val(input)) == nil; valid != tc.match(input) {
+					t.Errorf("Expected valid=%v for %q", !valid, input)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkFixedFormats(b *testing.B) {
+	for _, tc := range fixedFormatCases {
+		v := valtra.Val(tc.example)
+		b.Run(tc.name, func(b *testing.B) {
+			for b.Loop() {
+				if tc.rule(v) != nil {
+					b.Fatal("Expected a valid value")
+				}
+			}
+		})
+		b.Run(tc.name+"/replaced", func(b *testing.B) {
+			for b.Loop() {
+				if !tc.match(tc.example) {
+					b.Fatal("Expected a valid value")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package valtra
+
+import (
+	"html"
+	"iter"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SanitizePolicy describes the HTML that SanitizeHTML keeps in
+// user-generated content.
+//
+// Event handler (on*) and style attributes are never kept, and
+// neither are elements whose content is not text, such as
+// script, style and iframe, which are removed along with their
+// content.
+//
+// Example:
+//
+//	policy := valtra.SanitizePolicy{
+//	    Tags: map[string][]string{"b": nil, "i": nil, "a": {"href", "title"}},
+//	}
+type SanitizePolicy struct {
+	// Tags maps the names of the allowed elements to their
+	// allowed attributes, e.g. {"a": {"href"}}. Names are
+	// lowercase.
+	Tags map[string][]string
+	// URLSchemes lists the schemes allowed in URL attributes
+	// such as href and src. Relative URLs are always allowed.
+	// Default is http, https and mailto.
+	URLSchemes []string
+}
+
+// UGCPolicy returns a policy suited to user-generated content
+// such as comments: basic formatting, lists, quotes, code and
+// links.
+func UGCPolicy() SanitizePolicy {
+	return SanitizePolicy{
+		Tags: map[string][]string{
+			"a":          {"href", "title"},
+			"b":          nil,
+			"blockquote": {"cite"},
+			"br":         nil,
+			"code":       nil,
+			"em":         nil,
+			"i":          nil,
+			"li":         nil,
+			"ol":         nil,
+			"p":          nil,
+			"pre":        nil,
+			"strong":     nil,
+			"ul":         nil,
+		},
+	}
+}
+
+// htmlRawText lists the elements whose content is not parsed
+// as HTML, and which are removed along with their content.
+var htmlRawText = []string{"iframe", "noembed", "noframes", "noscript", "script", "style", "template", "textarea", "title", "xmp"}
+
+// htmlVoid lists the elements that have no end tag.
+var htmlVoid = []string{"area", "br", "col", "embed", "hr", "img", "input", "source", "track", "wbr"}
+
+// htmlURLAttrs lists the attributes that hold URLs.
+var htmlURLAttrs = []string{"action", "cite", "formaction", "href", "poster", "src"}
+
+// StripHTML returns a transformation that removes all HTML
+// tags and comments from the value, keeping only its text.
+//
+// The content of elements such as script and style is removed
+// too. Character references such as "&amp;" are left as
+// written, while a "<" or ">" that is not part of a tag is
+// escaped, so the result is safe to embed in HTML.
+//
+// Example:
+//
+//	valtra.Val(input.Bio).Transform(valtra.StripHTML())
+func StripHTML() func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		var b strings.Builder
+		for tok := range htmlTokens(v.value) {
+			if tok.kind == htmlText {
+				b.WriteString(htmlTextEscaper.Replace(tok.text))
+			}
+		}
+
+		return b.String(), nil
+	}
+}
+
+// EscapeHTML returns a transformation that escapes the special
+// HTML characters in the value, e.g. "<" becomes "&lt;", so it
+// can be embedded in HTML as text.
+//
+// Example:
+//
+//	valtra.Val(input.DisplayName).Transform(valtra.EscapeHTML())
+func EscapeHTML() func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		return html.EscapeString(v.value), nil
+	}
+}
+
+// SanitizeHTML returns a transformation that removes the HTML
+// the policy does not allow from the value, keeping the text.
+//
+// Attribute values are re-escaped and quoted, URL attributes
+// with disallowed schemes (such as "javascript:") are removed,
+// and elements left open are closed, so the result cannot
+// break out of its surrounding markup.
+//
+// Example:
+//
+//	valtra.Val(input.Comment).Transform(valtra.SanitizeHTML(valtra.UGCPolicy()))
+func SanitizeHTML(policy SanitizePolicy) func(Value[string]) (string, error) {
+	schemes := policy.URLSchemes
+	if len(schemes) == 0 {
+		schemes = []string{"http", "https", "mailto"}
+	}
+
+	return func(v Value[string]) (string, error) {
+		var b strings.Builder
+		var open []string
+		for tok := range htmlTokens(v.value) {
+			allowedAttrs, allowed := policy.Tags[tok.name]
+
+			switch {
+			case tok.kind == htmlText:
+				b.WriteString(htmlTextEscaper.Replace(tok.text))
+			case !allowed:
+				continue
+			case tok.kind == htmlStartTag:
+				b.WriteString("<" + tok.name)
+				for _, attr := range tok.attrs {
+					if !slices.Contains(allowedAttrs, attr.name) ||
+						strings.HasPrefix(attr.name, "on") || attr.name == "style" ||
+						slices.Contains(htmlURLAttrs, attr.name) && !isAllowedURL(attr.value, schemes) {
+						continue
+					}
+					b.WriteString(" " + attr.name + `="` + html.EscapeString(attr.value) + `"`)
+				}
+				b.WriteString(">")
+
+				if !slices.Contains(htmlVoid, tok.name) {
+					open = append(open, tok.name)
+				}
+			case tok.kind == htmlEndTag:
+				i := len(open) - 1
+				for i >= 0 && open[i] != tok.name {
+					i--
+				}
+				if i < 0 {
+					continue
+				}
+				for len(open) > i {
+					b.WriteString("</" + open[len(open)-1] + ">")
+					open = open[:len(open)-1]
+				}
+			}
+		}
+
+		for _, name := range slices.Backward(open) {
+			b.WriteString("</" + name + ">")
+		}
+
+		return b.String(), nil
+	}
+}
+
+// isAllowedURL reports whether the URL is relative, or uses
+// one of the allowed schemes. Whitespace and control
+// characters, which browsers ignore in schemes, are ignored.
+func isAllowedURL(rawURL string, schemes []string) bool {
+	rawURL = strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return -1
+		}
+
+		return r
+	}, rawURL)
+
+	i := strings.IndexAny(rawURL, ":/?#")
+	if i <= 0 || rawURL[i] != ':' {
+		return true
+	}
+
+	return slices.ContainsFunc(schemes, func(s string) bool { return strings.EqualFold(s, rawURL[:i]) })
+}
+
+// htmlTextEscaper escapes the text kept by StripHTML and
+// SanitizeHTML, so that removing a tag cannot join a "<" to
+// the text after it. It leaves character references alone.
+var htmlTextEscaper = strings.NewReplacer("<", "&lt;", ">", "&gt;")
+
+// htmlTokenKind identifies the kind of an htmlToken.
+type htmlTokenKind int
+
+const (
+	htmlText htmlTokenKind = iota
+	htmlStartTag
+	htmlEndTag
+)
+
+// htmlToken is a piece of HTML: either text, or a start or end
+// tag with a lowercase name.
+type htmlToken struct {
+	kind  htmlTokenKind
+	text  string
+	name  string
+	attrs []htmlAttr
+}
+
+// htmlAttr is an attribute of a start tag, with a lowercase
+// name and an unescaped value.
+type htmlAttr struct {
+	name  string
+	value string
+}
+
+// htmlTokens returns an iterator over the text and tags of the
+// HTML, leaving out comments, doctypes and the content of raw
+// text elements such as script. It is lenient the way browsers
+// are: a "<" that cannot start a tag is text, and an
+// unterminated tag is dropped.
+func htmlTokens(s string) iter.Seq[htmlToken] {
+	return func(yield func(htmlToken) bool) {
+		s := s
+		for s != "" {
+			lt := strings.IndexByte(s, '<')
+			if lt != 0 {
+				if lt < 0 {
+					lt = len(s)
+				}
+				if !yield(htmlToken{kind: htmlText, text: s[:lt]}) {
+					return
+				}
+				s = s[lt:]
+				continue
+			}
+
+			var tok htmlToken
+			var ok bool
+			switch {
+			case strings.HasPrefix(s, "<!--"):
+				s = skipPast(s[4:], "-->")
+				continue
+			case len(s) > 1 && (s[1] == '!' || s[1] == '?'):
+				s = skipPast(s[2:], ">")
+				continue
+			case len(s) > 2 && s[1] == '/' && isASCIILetter(s[2]):
+				tok, s, ok = parseHTMLTag(s[2:], htmlEndTag)
+			case len(s) > 1 && isASCIILetter(s[1]):
+				tok, s, ok = parseHTMLTag(s[1:], htmlStartTag)
+			default:
+				tok, s, ok = htmlToken{kind: htmlText, text: "<"}, s[1:], true
+			}
+			if !ok {
+				return
+			}
+
+			if tok.kind == htmlStartTag && slices.Contains(htmlRawText, tok.name) {
+				s = skipRawText(s, tok.name)
+				continue
+			}
+			if !yield(tok) {
+				return
+			}
+		}
+	}
+}
+
+// parseHTMLTag parses a tag, starting at its name, returning
+// the tag and the rest of the HTML. It reports false if the
+// tag is not terminated.
+func parseHTMLTag(s string, kind htmlTokenKind) (htmlToken, string, bool) {
+	tok := htmlToken{kind: kind}
+
+	end := strings.IndexAny(s, " \t\n\f\r/>")
+	if end < 0 {
+		return tok, "", false
+	}
+	tok.name, s = strings.ToLower(s[:end]), s[end:]
+
+	for {
+		s = strings.TrimLeft(s, " \t\n\f\r/")
+		if s == "" {
+			return tok, "", false
+		}
+		if s[0] == '>' {
+			return tok, s[1:], true
+		}
+
+		end := strings.IndexAny(s[1:], " \t\n\f\r/>=") + 1
+		if end == 0 {
+			return tok, "", false
+		}
+		attr := htmlAttr{name: strings.ToLower(s[:end])}
+		s = strings.TrimLeft(s[end:], " \t\n\f\r")
+
+		if strings.HasPrefix(s, "=") {
+			s = strings.TrimLeft(s[1:], " \t\n\f\r")
+			if s == "" {
+				return tok, "", false
+			}
+
+			var value string
+			if q := s[0]; q == '"' || q == '\'' {
+				end := strings.IndexByte(s[1:], q)
+				if end < 0 {
+					return tok, "", false
+				}
+				value, s = s[1:end+1], s[end+2:]
+			} else {
+				end := strings.IndexAny(s, " \t\n\f\r>")
+				if end < 0 {
+					return tok, "", false
+				}
+				value, s = s[:end], s[end:]
+			}
+			attr.value = html.UnescapeString(value)
+		}
+
+		if kind == htmlStartTag {
+			tok.attrs = append(tok.attrs, attr)
+		}
+	}
+}
+
+// skipRawText skips the content of a raw text element, up to
+// and including its end tag.
+func skipRawText(s, name string) string {
+	// Lowercasing s would change the length of some runes, so
+	// the original bytes are compared case-insensitively.
+	for i := 0; ; {
+		j := strings.Index(s[i:], "</")
+		if j < 0 {
+			return ""
+		}
+		i += j + 2
+		if len(s)-i < len(name) || !strings.EqualFold(s[i:i+len(name)], name) {
+			continue
+		}
+		i += len(name)
+		if i == len(s) || strings.IndexByte(" \t\n\f\r/>", s[i]) >= 0 {
+			return skipPast(s[i:], ">")
+		}
+	}
+}
+
+// skipPast returns the rest of s after the first occurrence of
+// sep, or "" if there is none.
+func skipPast(s, sep string) string {
+	_, after, ok := strings.Cut(s, sep)
+	if !ok {
+		return ""
+	}
+
+	return after
+}
+
+// isASCIILetter reports whether c is an ASCII letter.
+func isASCIILetter(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
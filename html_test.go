@@ -0,0 +1,82 @@
+package valtra_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestStripHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"plain text is unchanged", "Hello &amp; welcome", "Hello &amp; welcome"},
+		{"tags are removed", `<p>Hello <b class="x">world</b></p>`, "Hello world"},
+		{"scripts are removed with their content", "Hi<script>alert('x')</script>!", "Hi!"},
+		{"comments are removed", "a<!-- secret -->b", "ab"},
+		{"stray brackets are escaped", "1 < 2 > 0", "1 &lt; 2 &gt; 0"},
+		{"removed tags cannot form new ones", "<<b>script>alert(1)<</b>/script>", "&lt;script&gt;alert(1)&lt;/script&gt;"},
+		{"unterminated tags are dropped", `Hi <img src="x`, "Hi "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.input).Transform(valtra.StripHTML())
+			if v.Value() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, v.Value())
+			}
+		})
+	}
+}
+
+func TestEscapeHTML(t *testing.T) {
+	v := valtra.Val(`<b>"Bobby" & co</b>`).Transform(valtra.EscapeHTML())
+	if v.Value() != "&lt;b&gt;&#34;Bobby&#34; &amp; co&lt;/b&gt;" {
+		t.Errorf("Unexpected result: %q", v.Value())
+	}
+}
+
+func TestSanitizeHTML(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"allowed markup is kept", `<p>Hi <strong>there</strong></p>`, `<p>Hi <strong>there</strong></p>`},
+		{"disallowed tags keep their text", `<div><span>text</span></div>`, "text"},
+		{"scripts are removed", `<p>a<script>alert(1)</script>b</p>`, "<p>ab</p>"},
+		{"runes changing length when lowercased", `<p>ok</p><script>ȺȺȺȺȺȺȺȺȺȺȺȺ</script>`, "<p>ok</p>"},
+		{"end tags are matched case-insensitively", `<p>a<script>x</SCRIPT >b</p>`, "<p>ab</p>"},
+		{"event handlers are removed", `<b onclick="alert(1)">x</b>`, "<b>x</b>"},
+		{"disallowed attributes are removed", `<a href="/home" target="_blank" title='Home'>home</a>`, `<a href="/home" title="Home">home</a>`},
+		{"javascript URLs are removed", `<a href=" jav&#x09;ascript:alert(1)">x</a>`, "<a>x</a>"},
+		{"allowed schemes are kept", `<a href="mailto:bobby@example.com">mail</a>`, `<a href="mailto:bobby@example.com">mail</a>`},
+		{"attribute values are re-escaped", `<a title="a&quot;b<c">x</a>`, `<a title="a&#34;b&lt;c">x</a>`},
+		{"open elements are closed", `<b><i>bold italic`, "<b><i>bold italic</i></b>"},
+		{"misnested end tags close inner elements", `<b><i>x</b>y</i>`, "<b><i>x</i></b>y"},
+		{"case is normalised", `<STRONG>x</Strong>`, "<strong>x</strong>"},
+		{"void elements are not closed", "line<br/>break", "line<br>break"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.input).Transform(valtra.SanitizeHTML(valtra.UGCPolicy()))
+			if v.Value() != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, v.Value())
+			}
+		})
+	}
+
+	t.Run("custom URL schemes", func(t *testing.T) {
+		policy := valtra.SanitizePolicy{
+			Tags:       map[string][]string{"a": {"href"}},
+			URLSchemes: []string{"https"},
+		}
+		v := valtra.Val(`<a href="http://example.com">x</a><a href="https://example.com">y</a>`).Transform(valtra.SanitizeHTML(policy))
+		if v.Value() != `<a>x</a><a href="https://example.com">y</a>` {
+			t.Errorf("Unexpected result: %q", v.Value())
+		}
+	})
+}
@@ -0,0 +1,132 @@
+// Package httpvaltra integrates valtra with net/http
+// handlers.
+//
+// It decodes JSON request bodies, validates them with a
+// valtra schema, and writes standardized error responses, so
+// handlers do not repeat the same boilerplate. SanitizeBody
+// normalises request bodies for handlers that do not use
+// valtra themselves.
+package httpvaltra
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// MaxBodySize is the maximum size in bytes of the request
+// bodies decoded by DecodeAndValidate.
+var MaxBodySize int64 = 1 << 20
+
+// ErrInvalidBody is returned (wrapped) by DecodeAndValidate
+// when the request body is not valid JSON for the target
+// type.
+var ErrInvalidBody = errors.New("httpvaltra: invalid request body")
+
+// FieldError describes a single failed validation in an
+// error response.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// Response is the body of the error responses written by
+// WriteError.
+type Response struct {
+	Errors []FieldError `json:"errors"`
+}
+
+// DecodeAndValidate decodes the JSON body of the request into
+// dst, and applies the schema to it.
+//
+// The (possibly transformed) value is stored in dst even if
+// validation fails. Bodies larger than MaxBodySize, bodies
+// with trailing data, and bodies that cannot be decoded into
+// T return an error wrapping ErrInvalidBody. Otherwise, the
+// schema's errors are returned joined into a single error.
+//
+// Example:
+//
+//	func createUser(w http.ResponseWriter, r *http.Request) {
+//	    var req CreateUserRequest
+//	    if err := httpvaltra.DecodeAndValidate(r, &req, createUserSchema); err != nil {
+//	        httpvaltra.WriteError(w, err)
+//	        return
+//	    }
+//	    // use req
+//	}
+func DecodeAndValidate[T any](r *http.Request, dst *T, schema valtra.Schema[T]) error {
+	dec := json.NewDecoder(http.MaxBytesReader(nil, r.Body, MaxBodySize))
+	if err := dec.Decode(dst); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidBody, err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: unexpected data after JSON value", ErrInvalidBody)
+	}
+
+	value, err := schema.Run(*dst, "body")
+	*dst = value
+
+	return err
+}
+
+// WriteError writes a JSON error response for an error
+// returned by DecodeAndValidate.
+//
+// Invalid bodies are answered with 400 Bad Request, and
+// bodies larger than MaxBodySize with 413 Request Entity Too
+// Large. Validation
+// errors are answered with 422 Unprocessable Entity, listing
+// every failed validation with its field, code and message.
+// Any other error is answered with 500 Internal Server Error,
+// without exposing its message.
+func WriteError(w http.ResponseWriter, err error) {
+	status, resp := http.StatusUnprocessableEntity, Response{Errors: fieldErrors(err)}
+
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &tooLarge):
+		status = http.StatusRequestEntityTooLarge
+		resp.Errors = []FieldError{{Message: fmt.Sprintf("request body cannot be larger than %d bytes", tooLarge.Limit)}}
+	case errors.Is(err, ErrInvalidBody):
+		status = http.StatusBadRequest
+		resp.Errors = []FieldError{{Message: "request body must be valid JSON"}}
+	case len(resp.Errors) == 0:
+		status = http.StatusInternalServerError
+		resp.Errors = []FieldError{{Message: http.StatusText(status)}}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// fieldErrors flattens the given (possibly joined) error into
+// field errors. It returns nil if any of the errors is not a
+// validation error.
+func fieldErrors(err error) []FieldError {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var fields []FieldError
+		for _, e := range joined.Unwrap() {
+			fe := fieldErrors(e)
+			if fe == nil {
+				return nil
+			}
+			fields = append(fields, fe...)
+		}
+
+		return fields
+	}
+
+	var ve *valtra.ValidationError
+	if !errors.As(err, &ve) {
+		return nil
+	}
+
+	return []FieldError{{Field: ve.Field, Code: ve.Code, Message: ve.Message}}
+}
@@ -0,0 +1,113 @@
+package httpvaltra_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/httpvaltra"
+)
+
+type createUser struct {
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+var createUserSchema = valtra.NewSchema[createUser]().
+	Transform(func(v valtra.Value[createUser]) (createUser, error) {
+		u := v.Value()
+		u.Email = strings.ToLower(u.Email)
+		return u, nil
+	}).
+	Validate(
+		valtra.Field("email", func(u createUser) string { return u.Email }, valtra.Required[string](), valtra.Email()),
+		valtra.Field("age", func(u createUser) int { return u.Age }, valtra.Min(18)),
+	)
+
+func request(body string) *http.Request {
+	return httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+}
+
+func TestDecodeAndValidate(t *testing.T) {
+	t.Run("valid body is decoded and transformed", func(t *testing.T) {
+		var req createUser
+		err := httpvaltra.DecodeAndValidate(request(`{"email":"Bobby@Example.com","age":30}`), &req, createUserSchema)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if req.Email != "bobby@example.com" || req.Age != 30 {
+			t.Errorf("Unexpected value: %+v", req)
+		}
+	})
+
+	t.Run("validation errors are returned", func(t *testing.T) {
+		var req createUser
+		err := httpvaltra.DecodeAndValidate(request(`{"email":"nope","age":16}`), &req, createUserSchema)
+		if !errors.Is(err, &valtra.ValidationError{Code: "min", Field: "age"}) {
+			t.Errorf("Expected age error, got %v", err)
+		}
+	})
+
+	t.Run("invalid bodies", func(t *testing.T) {
+		for _, body := range []string{`{"email":`, `{"age":"old"}`, `{} {}`} {
+			var req createUser
+			err := httpvaltra.DecodeAndValidate(request(body), &req, createUserSchema)
+			if !errors.Is(err, httpvaltra.ErrInvalidBody) {
+				t.Errorf("Expected ErrInvalidBody for %q, got %v", body, err)
+			}
+		}
+	})
+}
+
+func TestWriteError(t *testing.T) {
+	write := func(body string) (*httptest.ResponseRecorder, httpvaltra.Response) {
+		var req createUser
+		w := httptest.NewRecorder()
+		httpvaltra.WriteError(w, httpvaltra.DecodeAndValidate(request(body), &req, createUserSchema))
+
+		var resp httpvaltra.Response
+		if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+			t.Fatalf("Invalid response body: %v", err)
+		}
+		return w, resp
+	}
+
+	t.Run("validation errors answer 422 with field errors", func(t *testing.T) {
+		w, resp := write(`{"email":"nope","age":16}`)
+		if w.Code != http.StatusUnprocessableEntity || w.Header().Get("Content-Type") != "application/json" {
+			t.Errorf("Unexpected status %d or headers %v", w.Code, w.Header())
+		}
+
+		want := []httpvaltra.FieldError{
+			{Field: "email", Code: "email", Message: "email must be in correct email format"},
+			{Field: "age", Code: "min", Message: "age cannot be smaller than 18"},
+		}
+		if len(resp.Errors) != len(want) || resp.Errors[0] != want[0] || resp.Errors[1] != want[1] {
+			t.Errorf("Expected %v, got %v", want, resp.Errors)
+		}
+	})
+
+	t.Run("invalid body answers 400", func(t *testing.T) {
+		if w, _ := write(`not json`); w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", w.Code)
+		}
+	})
+
+	t.Run("oversized body answers 413", func(t *testing.T) {
+		if w, _ := write(`{"email":"` + strings.Repeat("a", int(httpvaltra.MaxBodySize)) + `"}`); w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected 413, got %d", w.Code)
+		}
+	})
+
+	t.Run("other errors answer 500", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		httpvaltra.WriteError(w, errors.New("database down"))
+		if w.Code != http.StatusInternalServerError || strings.Contains(w.Body.String(), "database") {
+			t.Errorf("Unexpected response %d: %s", w.Code, w.Body.String())
+		}
+	})
+}
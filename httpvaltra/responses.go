@@ -0,0 +1,93 @@
+package httpvaltra
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// ValidateResponses returns middleware that checks the JSON
+// bodies of successful (2xx) responses against the schema's
+// validations, passing any failure to report along with the
+// request, so that contract violations are caught before
+// clients see them.
+//
+// It suits development and staging environments: responses
+// are sent unchanged, but their bodies are also buffered (up
+// to MaxBodySize; larger bodies are not checked). Bodies that
+// cannot be decoded into T are reported with an error wrapping
+// ErrInvalidBody.
+//
+// Example:
+//
+//	if env != "production" {
+//	    handler = httpvaltra.ValidateResponses(userResponseSchema, func(r *http.Request, err error) {
+//	        slog.Error("invalid response", "path", r.URL.Path, "error", err)
+//	    })(handler)
+//	}
+func ValidateResponses[T any](schema valtra.Schema[T], report func(*http.Request, error)) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rec := &responseRecorder{ResponseWriter: w}
+			next.ServeHTTP(rec, r)
+
+			if rec.status < 200 || rec.status > 299 || rec.overflow || rec.body.Len() == 0 || !isJSON(w.Header().Get("Content-Type")) {
+				return
+			}
+
+			var value T
+			if err := json.Unmarshal(rec.body.Bytes(), &value); err != nil {
+				report(r, fmt.Errorf("%w: %w", ErrInvalidBody, err))
+				return
+			}
+			if err := schema.CheckOnly(value, "response"); err != nil {
+				report(r, err)
+			}
+		})
+	}
+}
+
+// responseRecorder is an http.ResponseWriter that keeps a copy
+// of the status and body written through it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status   int
+	body     bytes.Buffer
+	overflow bool
+}
+
+// WriteHeader records the status and writes it.
+func (rec *responseRecorder) WriteHeader(status int) {
+	if rec.status == 0 {
+		rec.status = status
+	}
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+// Write copies p (unless the body is too large to keep) and
+// writes it.
+func (rec *responseRecorder) Write(p []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+
+	if !rec.overflow {
+		if int64(rec.body.Len()+len(p)) > MaxBodySize {
+			rec.overflow = true
+			rec.body = bytes.Buffer{}
+		} else {
+			rec.body.Write(p)
+		}
+	}
+
+	return rec.ResponseWriter.Write(p)
+}
+
+// Unwrap returns the wrapped writer, for
+// http.ResponseController.
+func (rec *responseRecorder) Unwrap() http.ResponseWriter {
+	return rec.ResponseWriter
+}
@@ -0,0 +1,65 @@
+package httpvaltra_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/httpvaltra"
+)
+
+func TestValidateResponses(t *testing.T) {
+	serve := func(status int, contentType, body string) (*httptest.ResponseRecorder, error) {
+		var reported error
+		handler := httpvaltra.ValidateResponses(createUserSchema, func(r *http.Request, err error) {
+			reported = err
+		})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		}))
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, request(""))
+		return w, reported
+	}
+
+	t.Run("valid responses are not reported", func(t *testing.T) {
+		if _, err := serve(http.StatusOK, "application/json", `{"email":"bobby@example.com","age":30}`); err != nil {
+			t.Errorf("Unexpected report: %v", err)
+		}
+	})
+
+	t.Run("invalid responses are reported and sent unchanged", func(t *testing.T) {
+		body := `{"email":"bobby@example.com","age":16}`
+		w, err := serve(http.StatusOK, "application/json", body)
+		if !errors.Is(err, &valtra.ValidationError{Code: "min", Field: "age"}) {
+			t.Errorf("Expected age error, got %v", err)
+		}
+		if w.Code != http.StatusOK || w.Body.String() != body {
+			t.Errorf("Unexpected response: %d %s", w.Code, w.Body)
+		}
+	})
+
+	t.Run("undecodable responses are reported", func(t *testing.T) {
+		if _, err := serve(http.StatusCreated, "application/json", `{"age":"old"}`); !errors.Is(err, httpvaltra.ErrInvalidBody) {
+			t.Errorf("Expected ErrInvalidBody, got %v", err)
+		}
+	})
+
+	t.Run("other responses are not checked", func(t *testing.T) {
+		for _, tt := range []struct {
+			status      int
+			contentType string
+		}{
+			{http.StatusBadRequest, "application/json"},
+			{http.StatusOK, "text/html"},
+		} {
+			if _, err := serve(tt.status, tt.contentType, `{"age":16}`); err != nil {
+				t.Errorf("Unexpected report for %d %s: %v", tt.status, tt.contentType, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,185 @@
+package httpvaltra
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// SanitizeBody returns middleware that normalises JSON request
+// bodies with the schema's transformations (see
+// valtra.Schema.Sanitize) before the next handler reads them,
+// so existing handlers benefit without changes.
+//
+// The body is decoded into T, sanitized and encoded again. If
+// it is a JSON object, the fields T declares are replaced by
+// their sanitized values, while the others are kept as they
+// are and in their place, so T need not describe the whole
+// body. The schema's validations are not applied.
+//
+// Requests without a JSON content type, and bodies that cannot
+// be decoded into T, are passed on unchanged, leaving their
+// handling to the next handler. Bodies larger than MaxBodySize
+// and transformation errors are answered with WriteError.
+//
+// Example:
+//
+//	mux.Handle("POST /users", httpvaltra.SanitizeBody(createUserSchema)(legacyCreateUser))
+func SanitizeBody[T any](schema valtra.Schema[T]) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body == nil || r.Body == http.NoBody || !isJSON(r.Header.Get("Content-Type")) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, MaxBodySize))
+			if err != nil {
+				WriteError(w, fmt.Errorf("%w: %w", ErrInvalidBody, err))
+				return
+			}
+
+			r = r.Clone(r.Context())
+			r.GetBody = nil
+
+			var value T
+			if err := json.Unmarshal(body, &value); err != nil {
+				r.Body = io.NopCloser(bytes.NewReader(body))
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			value, err = schema.Sanitize(value, "body")
+			if err != nil {
+				WriteError(w, err)
+				return
+			}
+
+			sanitized, err := json.Marshal(value)
+			if err != nil {
+				WriteError(w, fmt.Errorf("httpvaltra: cannot encode sanitized body: %w", err))
+				return
+			}
+
+			sanitized = mergeFields[T](body, sanitized)
+			r.Body = io.NopCloser(bytes.NewReader(sanitized))
+			r.ContentLength = int64(len(sanitized))
+			r.Header.Set("Content-Length", strconv.Itoa(len(sanitized)))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// jsonField is a field of a JSON object.
+type jsonField struct {
+	key   string
+	value json.RawMessage
+}
+
+// objectFields returns the fields of the JSON object in their
+// order, or false if data is not an object.
+func objectFields(data []byte) ([]jsonField, bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, false
+	}
+
+	var fields []jsonField
+	for dec.More() {
+		tok, err := dec.Token()
+		key, ok := tok.(string)
+		if err != nil || !ok {
+			return nil, false
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, false
+		}
+		fields = append(fields, jsonField{key: key, value: value})
+	}
+
+	return fields, true
+}
+
+// mergeFields returns the original JSON object with the fields
+// of the sanitized one in place of those T declares. Keys are
+// matched case-insensitively, as encoding/json decodes them.
+// The sanitized body is returned as it is if either is not an
+// object.
+func mergeFields[T any](original, sanitized []byte) []byte {
+	fields, ok := objectFields(original)
+	if !ok {
+		return sanitized
+	}
+	replacements, ok := objectFields(sanitized)
+	if !ok {
+		return sanitized
+	}
+
+	var buf bytes.Buffer
+	write := func(f jsonField) {
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(f.key)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(f.value)
+	}
+
+	buf.WriteByte('{')
+	used := make([]bool, len(replacements))
+	for _, f := range fields {
+		i := slices.IndexFunc(replacements, func(r jsonField) bool { return strings.EqualFold(r.key, f.key) })
+		switch {
+		case i >= 0:
+			if !used[i] {
+				write(jsonField{key: f.key, value: replacements[i].value})
+				used[i] = true
+			}
+		case !declares[T](f):
+			write(f)
+		}
+	}
+	for i, r := range replacements {
+		if !used[i] {
+			write(r)
+		}
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes()
+}
+
+// declares reports whether T decodes the field, i.e. whether
+// it is one of T's fields rather than an unknown one. Fields
+// T declares but omits when encoding, e.g. with omitempty, are
+// reported too, so they are not kept unsanitized.
+func declares[T any](f jsonField) bool {
+	key, _ := json.Marshal(f.key)
+	dec := json.NewDecoder(bytes.NewReader(fmt.Appendf(nil, "{%s:%s}", key, f.value)))
+	dec.DisallowUnknownFields()
+
+	var probe T
+	return dec.Decode(&probe) == nil
+}
+
+// isJSON reports whether the content type is JSON, i.e.
+// application/json or a type with a +json suffix.
+func isJSON(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	return mediaType == "application/json" || strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json")
+}
@@ -0,0 +1,76 @@
+package httpvaltra_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/httpvaltra"
+)
+
+func TestSanitizeBody(t *testing.T) {
+	var seen string
+	echo := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		seen = string(body)
+	})
+	handler := httpvaltra.SanitizeBody(createUserSchema)(echo)
+
+	serve := func(contentType, body string) *httptest.ResponseRecorder {
+		seen = ""
+		r := request(body)
+		r.Header.Set("Content-Type", contentType)
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	t.Run("JSON bodies are sanitized", func(t *testing.T) {
+		serve("application/json; charset=utf-8", `{"email":"Bobby@Example.com","age":16}`)
+		if want := `{"email":"bobby@example.com","age":16}`; seen != want {
+			t.Errorf("Expected %s, got %s", want, seen)
+		}
+	})
+
+	t.Run("fields T does not declare are kept", func(t *testing.T) {
+		serve("application/json", `{"referrer":"Newsletter","Email":"Bobby@Example.com","tags":["a"]}`)
+		if want := `{"referrer":"Newsletter","Email":"bobby@example.com","tags":["a"],"age":0}`; seen != want {
+			t.Errorf("Expected %s, got %s", want, seen)
+		}
+	})
+
+	t.Run("other bodies are passed on unchanged", func(t *testing.T) {
+		for _, tt := range []struct{ contentType, body string }{
+			{"text/plain", `{"email":"Bobby@Example.com"}`},
+			{"application/json", `{"email":`},
+		} {
+			if serve(tt.contentType, tt.body); seen != tt.body {
+				t.Errorf("Expected %s, got %s", tt.body, seen)
+			}
+		}
+	})
+
+	t.Run("transformation errors are answered", func(t *testing.T) {
+		failing := valtra.NewSchema[createUser]().Transform(func(v valtra.Value[createUser]) (createUser, error) {
+			return v.Value(), errors.New("cannot normalise")
+		})
+
+		w := httptest.NewRecorder()
+		r := request(`{}`)
+		r.Header.Set("Content-Type", "application/json")
+		httpvaltra.SanitizeBody(failing)(echo).ServeHTTP(w, r)
+		if w.Code != http.StatusInternalServerError {
+			t.Errorf("Expected status 500, got %d", w.Code)
+		}
+	})
+
+	t.Run("large bodies are rejected", func(t *testing.T) {
+		if w := serve("application/json", `{"email":"`+strings.Repeat("a", int(httpvaltra.MaxBodySize))+`"}`); w.Code != http.StatusRequestEntityTooLarge {
+			t.Errorf("Expected status 413, got %d", w.Code)
+		}
+	})
+}
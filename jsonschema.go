@@ -0,0 +1,228 @@
+package valtra
+
+import (
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// jsonSchemaDialect is the JSON Schema dialect of the
+// documents produced by JSONSchema.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// jsonSchemaStringRules maps the parameterless struct tag
+// rules for strings to their JSON Schema keywords.
+var jsonSchemaStringRules = map[string]map[string]any{
+	"email":    {"format": "email"},
+	"url":      {"format": "uri"},
+	"uri":      {"format": "uri"},
+	"uuid":     {"format": "uuid"},
+	"hostname": {"format": "hostname"},
+	"ipv4":     {"format": "ipv4"},
+	"ipv6":     {"format": "ipv6"},
+	"ulid":     {"pattern": ulidPattern},
+	"numeric":  {"pattern": "^[0-9]+$"},
+	"ascii":    {"pattern": `^[\x00-\x7f]*$`},
+	"base64":   {"contentEncoding": "base64"},
+	"json":     {"contentMediaType": "application/json"},
+}
+
+// JSONSchema returns a JSON Schema (draft 2020-12) document
+// describing the values accepted by the schema, ready to be
+// marshalled with encoding/json. Without its "$schema"
+// keyword, the document is also a valid OpenAPI 3.1 component
+// schema.
+//
+// The document mirrors T's structure as encoded by
+// encoding/json, with the constraints declared by T's `valtra`
+// struct tags (see ValidateStruct): required fields, min and
+// max bounds, oneof enums, string patterns and formats. Rules
+// added with Validate or Transform are functions, so they
+// cannot be reflected in the document. Rules without a JSON
+// Schema equivalent, such as registered rules, are omitted.
+//
+// Example:
+//
+//	doc, _ := json.MarshalIndent(userSchema.JSONSchema(), "", "  ")
+func (s Schema[T]) JSONSchema() map[string]any {
+	doc := jsonSchemaFor(reflect.TypeFor[T](), map[reflect.Type]bool{})
+	doc["$schema"] = jsonSchemaDialect
+
+	return doc
+}
+
+// jsonSchemaFor returns the JSON Schema of the given type.
+// Types being described are tracked in seen, so that
+// recursive types are described as accepting any value rather
+// than recursing forever.
+func jsonSchemaFor(t reflect.Type, seen map[reflect.Type]bool) map[string]any {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": jsonSchemaFor(t.Elem(), seen)}
+	case reflect.Map:
+		if t.Key().Kind() == reflect.String {
+			return map[string]any{"type": "object", "additionalProperties": jsonSchemaFor(t.Elem(), seen)}
+		}
+	case reflect.Struct:
+		if seen[t] {
+			break
+		}
+
+		seen[t] = true
+		defer delete(seen, t)
+
+		doc := map[string]any{"type": "object"}
+		properties, required := map[string]any{}, []string{}
+		jsonSchemaProperties(t, seen, properties, &required)
+
+		doc["properties"] = properties
+		if len(required) > 0 {
+			doc["required"] = required
+		}
+		return doc
+	}
+
+	return map[string]any{}
+}
+
+// jsonSchemaProperties adds the properties of the struct type
+// to properties, and the names of its required fields to
+// required. Untagged embedded structs are flattened, as with
+// encoding/json.
+func jsonSchemaProperties(t reflect.Type, seen map[reflect.Type]bool, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("valtra")
+		if !sf.IsExported() || sf.Tag.Get("json") == "-" || tag == "-" {
+			continue
+		}
+
+		ft := sf.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		jsonName, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if sf.Anonymous && ft.Kind() == reflect.Struct && tag == "" && jsonName == "" {
+			jsonSchemaProperties(ft, seen, properties, required)
+			continue
+		}
+
+		name := structFieldName(sf)
+		prop := jsonSchemaFor(sf.Type, seen)
+		for rule := range strings.SplitSeq(tag, ",") {
+			rule, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+			if rule == "required" {
+				*required = append(*required, name)
+				continue
+			}
+
+			applyJSONSchemaRule(prop, ft, rule, param)
+		}
+
+		properties[name] = prop
+	}
+}
+
+// applyJSONSchemaRule adds the JSON Schema keywords of a
+// struct tag rule to the schema of a field of the given type.
+// Rules without an equivalent, and invalid parameters, are
+// ignored.
+func applyJSONSchemaRule(prop map[string]any, t reflect.Type, rule, param string) {
+	if keywords, ok := jsonSchemaStringRules[rule]; ok && t.Kind() == reflect.String {
+		for k, v := range keywords {
+			if k == "pattern" {
+				addJSONSchemaPattern(prop, v.(string))
+			} else {
+				prop[k] = v
+			}
+		}
+		return
+	}
+
+	switch rule {
+	case "min", "max":
+		keyword := map[reflect.Kind][2]string{
+			reflect.String: {"minLength", "maxLength"},
+			reflect.Slice:  {"minItems", "maxItems"},
+			reflect.Array:  {"minItems", "maxItems"},
+			reflect.Map:    {"minProperties", "maxProperties"},
+		}[t.Kind()]
+		if prop["type"] == "integer" || prop["type"] == "number" {
+			keyword = [2]string{"minimum", "maximum"}
+		}
+		if keyword[0] == "" {
+			return
+		}
+
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return
+		}
+		if rule == "min" {
+			prop[keyword[0]] = n
+		} else {
+			prop[keyword[1]] = n
+		}
+	case "oneof":
+		var enum []any
+		for o := range strings.FieldsSeq(param) {
+			if prop["type"] == "string" {
+				enum = append(enum, o)
+			} else if n, err := strconv.ParseInt(o, 10, 64); err == nil {
+				enum = append(enum, n)
+			}
+		}
+		prop["enum"] = enum
+	case "contains", "startswith", "endswith":
+		if t.Kind() != reflect.String {
+			return
+		}
+
+		switch quoted := regexp.QuoteMeta(param); rule {
+		case "contains":
+			addJSONSchemaPattern(prop, quoted)
+		case "startswith":
+			addJSONSchemaPattern(prop, "^"+quoted)
+		default:
+			addJSONSchemaPattern(prop, quoted+"$")
+		}
+	}
+}
+
+// addJSONSchemaPattern adds a pattern to the schema of a
+// field. As a schema has a single "pattern" keyword, fields
+// with several patterns have them combined under "allOf", so
+// that values must match every one of them.
+func addJSONSchemaPattern(prop map[string]any, pattern string) {
+	if first, ok := prop["pattern"]; ok {
+		delete(prop, "pattern")
+		prop["allOf"] = []any{map[string]any{"pattern": first}}
+	}
+	if allOf, ok := prop["allOf"].([]any); ok {
+		prop["allOf"] = append(allOf, map[string]any{"pattern": pattern})
+		return
+	}
+
+	prop["pattern"] = pattern
+}
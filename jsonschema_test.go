@@ -0,0 +1,83 @@
+package valtra_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+type Audit struct {
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type address struct {
+	City string `json:"city" valtra:"required,min=2"`
+}
+
+type node struct {
+	Children []*node `json:"children"`
+}
+
+type account struct {
+	Audit
+	Email    string            `json:"email" valtra:"required,email"`
+	Age      int               `json:"age" valtra:"min=18,max=130"`
+	Role     string            `json:"role" valtra:"oneof=admin user"`
+	Code     string            `json:"code" valtra:"startswith=ac-"`
+	Tags     []string          `json:"tags" valtra:"max=5"`
+	Labels   map[string]string `json:"labels"`
+	Address  *address          `json:"address"`
+	Avatar   []byte            `json:"avatar"`
+	Tree     node              `json:"tree"`
+	Nickname string            `valtra:"custom_rule"`
+	Secret   string            `json:"-"`
+	internal string
+}
+
+func TestSchemaJSONSchema(t *testing.T) {
+	doc, err := json.Marshal(valtra.NewSchema[account]().JSONSchema())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	want := `{"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+		`"properties":{` +
+		`"Nickname":{"type":"string"},` +
+		`"address":{"properties":{"city":{"minLength":2,"type":"string"}},"required":["city"],"type":"object"},` +
+		`"age":{"maximum":130,"minimum":18,"type":"integer"},` +
+		`"avatar":{"contentEncoding":"base64","type":"string"},` +
+		`"code":{"pattern":"^ac-","type":"string"},` +
+		`"created_at":{"format":"date-time","type":"string"},` +
+		`"email":{"format":"email","type":"string"},` +
+		`"labels":{"additionalProperties":{"type":"string"},"type":"object"},` +
+		`"role":{"enum":["admin","user"],"type":"string"},` +
+		`"tags":{"items":{"type":"string"},"maxItems":5,"type":"array"},` +
+		`"tree":{"properties":{"children":{"items":{},"type":"array"}},"type":"object"}` +
+		`},"required":["email"],"type":"object"}`
+	if string(doc) != want {
+		t.Errorf("Unexpected document:\n got: %s\nwant: %s", doc, want)
+	}
+
+	t.Run("several patterns are combined", func(t *testing.T) {
+		type sku struct {
+			Code string `json:"code" valtra:"startswith=SKU-,endswith=-EU,numeric"`
+		}
+
+		doc, _ := json.Marshal(valtra.NewSchema[sku]().JSONSchema())
+		want := `{"$schema":"https://json-schema.org/draft/2020-12/schema",` +
+			`"properties":{"code":{"allOf":[{"pattern":"^SKU-"},{"pattern":"-EU$"},{"pattern":"^[0-9]+$"}],"type":"string"}},` +
+			`"type":"object"}`
+		if string(doc) != want {
+			t.Errorf("Unexpected document:\n got: %s\nwant: %s", doc, want)
+		}
+	})
+
+	t.Run("non-struct types", func(t *testing.T) {
+		doc, _ := json.Marshal(valtra.NewSchema[[]int]().JSONSchema())
+		if string(doc) != `{"$schema":"https://json-schema.org/draft/2020-12/schema","items":{"type":"integer"},"type":"array"}` {
+			t.Errorf("Unexpected document: %s", doc)
+		}
+	})
+}
@@ -0,0 +1,151 @@
+package valtra
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RuleConflict describes rules found by Lint that contradict
+// or repeat each other.
+type RuleConflict struct {
+	// Field is the name of the field the rules apply to, or
+	// empty if they apply to the value as a whole.
+	Field string
+	// Kind is "contradiction" for rules no value can pass
+	// together, such as min_length=5 and max_length=3, or
+	// "duplicate" for a rule applied twice.
+	Kind string
+	// Rules holds the conflicting rules, in the order they
+	// are applied.
+	Rules []RuleInfo
+}
+
+// String formats the conflict, e.g. "age: min=65 contradicts
+// max=18" or "email: required is repeated".
+func (c RuleConflict) String() string {
+	prefix := ""
+	if c.Field != "" {
+		prefix = c.Field + ": "
+	}
+
+	rules := make([]string, len(c.Rules))
+	for i, r := range c.Rules {
+		rules[i] = r.String()
+	}
+
+	if c.Kind == "duplicate" {
+		return prefix + rules[0] + " is repeated"
+	}
+	if len(rules) == 1 {
+		return prefix + rules[0] + " contradicts itself"
+	}
+
+	return prefix + strings.Join(rules, " contradicts ")
+}
+
+// ruleBounds gives, for the rules Lint understands, the
+// parameters holding their lower and upper bounds, grouped by
+// what the bounds apply to, so that a minimum length is not
+// compared with a maximum value.
+var ruleBounds = map[string]struct{ kind, lower, upper string }{
+	"min":        {"value", "min", ""},
+	"max":        {"value", "", "max"},
+	"between":    {"value", "min", "max"},
+	"min_length": {"length", "min", ""},
+	"max_length": {"length", "", "max"},
+}
+
+// Lint returns the conflicts among the described rules, such
+// as those of Schema.Describe or DescribeStruct: contradictory
+// bounds, and rules repeated with the same parameters.
+//
+// It suits tests of schemas composed from shared fragments,
+// where conflicting rules are easy to miss. The bounds of the
+// "min", "max", "between", "min_length" and "max_length"
+// rules are compared, whether they are numbers or numeric
+// strings. Rules that apply at different times, or to
+// different countries, do not conflict.
+//
+// Example:
+//
+//	for _, c := range valtra.Lint(signupSchema.Describe()) {
+//	    t.Errorf("conflicting rules: %s", c)
+//	}
+func Lint(rules []RuleInfo) []RuleConflict {
+	var conflicts []RuleConflict
+	for i, a := range rules {
+		for j, b := range rules[i:] {
+			j += i
+			if a.Field != b.Field || !overlapping(a, b) {
+				continue
+			}
+
+			if i != j && a.Name == b.Name && reflect.DeepEqual(a.Params, b.Params) {
+				conflicts = append(conflicts, RuleConflict{Field: a.Field, Kind: "duplicate", Rules: []RuleInfo{a, b}})
+				continue
+			}
+			if contradicts(a, b) || i != j && contradicts(b, a) {
+				c := RuleConflict{Field: a.Field, Kind: "contradiction", Rules: []RuleInfo{a, b}}
+				if i == j {
+					c.Rules = c.Rules[:1]
+				}
+				conflicts = append(conflicts, c)
+			}
+		}
+	}
+
+	return conflicts
+}
+
+// Lint returns the conflicts among the schema's described
+// rules, as Lint does for Describe.
+func (s Schema[T]) Lint() []RuleConflict {
+	return Lint(s.Describe())
+}
+
+// overlapping reports whether the two rules are applied
+// together: at the same time for some time, and to the same
+// countries.
+func overlapping(a, b RuleInfo) bool {
+	if a.Country != b.Country && a.Country != "" && b.Country != "" {
+		return false
+	}
+
+	return (a.Until.IsZero() || b.EffectiveFrom.IsZero() || b.EffectiveFrom.Before(a.Until)) &&
+		(b.Until.IsZero() || a.EffectiveFrom.IsZero() || a.EffectiveFrom.Before(b.Until))
+}
+
+// contradicts reports whether a's lower bound is above b's
+// upper bound, for bounds of the same kind.
+func contradicts(a, b RuleInfo) bool {
+	ab, ok := ruleBounds[a.Name]
+	bb, bok := ruleBounds[b.Name]
+	if !ok || !bok || ab.kind != bb.kind || ab.lower == "" || bb.upper == "" {
+		return false
+	}
+
+	lower, ok := paramNumber(a.Params[ab.lower])
+	upper, uok := paramNumber(b.Params[bb.upper])
+	return ok && uok && lower > upper
+}
+
+// paramNumber returns a rule parameter that is a number, or a
+// string holding one, as a float64.
+func paramNumber(param any) (float64, bool) {
+	if s, ok := param.(string); ok {
+		n, err := strconv.ParseFloat(s, 64)
+		return n, err == nil
+	}
+
+	switch rv := reflect.ValueOf(param); rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,84 @@
+package valtra_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestLint(t *testing.T) {
+	required := valtra.Describe("required", nil, valtra.Required[string]())
+	minLength := func(n int) valtra.Rule[string] {
+		return valtra.Describe("min_length", map[string]any{"min": n}, valtra.MinLengthString(n))
+	}
+	maxLength := func(n int) valtra.Rule[string] {
+		return valtra.Describe("max_length", map[string]any{"max": n}, valtra.MaxLengthString(n))
+	}
+
+	t.Run("compatible rules pass", func(t *testing.T) {
+		schema := valtra.NewSchema[string]().Rules(required, minLength(3), maxLength(3))
+		if conflicts := schema.Lint(); len(conflicts) != 0 {
+			t.Errorf("Expected no conflicts, got %v", conflicts)
+		}
+	})
+
+	t.Run("contradictory bounds are reported", func(t *testing.T) {
+		schema := valtra.NewSchema[string]().Rules(maxLength(3), required, minLength(5))
+
+		conflicts := schema.Lint()
+		if len(conflicts) != 1 || conflicts[0].Kind != "contradiction" {
+			t.Fatalf("Expected a single contradiction, got %v", conflicts)
+		}
+		if got := conflicts[0].String(); got != "max_length(max=3) contradicts min_length(min=5)" {
+			t.Errorf("Unexpected description %q", got)
+		}
+	})
+
+	t.Run("duplicate rules are reported", func(t *testing.T) {
+		schema := valtra.NewSchema[string]().Rules(required, maxLength(3), required, maxLength(4))
+
+		conflicts := schema.Lint()
+		if len(conflicts) != 1 || conflicts[0].Kind != "duplicate" || conflicts[0].String() != "required is repeated" {
+			t.Errorf("Expected required to be reported as repeated, got %v", conflicts)
+		}
+	})
+
+	t.Run("between contradicting itself", func(t *testing.T) {
+		conflicts := valtra.Lint([]valtra.RuleInfo{{Field: "age", Name: "between", Params: map[string]any{"min": 65, "max": 18}}})
+		if len(conflicts) != 1 || conflicts[0].String() != "age: between(max=18, min=65) contradicts itself" {
+			t.Errorf("Expected a contradiction, got %v", conflicts)
+		}
+	})
+
+	t.Run("bounds of different kinds are not compared", func(t *testing.T) {
+		conflicts := valtra.Lint([]valtra.RuleInfo{
+			{Field: "tags", Name: "min_length", Params: map[string]any{"min": 5}},
+			{Field: "tags", Name: "max", Params: map[string]any{"max": 3}},
+			{Field: "other", Name: "max_length", Params: map[string]any{"max": 3}},
+		})
+		if len(conflicts) != 0 {
+			t.Errorf("Expected no conflicts, got %v", conflicts)
+		}
+	})
+
+	t.Run("struct tags are linted", func(t *testing.T) {
+		type user struct {
+			Age  int    `valtra:"min=18,max=12"`
+			Name string `valtra:"required,required"`
+		}
+
+		conflicts := valtra.Lint(valtra.DescribeStruct(user{}))
+		if len(conflicts) != 2 || conflicts[0].Field != "Age" || conflicts[1].Kind != "duplicate" {
+			t.Errorf("Expected a contradiction and a duplicate, got %v", conflicts)
+		}
+	})
+
+	t.Run("rules applied at different times do not conflict", func(t *testing.T) {
+		jan1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+		schema := valtra.NewSchema[string]().Rules(maxLength(3).Until(jan1), minLength(5).EffectiveFrom(jan1))
+		if conflicts := schema.Lint(); len(conflicts) != 0 {
+			t.Errorf("Expected no conflicts, got %v", conflicts)
+		}
+	})
+}
@@ -0,0 +1,406 @@
+package valtra
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unsafe"
+)
+
+// Translator provides message templates for validation error
+// codes, allowing built-in messages to be emitted in other
+// languages.
+//
+// Templates can reference the value's name with {name}, the
+// value itself with {value}, and any of the rule's parameters
+// by key, e.g. {min} or {max}.
+type Translator interface {
+	// Translate returns the message template for the given
+	// code, and whether one was found.
+	Translate(code string) (string, bool)
+}
+
+// Messages is a Translator backed by a map of error codes to
+// message templates.
+//
+// Example:
+//
+//	valtra.RegisterLocale("fr", valtra.Messages{
+//	    "required": "{name} est obligatoire",
+//	    "min":      "{name} doit être au moins {min}",
+//	})
+type Messages map[string]string
+
+// Translate returns the message template for the given code.
+func (m Messages) Translate(code string) (string, bool) {
+	tmpl, ok := m[code]
+	return tmpl, ok
+}
+
+// defaultMessages holds the built-in English message
+// templates, keyed by error code.
+var defaultMessages = Messages{
+	"required":            "{name} is required",
+	"not_zero":            "{name} is required",
+	"is_zero":             "{name} must not be set",
+	"equals":              "{name} does not match",
+	"not_equals":          "{name} must be different",
+	"max":                 "{name} cannot be larger than {max}",
+	"min":                 "{name} cannot be smaller than {min}",
+	"min_string":          "{name} must not sort before {min}",
+	"max_string":          "{name} must not sort after {max}",
+	"greater_than":        "{name} must be greater than {bound}",
+	"less_than":           "{name} must be less than {bound}",
+	"quota":               "{name} exceeds the remaining quota of {remaining}",
+	"between":             "{name} must be between {min} and {max}",
+	"positive":            "{name} must be positive",
+	"negative":            "{name} must be negative",
+	"non_negative":        "{name} cannot be negative",
+	"multiple_of":         "{name} must be a multiple of {n}",
+	"max_decimal_places":  "{name} cannot have more than {max} decimal places",
+	"max_length":          "{name}'s length cannot be larger than {max}",
+	"min_length":          "{name}'s length cannot be smaller than {min}",
+	"alpha":               "{name} must contain only letters",
+	"alphanumeric":        "{name} must contain only letters and digits",
+	"numeric":             "{name} must contain only digits",
+	"decimal":             "{name} must be a decimal number",
+	"decimal_range":       "{name} must be between {min} and {max}",
+	"quantity":            "{name} must be a valid quantity",
+	"quantity_range":      "{name} must be between {min} and {max}",
+	"duration":            "{name} must be a valid duration",
+	"duration_range":      "{name} must be between {min} and {max}",
+	"unit":                "{name} cannot be converted to {unit}",
+	"measurement":         "{name} must be between {min} and {max}",
+	"int":                 "{name} must be a whole number",
+	"float":               "{name} must be a number",
+	"bool":                "{name} must be true or false",
+	"ascii":               "{name} must contain only ASCII characters",
+	"contains":            "{name} must contain {substr}",
+	"has_prefix":          "{name} must start with {prefix}",
+	"has_suffix":          "{name} must end with {suffix}",
+	"decrypt":             "{name} could not be decrypted",
+	"disposable_email":    "{name} must not use a disposable email provider",
+	"email_mx":            "{name} must have a domain that accepts email",
+	"email":               "{name} must be in correct email format",
+	"match":               "{name} must match the pattern {pattern}",
+	"ascending":           "{name} must be in ascending order",
+	"or":                  "{reasons}",
+	"not":                 "{name} is invalid",
+	"pair":                "{name} are not valid together",
+	"url":                 "{name} must be a valid URL",
+	"url_scheme":          "{name} must use one of the schemes: {schemes}",
+	"uri":                 "{name} must be a valid URI",
+	"ip":                  "{name} must be a valid IP address",
+	"ipv4":                "{name} must be a valid IPv4 address",
+	"ipv6":                "{name} must be a valid IPv6 address",
+	"cidr":                "{name} must be a valid CIDR prefix",
+	"mac_address":         "{name} must be a valid MAC address",
+	"hostname":            "{name} must be a valid hostname",
+	"mqtt_topic":          "{name} must be a valid MQTT topic ({reason})",
+	"uuid":                "{name} must be a valid UUID",
+	"uuid_version":        "{name} must be a valid version {version} UUID",
+	"ulid":                "{name} must be a valid ULID",
+	"hex":                 "{name} must be a hexadecimal string",
+	"hex_color":           "{name} must be a hex colour, such as #1e90ff",
+	"one_of":              "{name} must be one of: {values}",
+	"not_in":              "{name} cannot be one of: {values}",
+	"unique":              "{name} contains a duplicate at index {index}",
+	"allowed_keys":        "{name} contains unknown keys",
+	"did_you_mean":        "(did you mean {suggestion}?)",
+	"pdf":                 "{name} must be a PDF document",
+	"max_size":            "{name} cannot be larger than {max} bytes",
+	"max_pages":           "{name} cannot have more than {max} pages",
+	"no_javascript":       "{name} cannot contain JavaScript",
+	"content_type":        "{name} has content type {detected}, which is not one of: {allowed}",
+	"content_mismatch":    "{name} has content type {detected}, which does not match its declared type {declared}",
+	"too_large":           "{name} cannot be larger than {max} bytes",
+	"image":               "{name} must be a valid image",
+	"gps_metadata":        "{name} cannot contain GPS location data",
+	"svg":                 "{name} must be a safe SVG image ({reason})",
+	"sha256":              "{name} does not match the expected SHA-256 digest",
+	"json":                "{name} must be valid JSON",
+	"json_depth":          "{name} must not be nested more than {max} levels deep",
+	"json_keys":           "{name} must not have more than {max} keys",
+	"json_string_bytes":   "{name}'s text cannot be longer than {max} bytes",
+	"csv":                 "{name} must be a valid CSV record ({reason})",
+	"base64":              "{name} must be valid base64",
+	"base64url":           "{name} must be valid URL-safe base64",
+	"before":              "{name} must be before {before}",
+	"after":               "{name} must be after {after}",
+	"between_time":        "{name} must be between {start} and {end}",
+	"date_format":         "{name} must be a date in the format {layout}",
+	"rrule":               "{name} must be a valid recurrence rule ({reason})",
+	"ics":                 "{name} must be a valid iCalendar file ({reason})",
+	"ics_event":           "{name} event {event} is invalid ({reason})",
+	"vcard":               "{name} must be a valid vCard file ({reason})",
+	"vcard_contact":       "{name} contact {contact} is invalid ({reason})",
+	"daily_window":        "{name} must be between {start} and {end} ({location} time)",
+	"cookie_name":         "{name} must be a valid cookie name",
+	"cookie_value":        "{name} contains characters that are not allowed in cookies",
+	"cookie_size":         "{name} cannot be larger than {max} bytes",
+	"cookie_same_site":    "{name} must be one of: {values}",
+	"country_code":        "{name} must be a valid ISO 3166-1 country code",
+	"currency_code":       "{name} must be a valid ISO 4217 currency code",
+	"language_tag":        "{name} must be a valid BCP 47 language tag",
+	"timezone":            "{name} must be a valid timezone",
+	"not_idempotent":      "{name} changes when normalised again",
+	"phone_number":        "{name} must be a valid phone number",
+	"phone_number_region": "{name} must be a valid phone number for region {region}",
+	"password_min_length": "{name} must be at least {min} characters long",
+	"password_upper":      "{name} must contain an uppercase letter",
+	"password_lower":      "{name} must contain a lowercase letter",
+	"password_digit":      "{name} must contain a digit",
+	"password_symbol":     "{name} must contain a symbol",
+	"password_repeated":   "{name} cannot repeat a character more than {max} times in a row",
+	"password_entropy":    "{name} is too easy to guess",
+	"luhn":                "{name} has an invalid check digit",
+	"credit_card":         "{name} must be a valid card number",
+	"credit_card_brand":   "{name} must be a valid card number from one of: {brands}",
+	"iban":                "{name} must be a valid IBAN",
+	"min_score":           "{name} does not look genuine (score {score}, minimum {min})",
+	"fixed":               "{name} was corrected",
+	"unknown_version":     "{name} uses unknown version {version}",
+	"remote_unavailable":  "{name} could not be checked",
+
+	// Reported by values whose Budget is used up
+	"validation_budget_exceeded": "{name} took too much work to validate",
+}
+
+// localeState holds the registered translators, keyed by
+// locale, along with the locale used for newly created
+// errors.
+type localeState struct {
+	translators map[string]Translator
+	// packs holds the message templates of the rule packs
+	// installed with Use, keyed by locale, with "" for English.
+	packs  map[string]Messages
+	active string
+}
+
+// locales holds the current localeState. As with the rule
+// registry, the state is immutable and replaced with an
+// updated copy on changes, so that rendering messages reads it
+// without locking, even while translators are reloaded.
+// Changes are serialised by mu.
+var locales struct {
+	mu    sync.Mutex
+	state atomic.Pointer[localeState]
+}
+
+// currentLocales returns the current localeState, which must
+// not be modified.
+func currentLocales() *localeState {
+	if state := locales.state.Load(); state != nil {
+		return state
+	}
+
+	return &noLocales
+}
+
+// noLocales is the localeState before any change.
+var noLocales localeState
+
+// updateLocales replaces the localeState with a copy changed
+// by update.
+func updateLocales(update func(*localeState)) {
+	locales.mu.Lock()
+	defer locales.mu.Unlock()
+
+	state := *currentLocales()
+	state.translators = maps.Clone(state.translators)
+	if state.translators == nil {
+		state.translators = map[string]Translator{}
+	}
+	update(&state)
+	locales.state.Store(&state)
+}
+
+// RegisterLocale registers a Translator for the given locale
+// (e.g. "fr" or "de-AT"), replacing any registered before.
+//
+// Codes the translator does not know fall back to the
+// default English messages. Translators can be replaced at
+// any time, e.g. to reload a catalog, without blocking the
+// rendering of messages.
+func RegisterLocale(locale string, t Translator) {
+	updateLocales(func(state *localeState) {
+		state.translators[locale] = t
+	})
+}
+
+// SetLocale sets the locale used for the messages of all
+// errors created from now on. An empty locale restores the
+// default English messages.
+//
+// To render errors in a per-request locale instead, use
+// Localize.
+func SetLocale(locale string) {
+	updateLocales(func(state *localeState) {
+		state.active = locale
+	})
+}
+
+// activeLocale returns the package-level locale.
+func activeLocale() string {
+	return currentLocales().active
+}
+
+// Localize re-renders the messages of the given error in the
+// given locale.
+//
+// It works with a single *ValidationError as well as with
+// joined errors. Errors created with a custom message, and
+// errors that are not validation errors, are left unchanged.
+//
+// Example:
+//
+//	for _, err := range c.Errors() {
+//	    fmt.Println(valtra.Localize(err, "de"))
+//	}
+func Localize(err error, locale string) error {
+	if ve, ok := err.(*ValidationError); ok {
+		if ve.custom != "" {
+			return ve
+		}
+
+		localized := *ve
+		localized.Message = render(ve, locale)
+		return &localized
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		errs := joined.Unwrap()
+		localized := make([]error, len(errs))
+		for i, e := range errs {
+			localized[i] = Localize(e, locale)
+		}
+
+		return errors.Join(localized...)
+	}
+
+	return err
+}
+
+// render renders the message of the given error in the given
+// locale, falling back to the default English template.
+//
+// Templates are looked up by the error's code first, and by
+// the rule's default code second, in the locale's translator,
+// the locale's templates of rule packs, the default English
+// templates and the English templates of rule packs, in turn.
+// If the error has a "suggestion" parameter, and is not
+// redacted, the "did_you_mean" template is appended to the
+// message.
+func render(e *ValidationError, locale string) string {
+	state := currentLocales()
+	translators := [4]Translator{2: defaultMessages}
+	if locale != "" {
+		translators[0] = state.translators[locale]
+		translators[1] = state.packs[locale]
+	}
+	translators[3] = state.packs[""]
+
+	msg := lookup(e, translators[:], e.Code, e.rule)
+	if _, ok := e.Params["suggestion"]; ok && !e.redacted {
+		msg += " " + lookup(e, translators[:], "did_you_mean")
+	}
+
+	return msg
+}
+
+// lookup renders the first template found for the given
+// codes, trying the translators in turn.
+func lookup(e *ValidationError, translators []Translator, codes ...string) string {
+	for _, tr := range translators {
+		if tr == nil {
+			continue
+		}
+		for _, code := range codes {
+			if tmpl, ok := tr.Translate(code); ok {
+				return interpolate(tmpl, e)
+			}
+		}
+	}
+
+	return interpolate("{name} is invalid", e)
+}
+
+// interpolate replaces the {name}, {value} and {param}
+// placeholders in the template with the details of the given
+// error. Unknown placeholders are left untouched.
+func interpolate(tmpl string, e *ValidationError) string {
+	if !strings.Contains(tmpl, "{") {
+		return tmpl
+	}
+
+	// Placeholders are usually replaced by short values, so
+	// growing the buffer up front avoids most reallocations.
+	size := len(tmpl) + len(e.Field) + 16
+	if e.scratch != nil {
+		return e.scratch.message(size, func(b []byte) []byte { return appendInterpolated(b, tmpl, e) })
+	}
+
+	b := appendInterpolated(make([]byte, 0, size), tmpl, e)
+	return unsafe.String(unsafe.SliceData(b), len(b))
+}
+
+// appendInterpolated appends the template to b with its
+// placeholders replaced, as interpolate does.
+func appendInterpolated(b []byte, tmpl string, e *ValidationError) []byte {
+	for {
+		start := strings.IndexByte(tmpl, '{')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(tmpl[start:], '}')
+		if end < 0 {
+			break
+		}
+		end += start
+
+		b = append(b, tmpl[:start]...)
+		key := tmpl[start+1 : end]
+		switch param, ok := e.Params[key]; {
+		case key == "name":
+			b = append(b, e.Field...)
+		case key == "value":
+			b = append(b, formatParam(e.value)...)
+		case ok && e.redacted && isValueParam(key):
+			b = append(b, Redacted...)
+		case ok:
+			b = append(b, formatParam(param)...)
+		default:
+			b = append(b, tmpl[start:end+1]...)
+		}
+
+		tmpl = tmpl[end+1:]
+	}
+
+	return append(b, tmpl...)
+}
+
+// formatParam formats a value for use in a message. Slices
+// are listed as comma-separated values, e.g. "a, b, c", and
+// times are formatted as RFC 3339.
+func formatParam(param any) string {
+	if t, ok := param.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+
+	rv := reflect.ValueOf(param)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return fmt.Sprint(param)
+	}
+
+	items := make([]string, rv.Len())
+	for i := range items {
+		items[i] = fmt.Sprint(rv.Index(i).Interface())
+	}
+
+	return strings.Join(items, ", ")
+}
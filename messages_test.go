@@ -0,0 +1,116 @@
+package valtra_test
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestValidationError(t *testing.T) {
+	t.Run("built-in errors expose code and params", func(t *testing.T) {
+		v := valtra.Val(5, "age").Validate(valtra.Min(18))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) {
+			t.Fatalf("Expected *ValidationError, got %T", v.Errors()[0])
+		}
+		if ve.Field != "age" || ve.Code != "min" || ve.Params["min"] != 18 {
+			t.Errorf("Unexpected error details: %+v", ve)
+		}
+		if ve.Error() != "age cannot be smaller than 18" {
+			t.Errorf("Expected default message, got %q", ve.Error())
+		}
+	})
+}
+
+func TestLocales(t *testing.T) {
+	valtra.RegisterLocale("fr", valtra.Messages{
+		"required": "{name} est obligatoire",
+		"min":      "{name} doit être au moins {min}",
+	})
+
+	t.Run("active locale translates messages", func(t *testing.T) {
+		valtra.SetLocale("fr")
+		t.Cleanup(func() { valtra.SetLocale("") })
+
+		v := valtra.Val("", "nom").Validate(valtra.Required[string]())
+		if v.Errors()[0].Error() != "nom est obligatoire" {
+			t.Errorf("Expected translated message, got %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("unknown codes fall back to english", func(t *testing.T) {
+		valtra.SetLocale("fr")
+		t.Cleanup(func() { valtra.SetLocale("") })
+
+		v := valtra.Val("ab", "nom").Validate(valtra.MinLengthString(3))
+		if v.Errors()[0].Error() != "nom's length cannot be smaller than 3" {
+			t.Errorf("Expected fallback message, got %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("Localize re-renders errors in a locale", func(t *testing.T) {
+		v := valtra.Val(5, "âge").Validate(valtra.Min(18))
+
+		err := valtra.Localize(v.Errors()[0], "fr")
+		if err.Error() != "âge doit être au moins 18" {
+			t.Errorf("Expected localized message, got %q", err.Error())
+		}
+		if v.Errors()[0].Error() != "âge cannot be smaller than 18" {
+			t.Errorf("Expected original error to be unchanged, got %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("Localize keeps custom messages", func(t *testing.T) {
+		customMsg := "Please enter a name"
+		v := valtra.Val("").Validate(valtra.Required[string](valtra.WithMessage(customMsg)))
+
+		if err := valtra.Localize(v.Errors()[0], "fr"); err.Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, err.Error())
+		}
+	})
+}
+
+func TestReloadLocale(t *testing.T) {
+	// Catalogs can be replaced while messages are rendered
+	catalogs := []valtra.Messages{{"required": "{name} ist erforderlich"}, {"required": "{name} muss angegeben werden"}}
+	valtra.RegisterLocale("de", catalogs[0])
+
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		for i := range 1000 {
+			valtra.RegisterLocale("de", catalogs[i%2])
+		}
+	})
+	for range 4 {
+		wg.Go(func() {
+			v := valtra.Val("", "name").Validate(valtra.Required[string]())
+			for range 1000 {
+				msg := valtra.Localize(v.FirstError(), "de").Error()
+				if msg != "name ist erforderlich" && msg != "name muss angegeben werden" {
+					t.Errorf("Unexpected message: %q", msg)
+					return
+				}
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestCustomMessagePlaceholders(t *testing.T) {
+	t.Run("placeholders are interpolated", func(t *testing.T) {
+		v := valtra.Val(16, "age").Validate(valtra.Min(18, valtra.WithMessage("{name} must be at least {min}, got {value}")))
+		if v.Errors()[0].Error() != "age must be at least 18, got 16" {
+			t.Errorf("Expected interpolated message, got %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("unknown placeholders are left untouched", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Required[string](valtra.WithMessage("{field} is {missing")))
+		if v.Errors()[0].Error() != "{field} is {missing" {
+			t.Errorf("Expected message to be unchanged, got %q", v.Errors()[0].Error())
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package valtra
+
+// meta is a key-value pair attached to a value with WithMeta,
+// linked to the pairs attached before it, so copies of a value
+// can add pairs without affecting each other.
+type meta struct {
+	key, value any
+	parent     *meta
+}
+
+// WithMeta returns a copy of the value carrying the given
+// key-value pair, which rules can read back with Meta.
+//
+// It lets custom rules depend on context, such as the current
+// user's country or the tenant's settings, without closing
+// over globals, so the same rule can be shared and tested with
+// different data. The pair is passed on to the values of
+// fields and derived values.
+//
+// As with context.WithValue, keys should be of an unexported
+// type, to avoid collisions between packages.
+//
+// Example:
+//
+//	type countryKey struct{}
+//
+//	phone := func(v valtra.Value[string]) error {
+//	    country, _ := v.Meta(countryKey{}).(string)
+//	    return valtra.PhoneNumberForRegion(country)(v)
+//	}
+//
+//	valtra.Val(input.Phone, "phone").WithMeta(countryKey{}, user.Country).Validate(phone)
+func (v Value[T]) WithMeta(key, value any) Value[T] {
+	v.meta = &meta{key: key, value: value, parent: v.meta}
+	return v
+}
+
+// Meta returns the value attached to the value with WithMeta
+// under the given key, or nil if there is none. If the key
+// was attached more than once, the last value wins.
+func (v Value[T]) Meta(key any) any {
+	for m := v.meta; m != nil; m = m.parent {
+		if m.key == key {
+			return m.value
+		}
+	}
+
+	return nil
+}
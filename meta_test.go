@@ -0,0 +1,68 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+type tenantKey struct{}
+
+func TestWithMeta(t *testing.T) {
+	maxForTenant := func(v valtra.Value[int]) error {
+		limit, ok := v.Meta(tenantKey{}).(int)
+		if !ok {
+			return errors.New("no tenant limit")
+		}
+
+		return valtra.Max(limit)(v)
+	}
+
+	t.Run("rules read metadata", func(t *testing.T) {
+		if v := valtra.Val(5).WithMeta(tenantKey{}, 10).Validate(maxForTenant); !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+		if v := valtra.Val(50).WithMeta(tenantKey{}, 10).Validate(maxForTenant); v.IsValid() {
+			t.Error("Expected validation to fail above the tenant's limit")
+		}
+	})
+
+	t.Run("missing key is nil", func(t *testing.T) {
+		v := valtra.Val(5).WithMeta("other", 1)
+		if got := v.Meta(tenantKey{}); got != nil {
+			t.Errorf("Expected nil, got %v", got)
+		}
+	})
+
+	t.Run("last value wins and copies are independent", func(t *testing.T) {
+		base := valtra.Val(5).WithMeta(tenantKey{}, 1)
+		a := base.WithMeta(tenantKey{}, 2)
+		b := base.WithMeta(tenantKey{}, 3)
+
+		if base.Meta(tenantKey{}) != 1 || a.Meta(tenantKey{}) != 2 || b.Meta(tenantKey{}) != 3 {
+			t.Errorf("Expected 1, 2 and 3, got %v, %v and %v", base.Meta(tenantKey{}), a.Meta(tenantKey{}), b.Meta(tenantKey{}))
+		}
+	})
+
+	t.Run("fields and derived values see metadata", func(t *testing.T) {
+		type order struct{ Quantity int }
+
+		schema := valtra.NewSchema[order]().Validate(
+			valtra.Field("quantity", func(o order) int { return o.Quantity }, maxForTenant),
+			valtra.Derive(func(o order) int { return o.Quantity * 2 }, maxForTenant),
+		)
+
+		v := valtra.Val(order{Quantity: 4}).WithMeta(tenantKey{}, 6).Apply(schema)
+		if len(v.Errors()) != 1 {
+			t.Errorf("Expected only the derived value to fail, got %v", v.Errors())
+		}
+	})
+
+	t.Run("conversions keep metadata", func(t *testing.T) {
+		v := valtra.Map(valtra.Val("4").WithMeta(tenantKey{}, 3), func(s string) (int, error) { return len(s) * 4, nil }).Validate(maxForTenant)
+		if v.IsValid() || v.Meta(tenantKey{}) != 3 {
+			t.Errorf("Expected the converted value to keep its metadata, got %v", v.Meta(tenantKey{}))
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package valtra
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// maxMQTTTopicLength is the maximum length in bytes of an MQTT
+// topic name or filter.
+const maxMQTTTopicLength = 65535
+
+// MQTTTopic returns a validation that ensures the value is a
+// valid MQTT topic name, as used when publishing: non-empty
+// UTF-8 of at most 65535 bytes, without null characters or
+// the "+" and "#" wildcards.
+//
+// A maxLevels larger than 0 also limits the number of
+// "/"-separated levels.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(msg.Topic, "topic").Validate(valtra.MQTTTopic(8))
+func MQTTTopic(maxLevels int, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		reason := checkMQTTTopic(v.value, maxLevels)
+		if reason == "" && strings.ContainsAny(v.value, "+#") {
+			reason = "wildcards are not allowed"
+		}
+
+		if reason != "" {
+			return newError(v, "mqtt_topic", map[string]any{"reason": reason}, opts)
+		}
+
+		return nil
+	}
+}
+
+// MQTTTopicFilter returns a validation that ensures the value
+// is a valid MQTT topic filter, as used when subscribing.
+//
+// Filters follow the rules of MQTTTopic, except that they can
+// contain wildcards: "+" matches a single level and "#" any
+// number of trailing levels. Wildcards must occupy a whole
+// level, and "#" must be the last level.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(sub.Filter, "filter").Validate(valtra.MQTTTopicFilter(8))
+func MQTTTopicFilter(maxLevels int, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		reason := checkMQTTTopic(v.value, maxLevels)
+		if reason == "" {
+			levels := strings.Split(v.value, "/")
+			for i, level := range levels {
+				switch {
+				case level == "#" && i != len(levels)-1:
+					reason = `"#" must be the last level`
+				case level != "+" && level != "#" && strings.ContainsAny(level, "+#"):
+					reason = "wildcards must occupy a whole level"
+				default:
+					continue
+				}
+				break
+			}
+		}
+
+		if reason != "" {
+			return newError(v, "mqtt_topic", map[string]any{"reason": reason}, opts)
+		}
+
+		return nil
+	}
+}
+
+// checkMQTTTopic checks the rules shared by topic names and
+// filters, returning the reason the topic is invalid, or an
+// empty string if it is valid.
+func checkMQTTTopic(topic string, maxLevels int) string {
+	switch {
+	case topic == "":
+		return "topic is empty"
+	case len(topic) > maxMQTTTopicLength:
+		return "topic is too long"
+	case !utf8.ValidString(topic) || strings.ContainsRune(topic, 0):
+		return "topic contains invalid characters"
+	case maxLevels > 0 && strings.Count(topic, "/")+1 > maxLevels:
+		return "topic has too many levels"
+	}
+
+	return ""
+}
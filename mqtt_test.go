@@ -0,0 +1,70 @@
+package valtra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestMQTTTopic(t *testing.T) {
+	tests := []struct {
+		name  string
+		topic string
+		valid bool
+	}{
+		{"simple topic", "sensors/kitchen/temperature", true},
+		{"leading slash", "/sensors", true},
+		{"empty level", "sensors//temperature", true},
+		{"empty", "", false},
+		{"single-level wildcard", "sensors/+/temperature", false},
+		{"multi-level wildcard", "sensors/#", false},
+		{"null character", "sensors\x00", false},
+		{"invalid UTF-8", "sensors/\xff", false},
+		{"too many levels", "a/b/c/d/e", false},
+		{"too long", strings.Repeat("a", 65536), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.topic).Validate(valtra.MQTTTopic(4))
+			if v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got errors: %v", tt.valid, v.Errors())
+			}
+		})
+	}
+}
+
+func TestMQTTTopicFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		valid  bool
+	}{
+		{"plain topic", "sensors/kitchen", true},
+		{"single-level wildcard", "sensors/+/temperature", true},
+		{"multi-level wildcard", "sensors/#", true},
+		{"only multi-level wildcard", "#", true},
+		{"both wildcards", "+/+/#", true},
+		{"multi-level wildcard not last", "sensors/#/temperature", false},
+		{"partial level wildcard", "sensors/kitchen+", false},
+		{"partial multi-level wildcard", "sensors#", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := valtra.Val(tt.filter).Validate(valtra.MQTTTopicFilter(0))
+			if v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got errors: %v", tt.valid, v.Errors())
+			}
+		})
+	}
+
+	t.Run("reason is reported", func(t *testing.T) {
+		v := valtra.Val("a/#/b", "filter").Validate(valtra.MQTTTopicFilter(0))
+		if v.IsValid() || v.Errors()[0].Error() != `filter must be a valid MQTT topic ("#" must be the last level)` {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
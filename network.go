@@ -0,0 +1,226 @@
+package valtra
+
+import (
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// URL returns a validation that ensures the value is an
+// absolute http or https URL with a host.
+//
+// It is built on net/url, so it checks the URL's structure
+// rather than whether it "looks like" a URL.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("https://example.com/webhook").Validate(valtra.URL())
+func URL(opts ...Option) func(Value[string]) error {
+	return URLWithSchemes([]string{"http", "https"}, opts...)
+}
+
+// URLWithSchemes returns a validation that ensures the value
+// is an absolute URL with a host, using one of the allowed
+// schemes.
+//
+// Schemes are compared case-insensitively. Without any, it is
+// an invalid rule (see ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("wss://example.com/socket").Validate(valtra.URLWithSchemes([]string{"wss"}))
+func URLWithSchemes(schemes []string, opts ...Option) func(Value[string]) error {
+	if len(schemes) == 0 {
+		return invalidRule[string]("URLWithSchemes has no schemes")
+	}
+
+	scheme := "https"
+	if !slices.Contains(schemes, scheme) {
+		scheme = schemes[0]
+	}
+
+	return withAutoFix(func(v Value[string]) error {
+		u, err := url.Parse(v.value)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return newError(v, "url", nil, opts)
+		}
+
+		if !slices.ContainsFunc(schemes, func(s string) bool { return strings.EqualFold(s, u.Scheme) }) {
+			return newError(v, "url_scheme", map[string]any{"schemes": schemes}, opts)
+		}
+
+		return nil
+	}, repairURL(scheme), opts)
+}
+
+// URI returns a validation that ensures the value is an
+// absolute URI, i.e. it has a scheme (such as "mailto:" or
+// "urn:").
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("urn:isbn:0451450523").Validate(valtra.URI())
+func URI(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		u, err := url.Parse(v.value)
+		if err != nil || !u.IsAbs() {
+			return newError(v, "uri", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// IP returns a validation that ensures the value is an IPv4
+// or IPv6 address.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("2001:db8::1").Validate(valtra.IP())
+func IP(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if _, err := netip.ParseAddr(v.value); err != nil {
+			return newError(v, "ip", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// IPv4 returns a validation that ensures the value is an IPv4
+// address in dotted decimal notation, e.g. "192.0.2.1".
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("192.0.2.1").Validate(valtra.IPv4())
+func IPv4(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		addr, err := netip.ParseAddr(v.value)
+		if err != nil || !addr.Is4() {
+			return newError(v, "ipv4", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// IPv6 returns a validation that ensures the value is an IPv6
+// address, e.g. "2001:db8::1".
+//
+// IPv4-mapped IPv6 addresses (e.g. "::ffff:192.0.2.1") pass,
+// while plain IPv4 addresses do not.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("2001:db8::1").Validate(valtra.IPv6())
+func IPv6(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		addr, err := netip.ParseAddr(v.value)
+		if err != nil || !addr.Is6() {
+			return newError(v, "ipv6", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// CIDR returns a validation that ensures the value is an IP
+// address prefix in CIDR notation, e.g. "10.0.0.0/8" or
+// "2001:db8::/32".
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("10.0.0.0/8").Validate(valtra.CIDR())
+func CIDR(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if _, err := netip.ParsePrefix(v.value); err != nil {
+			return newError(v, "cidr", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// MACAddress returns a validation that ensures the value is
+// a MAC address (EUI-48, EUI-64 or 20-octet IP over
+// InfiniBand), in any of the formats accepted by
+// net.ParseMAC, e.g. "00:00:5e:00:53:01".
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("00:00:5e:00:53:01").Validate(valtra.MACAddress())
+func MACAddress(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		for _, f := range macFormats {
+			if f.match(v.value) {
+				return nil
+			}
+		}
+		if _, err := net.ParseMAC(v.value); err != nil {
+			return newError(v, "mac_address", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// hostnameLabelRegex matches a single RFC 1123 hostname label.
+var hostnameLabelRegex = regexp.MustCompile(`^[a-zA-Z0-9](?:[a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+// Hostname returns a validation that ensures the value is a
+// hostname as defined by RFC 1123.
+//
+// Each dot-separated label must be 1 to 63 letters, digits or
+// hyphens, and cannot start or end with a hyphen. The whole
+// hostname cannot be longer than 253 characters, and a single
+// trailing dot is allowed.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("api.example.com").Validate(valtra.Hostname())
+func Hostname(opts ...Option) func(Value[string]) error {
+	return withAutoFix(func(v Value[string]) error {
+		host := strings.TrimSuffix(v.value, ".")
+		if host == "" || len(host) > 253 {
+			return newError(v, "hostname", nil, opts)
+		}
+
+		for label := range strings.SplitSeq(host, ".") {
+			if !hostnameLabelRegex.MatchString(label) {
+				return newError(v, "hostname", nil, opts)
+			}
+		}
+
+		return nil
+	}, repairHostname, opts)
+}
@@ -0,0 +1,197 @@
+package valtra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestURL(t *testing.T) {
+	t.Run("https URL passes", func(t *testing.T) {
+		v := valtra.Val("https://example.com/webhook?id=1").Validate(valtra.URL())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("relative URL fails", func(t *testing.T) {
+		v := valtra.Val("/webhook").Validate(valtra.URL())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for relative URL")
+		}
+	})
+
+	t.Run("URL without host fails", func(t *testing.T) {
+		v := valtra.Val("https://").Validate(valtra.URL())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for URL without host")
+		}
+	})
+
+	t.Run("non-http scheme fails", func(t *testing.T) {
+		v := valtra.Val("ftp://example.com/file").Validate(valtra.URL())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for ftp URL")
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Invalid webhook"
+		v := valtra.Val("not a url").Validate(valtra.URL(valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestURLWithSchemes(t *testing.T) {
+	t.Run("allowed scheme passes", func(t *testing.T) {
+		v := valtra.Val("WSS://example.com/socket").Validate(valtra.URLWithSchemes([]string{"wss"}))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("disallowed scheme fails", func(t *testing.T) {
+		v := valtra.Val("http://example.com").Validate(valtra.URLWithSchemes([]string{"https"}))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for disallowed scheme")
+		}
+	})
+}
+
+func TestURI(t *testing.T) {
+	t.Run("absolute URI passes", func(t *testing.T) {
+		v := valtra.Val("mailto:bobby@example.com").Validate(valtra.URI())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("relative URI fails", func(t *testing.T) {
+		v := valtra.Val("path/to/file").Validate(valtra.URI())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for relative URI")
+		}
+	})
+}
+
+func TestIP(t *testing.T) {
+	t.Run("IPv4 and IPv6 addresses pass", func(t *testing.T) {
+		for _, addr := range []string{"192.0.2.1", "2001:db8::1"} {
+			v := valtra.Val(addr).Validate(valtra.IP())
+			if !v.IsValid() {
+				t.Errorf("Expected validation to pass for %q, got errors: %v", addr, v.Errors())
+			}
+		}
+	})
+
+	t.Run("invalid address fails", func(t *testing.T) {
+		v := valtra.Val("256.0.0.1").Validate(valtra.IP())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid address")
+		}
+	})
+}
+
+func TestIPv4(t *testing.T) {
+	t.Run("IPv4 address passes", func(t *testing.T) {
+		v := valtra.Val("192.0.2.1").Validate(valtra.IPv4())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("IPv6 address fails", func(t *testing.T) {
+		v := valtra.Val("::ffff:192.0.2.1").Validate(valtra.IPv4())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for IPv6 address")
+		}
+	})
+
+	t.Run("leading zeros fail", func(t *testing.T) {
+		v := valtra.Val("192.0.2.01").Validate(valtra.IPv4())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for leading zeros")
+		}
+	})
+}
+
+func TestIPv6(t *testing.T) {
+	t.Run("IPv6 address passes", func(t *testing.T) {
+		v := valtra.Val("2001:db8::1").Validate(valtra.IPv6())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("IPv4 address fails", func(t *testing.T) {
+		v := valtra.Val("192.0.2.1").Validate(valtra.IPv6())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for IPv4 address")
+		}
+	})
+}
+
+func TestCIDR(t *testing.T) {
+	t.Run("prefix passes", func(t *testing.T) {
+		v := valtra.Val("2001:db8::/32").Validate(valtra.CIDR())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("out of range prefix length fails", func(t *testing.T) {
+		v := valtra.Val("10.0.0.0/33").Validate(valtra.CIDR())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for out of range prefix length")
+		}
+	})
+
+	t.Run("address without prefix length fails", func(t *testing.T) {
+		v := valtra.Val("10.0.0.0").Validate(valtra.CIDR())
+		if v.IsValid() {
+			t.Error("Expected validation to fail without prefix length")
+		}
+	})
+}
+
+func TestMACAddress(t *testing.T) {
+	t.Run("MAC address passes", func(t *testing.T) {
+		v := valtra.Val("00-00-5E-00-53-01").Validate(valtra.MACAddress())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("invalid MAC address fails", func(t *testing.T) {
+		v := valtra.Val("00:00:5e:00:53").Validate(valtra.MACAddress())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid MAC address")
+		}
+	})
+}
+
+func TestHostname(t *testing.T) {
+	t.Run("hostname passes", func(t *testing.T) {
+		for _, host := range []string{"api.example.com", "localhost", "3com.net", "example.com."} {
+			v := valtra.Val(host).Validate(valtra.Hostname())
+			if !v.IsValid() {
+				t.Errorf("Expected validation to pass for %q, got errors: %v", host, v.Errors())
+			}
+		}
+	})
+
+	t.Run("invalid hostname fails", func(t *testing.T) {
+		for _, host := range []string{"", "-example.com", "example-.com", "exa_mple.com", "example..com", strings.Repeat("a", 64) + ".com"} {
+			v := valtra.Val(host).Validate(valtra.Hostname())
+			if v.IsValid() {
+				t.Errorf("Expected validation to fail for %q", host)
+			}
+		}
+	})
+}
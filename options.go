@@ -0,0 +1,104 @@
+package valtra
+
+// Option configures the error returned by a validation when
+// it fails, such as its message, code or severity.
+//
+// Options are accepted as the last parameters of all built-in
+// validations.
+//
+// Example:
+//
+//	valtra.Val(age).Validate(valtra.Min(18, valtra.WithMessage("Age must be 18 or over"), valtra.WithCode("age_min")))
+type Option func(*options)
+
+// options holds the configuration applied by Option values.
+type options struct {
+	message  string
+	code     string
+	severity Severity
+	autoFix  bool
+
+	// strictEmail and disposable configure Email.
+	strictEmail bool
+	disposable  func(domain string) bool
+
+	// comparator is the func(a, b T) int set by Comparator.
+	comparator any
+
+	// valueInMessage is set by WithValueInMessage, if
+	// hasValueInMessage is set.
+	valueInMessage    bool
+	hasValueInMessage bool
+}
+
+// Severity describes how serious a validation failure is.
+type Severity int
+
+const (
+	// SeverityError marks a failure that makes the value
+	// invalid. This is the default.
+	SeverityError Severity = iota
+	// SeverityWarning marks a failure that should be reported,
+	// but only as a warning.
+	SeverityWarning
+)
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "error"
+	}
+}
+
+// WithMessage sets a custom error message, which replaces the
+// default (and translated) message.
+//
+// The message can reference the value's name, the value
+// itself and the rule's parameters as placeholders, e.g.
+// "{name} must be at least {min}, got {value}".
+func WithMessage(message string) Option {
+	return func(o *options) {
+		o.message = message
+	}
+}
+
+// WithCode sets a custom error code, which replaces the
+// rule's default code (e.g. "min").
+//
+// The code is also used to look up translated messages,
+// falling back to the messages of the rule's default code.
+func WithCode(code string) Option {
+	return func(o *options) {
+		o.code = code
+	}
+}
+
+// WithSeverity sets the severity of the error. Default is
+// SeverityError.
+func WithSeverity(severity Severity) Option {
+	return func(o *options) {
+		o.severity = severity
+	}
+}
+
+// applyOptions returns the configuration described by the
+// given options.
+//
+// The configuration is only allocated if there are options,
+// as it escapes to them, keeping failures without options
+// cheap.
+func applyOptions(opts []Option) options {
+	if len(opts) == 0 {
+		return options{}
+	}
+
+	o := new(options)
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return *o
+}
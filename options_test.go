@@ -0,0 +1,58 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestOptions(t *testing.T) {
+	t.Run("WithCode overrides the error code", func(t *testing.T) {
+		v := valtra.Val(15, "age").Validate(valtra.Min(18, valtra.WithCode("age_min")))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) || ve.Code != "age_min" {
+			t.Fatalf("Expected age_min code, got %v", v.Errors()[0])
+		}
+		if ve.Error() != "age cannot be smaller than 18" {
+			t.Errorf("Expected default message for rule, got %q", ve.Error())
+		}
+	})
+
+	t.Run("WithSeverity sets the error severity", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Required[string](valtra.WithSeverity(valtra.SeverityWarning)))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) || ve.Severity != valtra.SeverityWarning {
+			t.Errorf("Expected warning severity, got %v", v.Errors()[0])
+		}
+	})
+
+	t.Run("severity defaults to error", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Required[string]())
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) || ve.Severity != valtra.SeverityError {
+			t.Errorf("Expected error severity, got %v", v.Errors()[0])
+		}
+	})
+
+	t.Run("options can be combined", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Required[string](
+			valtra.WithMessage("Name is required"),
+			valtra.WithCode("name_required"),
+		))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) || ve.Code != "name_required" || ve.Error() != "Name is required" {
+			t.Errorf("Expected custom code and message, got %+v", ve)
+		}
+	})
+}
+
+func TestSeverityString(t *testing.T) {
+	if valtra.SeverityError.String() != "error" || valtra.SeverityWarning.String() != "warning" {
+		t.Errorf("Unexpected severity names: %s, %s", valtra.SeverityError, valtra.SeverityWarning)
+	}
+}
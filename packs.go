@@ -0,0 +1,147 @@
+package valtra
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// RulePack is a set of rules, with their messages, provided by
+// a third-party package, such as a package of financial or
+// geographic rules. Packs are installed with Use.
+type RulePack interface {
+	// Name identifies the pack, e.g. "fintech".
+	Name() string
+	// Rules returns the pack's rules, which are registered
+	// under their names, as with Register.
+	Rules() []PackRule
+	// Messages returns the message templates for the codes
+	// the pack's rules report, keyed by locale, e.g. "fr",
+	// with "" for the default English templates.
+	Messages() map[string]Messages
+}
+
+// PackRule is a named rule of a RulePack. Exactly one of Rule
+// and Factory must be set.
+type PackRule struct {
+	// Name is the name the rule is registered under, and
+	// referred to by in struct tags, e.g. "iban".
+	Name string
+	// Rule is the validation, e.g. a func(Value[string]) error,
+	// for rules without parameters.
+	Rule any
+	// Factory creates the validation from its parameter, for
+	// rules that take one, e.g. "DE" in the tag rule "iban=DE".
+	Factory RuleFactory
+}
+
+// RuleFactory creates a validation, e.g. a
+// func(Value[string]) error, from the parameter of a tag rule,
+// returning an error if the parameter is invalid.
+type RuleFactory func(param string) (any, error)
+
+// Use installs a rule pack, so that its rules can be referred
+// to by name with Registered and in struct tags, and their
+// error codes are rendered with the pack's messages.
+//
+// Messages registered with RegisterLocale take precedence over
+// the pack's, and the built-in English templates over the
+// pack's English ones, so packs cannot change the messages of
+// built-in rules. Packs installed later replace the templates
+// of earlier packs for the same code.
+//
+// As with Register, Use is intended to be called from init
+// functions, and panics if a pack with the same name is
+// already installed, or if any of its rules is invalid or
+// already registered. On panic, none of the pack is installed.
+//
+// Example:
+//
+//	func init() {
+//	    valtra.Use(fintech.Pack())
+//	}
+//
+//	type Payment struct {
+//	    Account string `valtra:"required,iban=DE"`
+//	}
+func Use(pack RulePack) {
+	name := pack.Name()
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	if slices.ContainsFunc(registry.packs, func(p RulePack) bool { return p.Name() == name }) {
+		panic("valtra: Use called twice for pack " + name)
+	}
+
+	rules := maps.Clone(registeredRules())
+	if rules == nil {
+		rules = map[string]any{}
+	}
+	for _, r := range pack.Rules() {
+		if _, dup := rules[r.Name]; dup {
+			panic(fmt.Sprintf("valtra: pack %s registers rule %s, which is already registered", name, r.Name))
+		}
+
+		switch {
+		case r.Rule != nil && r.Factory == nil && isRule(r.Rule):
+			rules[r.Name] = r.Rule
+		case r.Rule == nil && r.Factory != nil:
+			rules[r.Name] = r.Factory
+		default:
+			panic(fmt.Sprintf("valtra: pack %s has an invalid rule %s", name, r.Name))
+		}
+	}
+
+	if messages := pack.Messages(); len(messages) > 0 {
+		updateLocales(func(state *localeState) {
+			state.packs = maps.Clone(state.packs)
+			if state.packs == nil {
+				state.packs = map[string]Messages{}
+			}
+			for locale, m := range messages {
+				merged := maps.Clone(state.packs[locale])
+				if merged == nil {
+					merged = Messages{}
+				}
+				maps.Copy(merged, m)
+				state.packs[locale] = merged
+			}
+		})
+	}
+
+	registry.rules.Store(&rules)
+	registry.packs = append(registry.packs, pack)
+}
+
+// Packs returns the installed rule packs, in the order they
+// were installed, e.g. for tooling that lists the available
+// rules.
+func Packs() []RulePack {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	return slices.Clone(registry.packs)
+}
+
+// isRule reports whether rule is a validation, that is a
+// func(Value[T]) error for some T.
+func isRule(rule any) bool {
+	t := reflect.TypeOf(rule)
+	if t.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 1 || t.Out(0) != errorType {
+		return false
+	}
+
+	in := t.In(0)
+	return in.PkgPath() == valueType.PkgPath() && strings.HasPrefix(in.Name(), "Value[")
+}
+
+var (
+	// errorType is the reflect.Type of the error interface.
+	errorType = reflect.TypeFor[error]()
+	// valueType is the reflect.Type of a Value, whose package
+	// path identifies other instantiations of Value.
+	valueType = reflect.TypeFor[Value[any]]()
+)
@@ -0,0 +1,132 @@
+package valtra_test
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// testPack is a rule pack as a third-party package would
+// provide it.
+type testPack struct {
+	name  string
+	rules []valtra.PackRule
+}
+
+func (p testPack) Name() string             { return p.name }
+func (p testPack) Rules() []valtra.PackRule { return p.rules }
+
+func (p testPack) Messages() map[string]valtra.Messages {
+	return map[string]valtra.Messages{
+		"":   {"test_even": "{name} must be even", "test_prefix": "{name} must start with {prefix}"},
+		"fr": {"test_even": "{name} doit être pair"},
+	}
+}
+
+func even(v valtra.Value[int]) error {
+	if v.Value()%2 != 0 {
+		return valtra.NewError(v, "test_even", nil)
+	}
+
+	return nil
+}
+
+func prefix(param string) (any, error) {
+	if param == "" {
+		return nil, errors.New("empty prefix")
+	}
+
+	return func(v valtra.Value[string]) error {
+		if !strings.HasPrefix(v.Value(), param) {
+			return valtra.NewError(v, "test_prefix", map[string]any{"prefix": param})
+		}
+
+		return nil
+	}, nil
+}
+
+func TestUse(t *testing.T) {
+	pack := testPack{name: "pack-test", rules: []valtra.PackRule{
+		{Name: "pack-even", Rule: even},
+		{Name: "pack-prefix", Factory: prefix},
+	}}
+	valtra.Use(pack)
+
+	t.Run("rules are registered", func(t *testing.T) {
+		v := valtra.Val(3, "count").Validate(valtra.Registered[int]("pack-even"))
+		if got := v.Err(); got == nil || got.Error() != "count must be even" {
+			t.Errorf("Expected the pack's message, got %v", got)
+		}
+	})
+
+	t.Run("rules apply in struct tags", func(t *testing.T) {
+		type order struct {
+			Count int    `json:"count" valtra:"pack-even"`
+			SKU   string `json:"sku" valtra:"pack-prefix=SKU-"`
+		}
+
+		if c := valtra.ValidateStruct(order{Count: 2, SKU: "SKU-1"}); !c.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", c.Errors())
+		}
+
+		c := valtra.ValidateStruct(order{Count: 2, SKU: "1"})
+		if got := c.Err(); got == nil || got.Error() != "sku must start with SKU-" {
+			t.Errorf("Expected the factory's rule to fail, got %v", got)
+		}
+	})
+
+	t.Run("invalid factory parameters fail", func(t *testing.T) {
+		type order struct {
+			SKU string `valtra:"pack-prefix="`
+		}
+
+		c := valtra.ValidateStruct(order{SKU: "SKU-1"})
+		if got := c.Err(); got == nil || !strings.Contains(got.Error(), "empty prefix") {
+			t.Errorf("Expected an invalid parameter error, got %v", got)
+		}
+	})
+
+	t.Run("messages are localised", func(t *testing.T) {
+		err := valtra.Val(3, "count").Validate(even).Err()
+		if got := valtra.Localize(err, "fr").Error(); got != "count doit être pair" {
+			t.Errorf("Expected the pack's French message, got %q", got)
+		}
+	})
+
+	t.Run("packs are listed", func(t *testing.T) {
+		if !slices.ContainsFunc(valtra.Packs(), func(p valtra.RulePack) bool { return p.Name() == "pack-test" }) {
+			t.Errorf("Expected the pack to be listed, got %v", valtra.Packs())
+		}
+	})
+
+	t.Run("invalid packs panic", func(t *testing.T) {
+		tests := []struct {
+			name string
+			pack testPack
+		}{
+			{"installed twice", pack},
+			{"rule already registered", testPack{name: "pack-dup", rules: []valtra.PackRule{{Name: "pack-even", Rule: even}}}},
+			{"not a validation", testPack{name: "pack-func", rules: []valtra.PackRule{{Name: "pack-func", Rule: strings.ToUpper}}}},
+			{"rule and factory", testPack{name: "pack-both", rules: []valtra.PackRule{{Name: "pack-both", Rule: even, Factory: prefix}}}},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				defer func() {
+					if recover() == nil {
+						t.Error("Expected Use to panic")
+					}
+				}()
+
+				valtra.Use(tt.pack)
+			})
+		}
+
+		if _, ok := valtra.Lookup[string]("pack-func"); ok {
+			t.Error("Expected no rule of a rejected pack to be registered")
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package valtra
+
+import (
+	"net/url"
+	"time"
+)
+
+// Params validates named parameters of a query string or form
+// post, converting them from strings as needed. Errors carry
+// the parameter's name and are gathered by the embedded
+// Collector.
+//
+// Params are created with FromQuery, and parameters are read
+// back with Param.
+type Params struct {
+	*Collector
+
+	values url.Values
+	parsed map[string]any
+}
+
+// FromQuery returns Params for the given values, such as
+// r.URL.Query() or (after parsing) r.PostForm.
+//
+// Missing or empty parameters are validated as their type's
+// zero value, so Required can be used to reject them.
+// Parameters that fail to convert skip their validations.
+//
+// Example:
+//
+//	p := valtra.FromQuery(r.URL.Query()).
+//	    Int("page", valtra.Min(1)).
+//	    String("q", valtra.MaxLengthString(100))
+//	if !p.IsValid() {
+//	    return p.Err()
+//	}
+//	page, q := valtra.Param[int](p, "page"), valtra.Param[string](p, "q")
+func FromQuery(values url.Values) *Params {
+	return &Params{
+		Collector: NewCollector(),
+		values:    values,
+		parsed:    map[string]any{},
+	}
+}
+
+// String validates the named parameter as a string.
+func (p *Params) String(name string, validations ...func(Value[string]) error) *Params {
+	return param(p, name, nil, validations)
+}
+
+// Strings validates all values of the named parameter, e.g.
+// "?tag=a&tag=b", as a slice of strings.
+func (p *Params) Strings(name string, validations ...func(Value[[]string]) error) *Params {
+	v := Val(p.values[name], name).Validate(validations...)
+	p.parsed[name] = v.Collect(p.Collector)
+	return p
+}
+
+// Int validates the named parameter as an integer, converted
+// with ParseInt.
+func (p *Params) Int(name string, validations ...func(Value[int]) error) *Params {
+	return param(p, name, ParseInt(), validations)
+}
+
+// Float validates the named parameter as a number, converted
+// with ParseFloat.
+func (p *Params) Float(name string, validations ...func(Value[float64]) error) *Params {
+	return param(p, name, ParseFloat(), validations)
+}
+
+// Bool validates the named parameter as a boolean, converted
+// with ParseBool.
+func (p *Params) Bool(name string, validations ...func(Value[bool]) error) *Params {
+	return param(p, name, ParseBool(), validations)
+}
+
+// Time validates the named parameter as a time in the given
+// layout, converted with ParseTime.
+func (p *Params) Time(name string, layout string, validations ...func(Value[time.Time]) error) *Params {
+	return param(p, name, ParseTime(layout), validations)
+}
+
+// param converts the named parameter with the given
+// conversion (none for strings), validates it, and records
+// the result.
+func param[T any](p *Params, name string, conversion func(Value[string]) (T, error), validations []func(Value[T]) error) *Params {
+	raw := Val(p.values.Get(name), name)
+
+	var v Value[T]
+	switch {
+	case conversion == nil:
+		v = any(raw).(Value[T])
+	case raw.value == "":
+		v = Val(*new(T), name)
+	default:
+		v = Convert(raw, conversion)
+	}
+
+	if v.IsValid() {
+		v = v.Validate(validations...)
+	}
+
+	p.parsed[name] = v.Collect(p.Collector)
+	return p
+}
+
+// Param returns the value of the named parameter, as
+// converted by Params. It returns T's zero value if the
+// parameter was not validated as a T.
+func Param[T any](p *Params, name string) T {
+	value, _ := p.parsed[name].(T)
+	return value
+}
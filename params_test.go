@@ -0,0 +1,72 @@
+package valtra_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestFromQuery(t *testing.T) {
+	query := func(s string) url.Values {
+		values, err := url.ParseQuery(s)
+		if err != nil {
+			t.Fatalf("Invalid query: %v", err)
+		}
+		return values
+	}
+
+	t.Run("valid parameters are converted", func(t *testing.T) {
+		p := valtra.FromQuery(query("page=2&q=shoes&price=9.5&sale=true&tag=a&tag=b&since=2025-01-02")).
+			Int("page", valtra.Min(1)).
+			String("q", valtra.MaxLengthString(100)).
+			Float("price", valtra.Positive[float64]()).
+			Bool("sale").
+			Strings("tag", valtra.MaxLengthSlice[string](5)).
+			Time("since", time.DateOnly)
+
+		if !p.IsValid() {
+			t.Fatalf("Expected parameters to be valid, got errors: %v", p.Errors())
+		}
+		if valtra.Param[int](p, "page") != 2 || valtra.Param[string](p, "q") != "shoes" ||
+			valtra.Param[float64](p, "price") != 9.5 || !valtra.Param[bool](p, "sale") ||
+			len(valtra.Param[[]string](p, "tag")) != 2 || valtra.Param[time.Time](p, "since").Day() != 2 {
+			t.Error("Unexpected parameter values")
+		}
+	})
+
+	t.Run("errors carry the parameter name", func(t *testing.T) {
+		p := valtra.FromQuery(query("page=0")).Int("page", valtra.Min(1))
+		if p.FirstError() == nil || p.FirstError().Error() != "page cannot be smaller than 1" {
+			t.Errorf("Unexpected errors: %v", p.Errors())
+		}
+	})
+
+	t.Run("conversion failures skip validations", func(t *testing.T) {
+		p := valtra.FromQuery(query("page=two")).Int("page", valtra.Min(1))
+		if len(p.Errors()) != 1 || p.Errors()[0].Error() != "page must be a whole number" {
+			t.Errorf("Unexpected errors: %v", p.Errors())
+		}
+	})
+
+	t.Run("missing parameters are zero values", func(t *testing.T) {
+		p := valtra.FromQuery(query("")).
+			Int("limit", valtra.Max(100)).
+			String("sort", valtra.Required[string]())
+
+		if len(p.Errors()) != 1 || len(p.ErrorsByField()["sort"]) != 1 {
+			t.Errorf("Expected only sort to fail, got %v", p.ErrorsByField())
+		}
+		if valtra.Param[int](p, "limit") != 0 {
+			t.Errorf("Expected zero limit, got %d", valtra.Param[int](p, "limit"))
+		}
+	})
+
+	t.Run("mismatched type returns zero value", func(t *testing.T) {
+		p := valtra.FromQuery(query("page=2")).Int("page")
+		if valtra.Param[string](p, "page") != "" {
+			t.Error("Expected zero value for mismatched type")
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package valtra
+
+import (
+	"errors"
+	"math"
+	"unicode"
+	"unicode/utf8"
+)
+
+// PasswordPolicy describes the requirements a password must
+// meet. Zero-valued fields disable the respective check.
+type PasswordPolicy struct {
+	// MinLength is the minimum number of characters.
+	MinLength int
+	// RequireUpper requires at least one uppercase letter.
+	RequireUpper bool
+	// RequireLower requires at least one lowercase letter.
+	RequireLower bool
+	// RequireDigit requires at least one digit.
+	RequireDigit bool
+	// RequireSymbol requires at least one character that is
+	// neither a letter, a digit nor whitespace.
+	RequireSymbol bool
+	// MaxRepeated is the maximum number of times a character
+	// may be repeated consecutively, e.g. 2 rejects "aaa".
+	MaxRepeated int
+	// MinEntropy is the minimum estimated entropy in bits.
+	MinEntropy float64
+}
+
+// Password returns a validation that ensures the value meets
+// the given password policy.
+//
+// Each unmet requirement produces its own error, so users can
+// be told everything that needs fixing at once. The errors are
+// joined into a single error, and each has its own code (e.g.
+// "password_upper"), so they can be matched with errors.As.
+//
+// Entropy is estimated from the password's length and the
+// character classes it uses, which rewards long passwords
+// over short, complex ones.
+//
+// Options such as WithMessage can be provided as the last
+// parameters, and apply to every error.
+//
+// Example:
+//
+//	valtra.Val(input.Password).Validate(valtra.Password(valtra.PasswordPolicy{
+//	    MinLength:    12,
+//	    RequireUpper: true,
+//	    RequireDigit: true,
+//	    MaxRepeated:  2,
+//	}))
+func Password(policy PasswordPolicy, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		var upper, lower, digit, symbol, other bool
+		repeated, run := 0, 0
+		var prev rune
+		for i, r := range []rune(v.value) {
+			switch {
+			case unicode.IsUpper(r):
+				upper = true
+			case unicode.IsLower(r):
+				lower = true
+			case unicode.IsDigit(r):
+				digit = true
+			case unicode.IsLetter(r):
+				// Letters without case, such as CJK characters,
+				// widen the pool but are not symbols.
+				other = true
+			case r < utf8.RuneSelf && !unicode.IsSpace(r):
+				symbol = true
+			case !unicode.IsSpace(r):
+				symbol, other = true, true
+			}
+
+			if i > 0 && r == prev {
+				run++
+			} else {
+				run = 1
+			}
+			repeated = max(repeated, run)
+			prev = r
+		}
+
+		var errs []error
+		length := utf8.RuneCountInString(v.value)
+		if length < policy.MinLength {
+			errs = append(errs, newError(v, "password_min_length", map[string]any{"min": policy.MinLength}, opts))
+		}
+		if policy.RequireUpper && !upper {
+			errs = append(errs, newError(v, "password_upper", nil, opts))
+		}
+		if policy.RequireLower && !lower {
+			errs = append(errs, newError(v, "password_lower", nil, opts))
+		}
+		if policy.RequireDigit && !digit {
+			errs = append(errs, newError(v, "password_digit", nil, opts))
+		}
+		if policy.RequireSymbol && !symbol {
+			errs = append(errs, newError(v, "password_symbol", nil, opts))
+		}
+		if policy.MaxRepeated > 0 && repeated > policy.MaxRepeated {
+			errs = append(errs, newError(v, "password_repeated", map[string]any{"max": policy.MaxRepeated}, opts))
+		}
+		if policy.MinEntropy > 0 && passwordEntropy(length, upper, lower, digit, symbol, other) < policy.MinEntropy {
+			errs = append(errs, newError(v, "password_entropy", map[string]any{"min": policy.MinEntropy}, opts))
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// passwordEntropy estimates the entropy in bits of a password
+// of the given length, drawn from the pool of character
+// classes it uses.
+func passwordEntropy(length int, upper, lower, digit, symbol, other bool) float64 {
+	pool := 0
+	if upper {
+		pool += 26
+	}
+	if lower {
+		pool += 26
+	}
+	if digit {
+		pool += 10
+	}
+	if symbol {
+		pool += 33
+	}
+	if other {
+		pool += 100
+	}
+	if pool == 0 {
+		return 0
+	}
+
+	return float64(length) * math.Log2(float64(pool))
+}
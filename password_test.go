@@ -0,0 +1,87 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestPassword(t *testing.T) {
+	policy := valtra.PasswordPolicy{
+		MinLength:     10,
+		RequireUpper:  true,
+		RequireLower:  true,
+		RequireDigit:  true,
+		RequireSymbol: true,
+		MaxRepeated:   2,
+	}
+
+	t.Run("strong password passes", func(t *testing.T) {
+		v := valtra.Val("C0rrect-Horse").Validate(valtra.Password(policy))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("each unmet requirement is reported", func(t *testing.T) {
+		v := valtra.Val("aaab").Validate(valtra.Password(policy))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail")
+		}
+
+		joined, ok := v.Errors()[0].(interface{ Unwrap() []error })
+		if !ok {
+			t.Fatalf("Expected joined errors, got %T", v.Errors()[0])
+		}
+
+		var codes []string
+		for _, err := range joined.Unwrap() {
+			var ve *valtra.ValidationError
+			if errors.As(err, &ve) {
+				codes = append(codes, ve.Code)
+			}
+		}
+
+		expected := []string{"password_min_length", "password_upper", "password_digit", "password_symbol", "password_repeated"}
+		if len(codes) != len(expected) {
+			t.Fatalf("Expected codes %v, got %v", expected, codes)
+		}
+		for i := range expected {
+			if codes[i] != expected[i] {
+				t.Errorf("Expected codes %v, got %v", expected, codes)
+				break
+			}
+		}
+	})
+
+	t.Run("letters without case are not symbols", func(t *testing.T) {
+		v := valtra.Val("密码密码密码密码A1a").Validate(valtra.Password(valtra.PasswordPolicy{RequireSymbol: true}))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Err(), &ve) || ve.Code != "password_symbol" {
+			t.Errorf("Expected a password_symbol error, got %v", v.Errors())
+		}
+	})
+
+	t.Run("entropy threshold", func(t *testing.T) {
+		rule := valtra.Password(valtra.PasswordPolicy{MinEntropy: 80})
+
+		v := valtra.Val("Tr0ub4dor&").Validate(rule)
+		if v.IsValid() {
+			t.Error("Expected validation to fail for short password")
+		}
+
+		v = valtra.Val("correct horse battery staple").Validate(rule)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass for long passphrase, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("zero policy accepts anything", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Password(valtra.PasswordPolicy{}))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
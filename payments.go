@@ -0,0 +1,278 @@
+package valtra
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CardBrand identifies the issuing network of a payment card.
+type CardBrand string
+
+// Card brands recognised by DetectCardBrand.
+const (
+	CardBrandUnknown    CardBrand = ""
+	CardBrandVisa       CardBrand = "visa"
+	CardBrandMastercard CardBrand = "mastercard"
+	CardBrandAmex       CardBrand = "amex"
+	CardBrandDiscover   CardBrand = "discover"
+	CardBrandDinersClub CardBrand = "diners_club"
+	CardBrandJCB        CardBrand = "jcb"
+	CardBrandUnionPay   CardBrand = "unionpay"
+)
+
+// cardBrands lists the number prefix ranges and lengths of
+// each card brand. Ranges are compared against the number's
+// leading digits, which must have the same number of digits
+// as the range bounds.
+var cardBrands = []struct {
+	brand     CardBrand
+	low, high string
+	lengths   []int
+}{
+	{CardBrandAmex, "34", "34", []int{15}},
+	{CardBrandAmex, "37", "37", []int{15}},
+	{CardBrandDinersClub, "300", "305", []int{14, 16, 17, 18, 19}},
+	{CardBrandDinersClub, "36", "36", []int{14, 16, 17, 18, 19}},
+	{CardBrandDinersClub, "38", "39", []int{14, 16, 17, 18, 19}},
+	{CardBrandJCB, "3528", "3589", []int{16, 17, 18, 19}},
+	{CardBrandVisa, "4", "4", []int{13, 16, 19}},
+	{CardBrandMastercard, "51", "55", []int{16}},
+	{CardBrandMastercard, "2221", "2720", []int{16}},
+	{CardBrandDiscover, "6011", "6011", []int{16, 17, 18, 19}},
+	{CardBrandDiscover, "644", "649", []int{16, 17, 18, 19}},
+	{CardBrandDiscover, "65", "65", []int{16, 17, 18, 19}},
+	{CardBrandUnionPay, "62", "62", []int{16, 17, 18, 19}},
+}
+
+// DetectCardBrand returns the brand of the given card number,
+// or CardBrandUnknown if it is not recognised. Spaces and
+// dashes in the number are ignored.
+func DetectCardBrand(number string) CardBrand {
+	number = stripCardSeparators(number)
+	for _, b := range cardBrands {
+		if len(number) < len(b.low) || !slices.Contains(b.lengths, len(number)) {
+			continue
+		}
+
+		prefix := number[:len(b.low)]
+		if prefix >= b.low && prefix <= b.high {
+			return b.brand
+		}
+	}
+
+	return CardBrandUnknown
+}
+
+// Luhn returns a validation that ensures the value is a
+// string of digits with a valid Luhn (mod 10) check digit.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("79927398713").Validate(valtra.Luhn())
+func Luhn(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !luhnValid(v.value) {
+			return newError(v, "luhn", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// CreditCard returns a validation that ensures the value is a
+// payment card number: 12 to 19 digits with a valid Luhn
+// check digit. Spaces and dashes between digits are allowed.
+//
+// To restrict the accepted card brands, use
+// CreditCardBrands.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("4111 1111 1111 1111").Validate(valtra.CreditCard())
+func CreditCard(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		number := stripCardSeparators(v.value)
+		if len(number) < 12 || len(number) > 19 || !luhnValid(number) {
+			return newError(v, "credit_card", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// CreditCardBrands returns a validation that ensures the
+// value is a payment card number (see CreditCard) issued by
+// one of the given brands. An empty list of brands is an
+// invalid rule (see ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Card).Validate(
+//	    valtra.CreditCardBrands([]valtra.CardBrand{valtra.CardBrandVisa, valtra.CardBrandMastercard}),
+//	)
+func CreditCardBrands(brands []CardBrand, opts ...Option) func(Value[string]) error {
+	if len(brands) == 0 {
+		return invalidRule[string]("CreditCardBrands has no brands")
+	}
+
+	return func(v Value[string]) error {
+		number := stripCardSeparators(v.value)
+		if !luhnValid(number) || !slices.Contains(brands, DetectCardBrand(number)) {
+			return newError(v, "credit_card_brand", map[string]any{"brands": brands}, opts)
+		}
+
+		return nil
+	}
+}
+
+// ibanLengths holds the IBAN length of each country that
+// uses IBANs, keyed by ISO 3166-1 alpha-2 country code.
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16,
+	"BG": 22, "BH": 22, "BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28,
+	"CZ": 24, "DE": 22, "DK": 18, "DO": 28, "EE": 20, "EG": 29, "ES": 24,
+	"FI": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23, "GL": 18,
+	"GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23,
+	"IS": 26, "IT": 27, "JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32,
+	"LI": 21, "LT": 20, "LU": 20, "LV": 21, "MC": 27, "MD": 24, "ME": 22,
+	"MK": 19, "MR": 27, "MT": 31, "MU": 30, "NL": 18, "NO": 15, "PK": 24,
+	"PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "SA": 24,
+	"SC": 31, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20,
+}
+
+// TokenVault stores payment card numbers (PANs) on behalf of
+// the application, such as a payment provider's vault, and
+// hands out tokens that stand in for them.
+type TokenVault interface {
+	// Tokenize stores the card number, given as digits only,
+	// and returns its token.
+	Tokenize(number string) (token string, err error)
+}
+
+// Tokenize returns a transformation that swaps the card
+// number for a token from the vault, so that raw card numbers
+// never leave the validation layer, reducing the systems in
+// PCI DSS scope.
+//
+// Spaces and dashes are removed before the number is sent to
+// the vault. Validate the number first, in strict mode (see
+// Strict), so that invalid numbers are not sent.
+//
+// Errors from the vault are returned wrapped with context. As
+// with any failed transformation, the value then still holds
+// the raw card number, so it must be discarded unless the
+// value is valid.
+//
+// Example:
+//
+//	card := valtra.Val(input.Card, "card").Strict().
+//	    Validate(valtra.CreditCard()).
+//	    Transform(valtra.Tokenize(vault))
+func Tokenize(vault TokenVault) func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		token, err := vault.Tokenize(stripCardSeparators(v.value))
+		if err != nil {
+			return "", fmt.Errorf("valtra: cannot tokenize %s: %w", v.name, err)
+		}
+
+		return token, nil
+	}
+}
+
+// IBAN returns a validation that ensures the value is an
+// International Bank Account Number with the correct length
+// for its country and a valid mod 97 checksum.
+//
+// Letters may be in either case, and spaces are allowed, so
+// IBANs in their printed form (e.g. "GB82 WEST 1234 5698
+// 7654 32") pass.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("GB82WEST12345698765432").Validate(valtra.IBAN())
+func IBAN(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !ibanValid(v.value) {
+			return newError(v, "iban", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// ibanValid reports whether s is a valid IBAN.
+func ibanValid(s string) bool {
+	iban := strings.ToUpper(strings.ReplaceAll(s, " ", ""))
+	if len(iban) < 4 || len(iban) != ibanLengths[iban[:2]] {
+		return false
+	}
+	if _, err := strconv.Atoi(iban[2:4]); err != nil {
+		return false
+	}
+
+	// Move the country code and check digits to the end, and
+	// compute the remainder digit by digit, with letters
+	// expanded to two digits (A = 10, ..., Z = 35)
+	remainder := 0
+	for _, r := range iban[4:] + iban[:4] {
+		switch {
+		case r >= '0' && r <= '9':
+			remainder = (remainder*10 + int(r-'0')) % 97
+		case r >= 'A' && r <= 'Z':
+			remainder = (remainder*100 + int(r-'A'+10)) % 97
+		default:
+			return false
+		}
+	}
+
+	return remainder == 1
+}
+
+// luhnValid reports whether s is a non-empty string of
+// digits with a valid Luhn check digit.
+func luhnValid(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	sum := 0
+	double := false
+	for i := len(s) - 1; i >= 0; i-- {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+
+		d := int(s[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
+
+// stripCardSeparators removes the spaces and dashes commonly
+// used to group the digits of card numbers.
+func stripCardSeparators(number string) string {
+	return strings.NewReplacer(" ", "", "-", "").Replace(number)
+}
@@ -0,0 +1,178 @@
+package valtra_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestLuhn(t *testing.T) {
+	t.Run("valid check digit passes", func(t *testing.T) {
+		v := valtra.Val("79927398713").Validate(valtra.Luhn())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("invalid check digit fails", func(t *testing.T) {
+		v := valtra.Val("79927398710").Validate(valtra.Luhn())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid check digit")
+		}
+	})
+
+	t.Run("non-digits fail", func(t *testing.T) {
+		v := valtra.Val("7992739871a").Validate(valtra.Luhn())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-digits")
+		}
+	})
+}
+
+func TestCreditCard(t *testing.T) {
+	t.Run("valid card number passes", func(t *testing.T) {
+		v := valtra.Val("4111 1111 1111 1111").Validate(valtra.CreditCard())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("invalid check digit fails", func(t *testing.T) {
+		v := valtra.Val("4111-1111-1111-1112").Validate(valtra.CreditCard())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid check digit")
+		}
+	})
+
+	t.Run("too short fails", func(t *testing.T) {
+		v := valtra.Val("79927398713").Validate(valtra.CreditCard())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for too short number")
+		}
+	})
+}
+
+func TestCreditCardBrands(t *testing.T) {
+	allowed := []valtra.CardBrand{valtra.CardBrandVisa, valtra.CardBrandMastercard}
+
+	t.Run("allowed brand passes", func(t *testing.T) {
+		v := valtra.Val("5555 5555 5555 4444").Validate(valtra.CreditCardBrands(allowed))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("other brand fails", func(t *testing.T) {
+		v := valtra.Val("3782 822463 10005", "card").Validate(valtra.CreditCardBrands(allowed))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail for other brand")
+		}
+		expected := "card must be a valid card number from one of: visa, mastercard"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestDetectCardBrand(t *testing.T) {
+	tests := map[string]valtra.CardBrand{
+		"4111111111111111": valtra.CardBrandVisa,
+		"5555555555554444": valtra.CardBrandMastercard,
+		"2223003122003222": valtra.CardBrandMastercard,
+		"378282246310005":  valtra.CardBrandAmex,
+		"6011111111111117": valtra.CardBrandDiscover,
+		"30569309025904":   valtra.CardBrandDinersClub,
+		"3530111333300000": valtra.CardBrandJCB,
+		"6200000000000005": valtra.CardBrandUnionPay,
+		"9999999999999995": valtra.CardBrandUnknown,
+	}
+
+	for number, expected := range tests {
+		t.Run(number, func(t *testing.T) {
+			if brand := valtra.DetectCardBrand(number); brand != expected {
+				t.Errorf("Expected %q, got %q", expected, brand)
+			}
+		})
+	}
+}
+
+func TestIBAN(t *testing.T) {
+	t.Run("valid IBAN passes", func(t *testing.T) {
+		v := valtra.Val("GB82WEST12345698765432").Validate(valtra.IBAN())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("printed form passes", func(t *testing.T) {
+		v := valtra.Val("de89 3704 0044 0532 0130 00").Validate(valtra.IBAN())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("invalid checksum fails", func(t *testing.T) {
+		v := valtra.Val("GB82WEST12345698765431").Validate(valtra.IBAN())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid checksum")
+		}
+	})
+
+	t.Run("wrong length for country fails", func(t *testing.T) {
+		v := valtra.Val("GB82WEST1234569876543").Validate(valtra.IBAN())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for wrong length")
+		}
+	})
+}
+
+// fakeVault hands out sequential tokens for card numbers.
+type fakeVault struct {
+	numbers []string
+	err     error
+}
+
+func (v *fakeVault) Tokenize(number string) (string, error) {
+	if v.err != nil {
+		return "", v.err
+	}
+	v.numbers = append(v.numbers, number)
+
+	return fmt.Sprintf("tok_%d", len(v.numbers)), nil
+}
+
+func TestTokenize(t *testing.T) {
+	t.Run("card numbers are swapped for tokens", func(t *testing.T) {
+		vault := &fakeVault{}
+		v := valtra.Val("4111 1111-1111 1111", "card").Strict().
+			Validate(valtra.CreditCard()).
+			Transform(valtra.Tokenize(vault))
+
+		if !v.IsValid() || v.Value() != "tok_1" {
+			t.Errorf("Expected %q, got %q (%v)", "tok_1", v.Value(), v.Errors())
+		}
+		if len(vault.numbers) != 1 || vault.numbers[0] != "4111111111111111" {
+			t.Errorf("Expected the digits to be stored, got %v", vault.numbers)
+		}
+	})
+
+	t.Run("invalid numbers are not sent in strict mode", func(t *testing.T) {
+		vault := &fakeVault{}
+		valtra.Val("1234", "card").Strict().Validate(valtra.CreditCard()).Transform(valtra.Tokenize(vault))
+
+		if len(vault.numbers) != 0 {
+			t.Errorf("Expected nothing to be stored, got %v", vault.numbers)
+		}
+	})
+
+	t.Run("vault errors are returned", func(t *testing.T) {
+		unavailable := errors.New("vault unavailable")
+		v := valtra.Val("4111111111111111", "card").Transform(valtra.Tokenize(&fakeVault{err: unavailable}))
+
+		if v.IsValid() || !errors.Is(v.Errors()[0], unavailable) {
+			t.Errorf("Expected the vault error, got %v", v.Errors())
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package valtra
+
+// Phase orders the validations of a schema, so that cheap
+// checks of an input's form can rule out junk before costly
+// checks of its meaning, such as lookups in a database or
+// calls to other services, are made.
+type Phase int
+
+const (
+	// PhaseSyntax holds structural checks, such as Required,
+	// Email or MaxLengthString, and all transformations. It
+	// is the phase of steps added with Validate and Transform.
+	PhaseSyntax Phase = iota
+	// PhaseSemantic holds checks of meaning, which are only
+	// applied if the syntax phase passed.
+	PhaseSemantic
+)
+
+// InPhase returns a new schema that applies the provided
+// validation functions in the given phase.
+//
+// All steps of the syntax phase are applied first, in order,
+// whatever phase the steps added before them are in. Steps of
+// the semantic phase are applied after them, in order, only if
+// the syntax phase added no errors.
+//
+// Example:
+//
+//	var emailSchema = valtra.NewSchema[string]().
+//	    Validate(valtra.Required[string](), valtra.Email()).
+//	    InPhase(valtra.PhaseSemantic, notBlocked) // looks the address up in a database
+func (s Schema[T]) InPhase(phase Phase, validations ...func(Value[T]) error) Schema[T] {
+	steps := len(s.steps)
+	s = s.Validate(validations...)
+	for i := steps; i < len(s.steps); i++ {
+		s.steps[i].phase = phase
+	}
+
+	return s
+}
+
+// inPhases applies the schema's steps to the value with
+// apply, phase by phase, skipping the semantic phase if the
+// syntax phase added errors.
+func (s Schema[T]) inPhases(v Value[T], apply func(Value[T], step[T]) Value[T]) Value[T] {
+	errs := len(v.errs)
+	for phase := PhaseSyntax; phase <= PhaseSemantic; phase++ {
+		if phase > PhaseSyntax && len(v.errs) > errs {
+			break
+		}
+
+		for _, st := range s.steps {
+			if st.phase == phase {
+				v = apply(v, st)
+			}
+		}
+	}
+
+	return v
+}
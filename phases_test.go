@@ -0,0 +1,63 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestInPhase(t *testing.T) {
+	var lookups int
+	notTaken := func(v valtra.Value[string]) error {
+		lookups++
+		if v.Value() == "admin@example.com" {
+			return errors.New(v.Name() + " is taken")
+		}
+
+		return nil
+	}
+
+	schema := valtra.NewSchema[string]().
+		InPhase(valtra.PhaseSemantic, notTaken).
+		Transform(valtra.TrimSpace()).
+		Validate(valtra.Required[string](), valtra.Email())
+
+	t.Run("semantic rules skipped when syntax fails", func(t *testing.T) {
+		lookups = 0
+		if _, err := schema.Run("not an email"); err == nil {
+			t.Error("Expected validation to fail")
+		}
+		if lookups != 0 {
+			t.Errorf("Expected no lookups, got %d", lookups)
+		}
+	})
+
+	t.Run("semantic rules run after syntax passes", func(t *testing.T) {
+		lookups = 0
+		if _, err := schema.Run(" admin@example.com "); err == nil {
+			t.Error("Expected validation to fail for a taken address")
+		}
+		if lookups != 1 {
+			t.Errorf("Expected 1 lookup of the trimmed address, got %d", lookups)
+		}
+	})
+
+	t.Run("check only respects phases", func(t *testing.T) {
+		lookups = 0
+		if err := schema.CheckOnly(""); err == nil {
+			t.Error("Expected validation to fail")
+		}
+		if lookups != 0 {
+			t.Errorf("Expected no lookups, got %d", lookups)
+		}
+	})
+
+	t.Run("earlier errors do not skip the semantic phase", func(t *testing.T) {
+		lookups = 0
+		v := valtra.Val("bobby@example.com").Validate(valtra.MaxLengthString(3)).Apply(schema)
+		if len(v.Errors()) != 1 || lookups != 1 {
+			t.Errorf("Expected the semantic phase to run, got %d lookups and errors: %v", lookups, v.Errors())
+		}
+	})
+}
@@ -0,0 +1,232 @@
+package valtra
+
+import (
+	"regexp"
+	"strings"
+)
+
+// e164Regex matches phone numbers in E.164 format: a plus
+// sign followed by up to 15 digits, the first of which
+// cannot be zero.
+var e164Regex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+// phoneRegion describes the numbering plan of a region: its
+// country calling code, the allowed lengths of the national
+// number that follows it, and the trunk prefix dialled before
+// the national number within the region (if any).
+type phoneRegion struct {
+	code           string
+	minLen, maxLen int
+	trunk          string
+}
+
+// phoneRegions holds the numbering plans supported by
+// PhoneNumberForRegion and NationalPhoneNumber, keyed by ISO
+// 3166-1 alpha-2 region code.
+var phoneRegions = map[string]phoneRegion{
+	"AT": {"43", 4, 13, "0"},
+	"AU": {"61", 9, 9, "0"},
+	"BE": {"32", 8, 9, "0"},
+	"BG": {"359", 8, 9, "0"},
+	"BR": {"55", 10, 11, "0"},
+	"CA": {"1", 10, 10, ""},
+	"CH": {"41", 9, 9, "0"},
+	"CN": {"86", 11, 11, "0"},
+	"CZ": {"420", 9, 9, ""},
+	"DE": {"49", 6, 13, "0"},
+	"DK": {"45", 8, 8, ""},
+	"ES": {"34", 9, 9, ""},
+	"FI": {"358", 5, 12, "0"},
+	"FR": {"33", 9, 9, "0"},
+	"GB": {"44", 9, 10, "0"},
+	"GR": {"30", 10, 10, ""},
+	"IE": {"353", 7, 9, "0"},
+	"IN": {"91", 10, 10, "0"},
+	"IT": {"39", 6, 11, ""},
+	"JP": {"81", 9, 10, "0"},
+	"MX": {"52", 10, 10, ""},
+	"NL": {"31", 9, 9, "0"},
+	"NO": {"47", 8, 8, ""},
+	"NZ": {"64", 8, 10, "0"},
+	"PL": {"48", 9, 9, ""},
+	"PT": {"351", 9, 9, ""},
+	"RO": {"40", 9, 9, "0"},
+	"SE": {"46", 7, 13, "0"},
+	"US": {"1", 10, 10, ""},
+	"ZA": {"27", 9, 9, "0"},
+}
+
+// canadianAreaCodes holds the area codes of Canada, which
+// shares the country calling code 1 with the United States and
+// others in the North American Numbering Plan.
+var canadianAreaCodes = codeSet(`
+	204 226 236 249 250 257 263 289 306 343 354 365 367 368 382 387 403 416 418 428 431 437 438 450 460 468
+	474 506 514 519 548 579 581 584 587 600 604 613 622 639 647 672 683 705 709 742 753 778 780 782 807 819
+	825 867 873 879 902 905 942
+`)
+
+// caribbeanAreaCodes holds the area codes of the other
+// countries of the North American Numbering Plan, outside the
+// United States and its territories.
+var caribbeanAreaCodes = codeSet(`
+	242 246 264 268 284 345 441 473 649 658 664 721 758 767 784 809 829 849 868 869 876
+`)
+
+// nanpRegion returns the region of a national number of the
+// North American Numbering Plan by its area code: "CA" for
+// Canada, "US" for the United States and its territories, or
+// "" for other countries and numbers that are not valid in the
+// plan, whose area code and exchange cannot start with 0 or 1.
+func nanpRegion(national string) string {
+	if len(national) != 10 || national[0] < '2' || national[3] < '2' {
+		return ""
+	}
+
+	area := national[:3]
+	if _, ok := canadianAreaCodes[area]; ok {
+		return "CA"
+	}
+	if _, ok := caribbeanAreaCodes[area]; ok {
+		return ""
+	}
+
+	return "US"
+}
+
+// inPhoneRegion reports whether the national number, which
+// follows the region's calling code, has a valid length for
+// the region, and belongs to it rather than another region
+// with the same calling code, as with the United States and
+// Canada.
+func inPhoneRegion(region string, plan phoneRegion, national string) bool {
+	if len(national) < plan.minLen || len(national) > plan.maxLen {
+		return false
+	}
+
+	return plan.code != "1" || nanpRegion(national) == region
+}
+
+// PhoneNumber returns a validation that ensures the value is
+// a phone number in E.164 format, e.g. "+442071838750".
+//
+// Separators such as spaces or dashes are not allowed, so
+// inputs should be normalised before validation.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("+442071838750").Validate(valtra.PhoneNumber())
+func PhoneNumber(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !e164Regex.MatchString(v.value) {
+			return newError(v, "phone_number", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// PhoneNumberForRegion returns a validation that ensures the
+// value is a phone number in E.164 format belonging to the
+// given region (an ISO 3166-1 alpha-2 code such as "GB").
+//
+// The number must start with the region's country calling
+// code, and its national number must have a valid length for
+// the region. Where regions share a calling code, as the
+// United States and Canada do, the number's area code must be
+// one of the region's. Unsupported regions make it an invalid
+// rule (see ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("+442071838750").Validate(valtra.PhoneNumberForRegion("GB"))
+func PhoneNumberForRegion(region string, opts ...Option) func(Value[string]) error {
+	code := strings.ToUpper(region)
+	plan, ok := phoneRegions[code]
+	if !ok {
+		return invalidRule[string]("PhoneNumberForRegion has an unsupported region %q", region)
+	}
+
+	return func(v Value[string]) error {
+		if !e164Regex.MatchString(v.value) {
+			return newError(v, "phone_number_region", map[string]any{"region": region}, opts)
+		}
+
+		national, found := strings.CutPrefix(v.value[1:], plan.code)
+		if !found || !inPhoneRegion(code, plan, national) {
+			return newError(v, "phone_number_region", map[string]any{"region": region}, opts)
+		}
+
+		return nil
+	}
+}
+
+// phoneSeparators removes the separators people type in
+// phone numbers.
+var phoneSeparators = strings.NewReplacer(" ", "", "-", "", ".", "", "(", "", ")", "", "/", "")
+
+// NationalPhoneNumber returns a reversible transformation
+// between the phone number formats used within the given
+// region (an ISO 3166-1 alpha-2 code such as "GB") and E.164.
+//
+// Normalize removes separators such as spaces and dashes, and
+// turns a national number (e.g. "020 7183 8750") into E.164
+// ("+442071838750") by dropping the region's trunk prefix and
+// adding its country calling code. International numbers
+// starting with "+" or "00" are kept in E.164 as well. Values
+// that are not phone numbers are left for PhoneNumber to
+// reject.
+//
+// Denormalize turns E.164 numbers of the region back into the
+// national format, without separators ("02071838750"), and
+// leaves numbers of other regions, including those sharing its
+// calling code, in E.164.
+//
+// Example:
+//
+//	var phoneSchema = valtra.NewSchema[string]().
+//	    TransformReversible(valtra.NationalPhoneNumber("GB")).
+//	    Validate(valtra.PhoneNumberForRegion("GB"))
+//
+//	stored, err := phoneSchema.Run(input.Phone, "phone") // "+442071838750"
+//	display, _ := phoneSchema.Denormalize(stored)        // "02071838750"
+func NationalPhoneNumber(region string) Reversible[string] {
+	code := strings.ToUpper(region)
+	plan, ok := phoneRegions[code]
+
+	return Reversible[string]{
+		Normalize: func(v Value[string]) (string, error) {
+			number := phoneSeparators.Replace(strings.TrimSpace(v.value))
+			if international, found := strings.CutPrefix(number, "00"); found {
+				number = "+" + international
+			}
+
+			digits := strings.TrimPrefix(number, "+")
+			if digits == "" || strings.Trim(digits, "0123456789") != "" {
+				return v.value, nil
+			}
+			if !ok || strings.HasPrefix(number, "+") {
+				return number, nil
+			}
+
+			return "+" + plan.code + strings.TrimPrefix(digits, plan.trunk), nil
+		},
+		Denormalize: func(v Value[string]) (string, error) {
+			if !ok || !e164Regex.MatchString(v.value) {
+				return v.value, nil
+			}
+
+			national, found := strings.CutPrefix(v.value[1:], plan.code)
+			if !found || !inPhoneRegion(code, plan, national) {
+				return v.value, nil
+			}
+
+			return plan.trunk + national, nil
+		},
+	}
+}
@@ -0,0 +1,165 @@
+package valtra_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestPhoneNumber(t *testing.T) {
+	t.Run("E.164 number passes", func(t *testing.T) {
+		v := valtra.Val("+442071838750").Validate(valtra.PhoneNumber())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("missing plus sign fails", func(t *testing.T) {
+		v := valtra.Val("442071838750").Validate(valtra.PhoneNumber())
+		if v.IsValid() {
+			t.Error("Expected validation to fail without plus sign")
+		}
+	})
+
+	t.Run("separators fail", func(t *testing.T) {
+		v := valtra.Val("+44 20 7183 8750").Validate(valtra.PhoneNumber())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for separators")
+		}
+	})
+
+	t.Run("too many digits fail", func(t *testing.T) {
+		v := valtra.Val("+1234567890123456").Validate(valtra.PhoneNumber())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for too many digits")
+		}
+	})
+}
+
+func TestPhoneNumberForRegion(t *testing.T) {
+	t.Run("number for region passes", func(t *testing.T) {
+		v := valtra.Val("+442071838750").Validate(valtra.PhoneNumberForRegion("gb"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("number for another region fails", func(t *testing.T) {
+		v := valtra.Val("+12025550123").Validate(valtra.PhoneNumberForRegion("GB"))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for another region")
+		}
+	})
+
+	t.Run("numbers of each region pass", func(t *testing.T) {
+		numbers := map[string]string{
+			"AT": "+431234567", "AU": "+61212345678", "BE": "+3221234567", "BG": "+35921234567",
+			"BR": "+551123456789", "CA": "+14165550123", "CH": "+41441234567", "CN": "+8613812345678",
+			"CZ": "+420601123456", "DE": "+4930123456", "DK": "+4532123456", "ES": "+34912345678",
+			"FI": "+358912345", "FR": "+33612345678", "GB": "+442071838750", "GR": "+302101234567",
+			"IE": "+35312345678", "IN": "+919812345678", "IT": "+390612345678", "JP": "+81312345678",
+			"MX": "+525512345678", "NL": "+31201234567", "NO": "+4722123456", "NZ": "+6491234567",
+			"PL": "+48221234567", "PT": "+351211234567", "RO": "+40211234567", "SE": "+4681234567",
+			"US": "+12025550143", "ZA": "+27211234567",
+		}
+
+		for region, number := range numbers {
+			t.Run(region, func(t *testing.T) {
+				if v := valtra.Val(number).Validate(valtra.PhoneNumberForRegion(region)); !v.IsValid() {
+					t.Errorf("Expected %s to pass for %s, got errors: %v", number, region, v.Errors())
+				}
+			})
+		}
+	})
+
+	t.Run("regions sharing a calling code are told apart", func(t *testing.T) {
+		tests := []struct {
+			number, region string
+			valid          bool
+		}{
+			{"+14165550123", "US", false},
+			{"+12025550143", "CA", false},
+			{"+16045550123", "CA", true},
+			{"+18765550123", "US", false},
+			{"+18765550123", "CA", false},
+			{"+11025550143", "US", false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.number+" "+tt.region, func(t *testing.T) {
+				v := valtra.Val(tt.number).Validate(valtra.PhoneNumberForRegion(tt.region))
+				if v.IsValid() != tt.valid {
+					t.Errorf("Expected valid to be %v, got errors: %v", tt.valid, v.Errors())
+				}
+			})
+		}
+	})
+
+	t.Run("wrong length for region fails", func(t *testing.T) {
+		v := valtra.Val("+1202555012").Validate(valtra.PhoneNumberForRegion("US"))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for wrong length")
+		}
+	})
+}
+
+func TestNationalPhoneNumber(t *testing.T) {
+	t.Run("numbers are normalised to E.164", func(t *testing.T) {
+		tests := []struct {
+			region, input, want string
+		}{
+			{"GB", "020 7183 8750", "+442071838750"},
+			{"GB", "(020) 7183-8750", "+442071838750"},
+			{"gb", "020 7183 8750", "+442071838750"},
+			{"GB", "+44 20 7183 8750", "+442071838750"},
+			{"GB", "0044 20 7183 8750", "+442071838750"},
+			{"US", "(202) 555-0143", "+12025550143"},
+			{"GB", "not a number", "not a number"},
+			{"XX", "020 7183 8750", "02071838750"},
+		}
+
+		for _, tt := range tests {
+			v := valtra.Val(tt.input).Transform(valtra.NationalPhoneNumber(tt.region).Normalize)
+			if v.Value() != tt.want {
+				t.Errorf("Expected %q for %q in %s, got %q", tt.want, tt.input, tt.region, v.Value())
+			}
+		}
+	})
+
+	t.Run("numbers of the region are denormalised", func(t *testing.T) {
+		tests := []struct {
+			region, input, want string
+		}{
+			{"GB", "+442071838750", "02071838750"},
+			{"US", "+12025550143", "2025550143"},
+			{"CA", "+14165550123", "4165550123"},
+			{"US", "+14165550123", "+14165550123"},
+			{"CA", "+12025550143", "+12025550143"},
+			{"GB", "+12025550143", "+12025550143"},
+			{"XX", "+442071838750", "+442071838750"},
+		}
+
+		for _, tt := range tests {
+			v := valtra.Val(tt.input).Transform(valtra.NationalPhoneNumber(tt.region).Denormalize)
+			if v.Value() != tt.want {
+				t.Errorf("Expected %q for %q in %s, got %q", tt.want, tt.input, tt.region, v.Value())
+			}
+		}
+	})
+
+	t.Run("schemas round-trip numbers", func(t *testing.T) {
+		schema := valtra.NewSchema[string]().
+			TransformReversible(valtra.NationalPhoneNumber("GB")).
+			Validate(valtra.PhoneNumberForRegion("GB"))
+
+		stored, err := schema.Run("020 7183 8750", "phone")
+		if err != nil || stored != "+442071838750" {
+			t.Fatalf("Expected %q, got %q (%v)", "+442071838750", stored, err)
+		}
+
+		display, err := schema.Denormalize(stored)
+		if err != nil || display != "02071838750" {
+			t.Errorf("Expected %q, got %q (%v)", "02071838750", display, err)
+		}
+	})
+}
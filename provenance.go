@@ -0,0 +1,121 @@
+package valtra
+
+import (
+	"maps"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// Provenance records where a collected value came from and
+// how it was changed on the way, for data lineage reporting.
+type Provenance struct {
+	// Source is the place the value was read from, as given to
+	// Value.From, e.g. "query:page" or "body.email".
+	Source string
+	// Steps names the transformations and conversions applied
+	// to the value, in order, e.g. ["TrimSpace", "Lowercase"].
+	Steps []string
+}
+
+// provenance tracks the provenance of a value. Steps are the
+// transformation and conversion functions applied, named only
+// when the value is collected.
+type provenance struct {
+	source string
+	steps  []any
+}
+
+// then returns the provenance with the given step added. The
+// steps are copied, so copies of a value keep their own.
+func (p *provenance) then(step any) *provenance {
+	if p == nil {
+		return nil
+	}
+
+	return &provenance{source: p.source, steps: append(slices.Clip(p.steps), step)}
+}
+
+// resolve returns the provenance with its steps named.
+func (p *provenance) resolve() Provenance {
+	steps := make([]string, len(p.steps))
+	for i, step := range p.steps {
+		steps[i] = funcName(step)
+	}
+
+	return Provenance{Source: p.source, Steps: steps}
+}
+
+// funcName returns the name of the function, without its
+// package or the suffixes of closures and type arguments, e.g.
+// "TrimSpace" for the transformation TrimSpace returns.
+func funcName(fn any) string {
+	f := runtime.FuncForPC(reflect.ValueOf(fn).Pointer())
+	if f == nil {
+		return ""
+	}
+
+	name := f.Name()
+	name = name[strings.LastIndexByte(name, '/')+1:]
+	name = name[strings.IndexByte(name, '.')+1:]
+	name = strings.ReplaceAll(name, "[...]", "")
+	name = strings.TrimSuffix(name, "-fm")
+	for {
+		i := strings.LastIndexByte(name, '.')
+		suffix := strings.TrimPrefix(name[i+1:], "func")
+		if i <= 0 || suffix == "" || strings.Trim(suffix, "0123456789") != "" {
+			break
+		}
+		name = name[:i]
+	}
+
+	return name
+}
+
+// From returns a copy of the value recording the place it was
+// read from, such as "query:page" or "body.email", and the
+// transformations and conversions applied to it from here on.
+//
+// When the value is collected, its provenance is available
+// from Collector.Provenance. Transformations are named after
+// the functions that create them, e.g. "TrimSpace", or that
+// they are, for named functions. Without From, nothing is
+// recorded.
+//
+// Example:
+//
+//	email := valtra.Val(r.FormValue("email"), "email").From("form:email").
+//	    Transform(valtra.TrimSpace(), valtra.Lowercase()).
+//	    Collect(c)
+//	c.Provenance()["email"] // {form:email [TrimSpace Lowercase]}
+func (v Value[T]) From(source string) Value[T] {
+	v.lineage = &provenance{source: source}
+	return v
+}
+
+// trace records the provenance of the named field, forwarding
+// it to the parent collector, if any.
+func (c *Collector) trace(field string, p Provenance) {
+	c.mu.Lock()
+	if c.provenance == nil {
+		c.provenance = map[string]Provenance{}
+	}
+	c.provenance[field] = p
+	c.mu.Unlock()
+
+	if c.parent != nil {
+		c.parent.trace(c.prefix+field, p)
+	}
+}
+
+// Provenance returns the provenance of the collected values
+// that recorded one (see Value.From), keyed by their names.
+// Values collected through a prefixed collector are keyed by
+// the prefixed name, e.g. "address.city".
+func (c *Collector) Provenance() map[string]Provenance {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return maps.Clone(c.provenance)
+}
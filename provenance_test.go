@@ -0,0 +1,93 @@
+package valtra_test
+
+import (
+	"cmp"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestProvenance(t *testing.T) {
+	t.Run("source and steps are recorded", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val(" Bobby@Example.com ", "email").From("form:email").
+			Transform(valtra.TrimSpace(), valtra.Lowercase()).
+			Validate(valtra.Email()).
+			Collect(c)
+
+		want := valtra.Provenance{Source: "form:email", Steps: []string{"TrimSpace", "Lowercase"}}
+		if got := c.Provenance()["email"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("conversions and named functions are recorded", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Map(valtra.Val("a,b", "tags").From("query:tags"), splitTags).
+			Transform(sorted[string]()).
+			Collect(c)
+
+		want := []string{"splitTags", "sorted"}
+		if got := c.Provenance()["tags"].Steps; !reflect.DeepEqual(got, want) {
+			t.Errorf("Expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("failed steps are not recorded", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Convert(valtra.Val("abc", "page").From("query:page"), valtra.ParseInt()).Collect(c)
+
+		if got := c.Provenance()["page"]; got.Source != "query:page" || len(got.Steps) != 0 {
+			t.Errorf("Expected only the source, got %v", got)
+		}
+	})
+
+	t.Run("values without a source are not recorded", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("bobby", "name").Transform(valtra.TrimSpace()).Collect(c)
+
+		if got := c.Provenance(); len(got) != 0 {
+			t.Errorf("Expected no provenance, got %v", got)
+		}
+	})
+
+	t.Run("prefixed and merged collectors forward provenance", func(t *testing.T) {
+		c := valtra.NewCollector()
+		valtra.Val("London", "city").From("body.address.city").Collect(c.WithPrefix("address"))
+
+		other := valtra.NewCollector()
+		valtra.Val("bobby", "name").From("body.name").Collect(other)
+		c.Merge(other)
+
+		got := c.Provenance()
+		if got["address.city"].Source != "body.address.city" || got["name"].Source != "body.name" {
+			t.Errorf("Expected provenance of address.city and name, got %v", got)
+		}
+	})
+
+	t.Run("copies keep their own steps", func(t *testing.T) {
+		base := valtra.Val(" x ", "a").From("a").Transform(valtra.TrimSpace())
+
+		c := valtra.NewCollector()
+		base.Transform(valtra.Uppercase()).Collect(c)
+		valtra.Val(base.Value(), "b").From("b").Collect(c)
+		base.Transform(valtra.Lowercase()).Collect(valtra.NewCollector())
+
+		if got := c.Provenance()["a"].Steps; !reflect.DeepEqual(got, []string{"TrimSpace", "Uppercase"}) {
+			t.Errorf("Expected TrimSpace and Uppercase, got %v", got)
+		}
+	})
+}
+
+func splitTags(s string) ([]string, error) {
+	return strings.Split(s, ","), nil
+}
+
+func sorted[T cmp.Ordered]() func(valtra.Value[[]T]) ([]T, error) {
+	return func(v valtra.Value[[]T]) ([]T, error) {
+		return slices.Sorted(slices.Values(v.Value())), nil
+	}
+}
@@ -0,0 +1,207 @@
+package valtra
+
+import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// quantityRegex matches quantities in the Kubernetes quantity
+// grammar: a decimal number followed by an optional binary SI
+// suffix, decimal SI suffix or decimal exponent.
+var quantityRegex = regexp.MustCompile(`^([+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+))(Ki|Mi|Gi|Ti|Pi|Ei|n|u|m|k|M|G|T|P|E|[eE][+-]?[0-9]+)?$`)
+
+// quantitySuffixes maps the SI suffixes of quantities to
+// their multipliers, as base and exponent.
+var quantitySuffixes = map[string]struct{ base, exp int64 }{
+	"Ki": {2, 10}, "Mi": {2, 20}, "Gi": {2, 30}, "Ti": {2, 40}, "Pi": {2, 50}, "Ei": {2, 60},
+	"n": {10, -9}, "u": {10, -6}, "m": {10, -3}, "": {10, 0},
+	"k": {10, 3}, "M": {10, 6}, "G": {10, 9}, "T": {10, 12}, "P": {10, 15}, "E": {10, 18},
+}
+
+// maxQuantityExponent bounds decimal exponents, so inputs
+// such as "1e999999999" cannot exhaust memory.
+const maxQuantityExponent = 100
+
+// Quantity is an exact numeric quantity with an optional SI
+// suffix, such as "500m" (0.5), "2Gi" (2 × 2^30) or "1.5e3",
+// as used in infrastructure configuration.
+//
+// Quantities are created with ParseQuantity.
+type Quantity struct {
+	s   string
+	rat *big.Rat
+}
+
+// ParseQuantity parses a quantity following the Kubernetes
+// quantity grammar: a decimal number followed by an optional
+// binary SI suffix (Ki, Mi, Gi, Ti, Pi, Ei), decimal SI
+// suffix (n, u, m, k, M, G, T, P, E) or decimal exponent
+// (e.g. "e3").
+func ParseQuantity(s string) (Quantity, error) {
+	m := quantityRegex.FindStringSubmatch(s)
+	if m == nil {
+		return Quantity{}, fmt.Errorf("valtra: invalid quantity %q", s)
+	}
+
+	rat, ok := new(big.Rat).SetString(m[1])
+	if !ok {
+		return Quantity{}, fmt.Errorf("valtra: invalid quantity %q", s)
+	}
+
+	mult, ok := quantitySuffixes[m[2]]
+	if !ok {
+		exp, err := strconv.ParseInt(m[2][1:], 10, 64)
+		if err != nil || exp < -maxQuantityExponent || exp > maxQuantityExponent {
+			return Quantity{}, fmt.Errorf("valtra: quantity exponent out of range in %q", s)
+		}
+		mult.base, mult.exp = 10, exp
+	}
+
+	factor := new(big.Int).Exp(big.NewInt(mult.base), big.NewInt(max(mult.exp, -mult.exp)), nil)
+	if mult.exp >= 0 {
+		rat.Mul(rat, new(big.Rat).SetInt(factor))
+	} else {
+		rat.Quo(rat, new(big.Rat).SetInt(factor))
+	}
+
+	return Quantity{s: s, rat: rat}, nil
+}
+
+// String returns the quantity as it was parsed.
+func (q Quantity) String() string {
+	return q.s
+}
+
+// Cmp compares the quantity with another, returning -1, 0 or
+// +1 if it is less than, equal to or greater than the other.
+func (q Quantity) Cmp(other Quantity) int {
+	return q.ratOrZero().Cmp(other.ratOrZero())
+}
+
+// Float64 returns the nearest float64 value of the quantity.
+func (q Quantity) Float64() float64 {
+	f, _ := q.ratOrZero().Float64()
+	return f
+}
+
+// ratOrZero returns the quantity's value, treating the zero
+// Quantity as 0.
+func (q Quantity) ratOrZero() *big.Rat {
+	if q.rat == nil {
+		return new(big.Rat)
+	}
+
+	return q.rat
+}
+
+// ToQuantity returns a conversion that parses the value as
+// a Quantity (see ParseQuantity), for use with Convert.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	cpu := valtra.Convert(valtra.Val(input.CPU, "cpu"), valtra.ToQuantity())
+func ToQuantity(opts ...Option) func(Value[string]) (Quantity, error) {
+	return func(v Value[string]) (Quantity, error) {
+		q, err := ParseQuantity(v.value)
+		if err != nil {
+			return Quantity{}, newError(v, "quantity", nil, opts)
+		}
+
+		return q, nil
+	}
+}
+
+// QuantityBetween returns a validation that ensures the
+// value is a quantity (see ParseQuantity) between min and max
+// (inclusive), e.g. between "100m" and "4".
+//
+// Quantities are compared exactly, so "1Gi" is larger than
+// "1G". An empty min or max leaves that side of the range
+// unbounded. If min or max is not a valid quantity, or min is
+// above max, the validation always fails with an error
+// wrapping ErrInvalidRule.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Memory).Validate(valtra.QuantityBetween("64Mi", "16Gi"))
+func QuantityBetween(min, max string, opts ...Option) func(Value[string]) error {
+	lo, ok := parseQuantityBound(min)
+	if !ok {
+		return invalidRule[string]("QuantityBetween bound %q is not a quantity", min)
+	}
+	hi, ok := parseQuantityBound(max)
+	if !ok {
+		return invalidRule[string]("QuantityBetween bound %q is not a quantity", max)
+	}
+	if lo != nil && hi != nil && lo.Cmp(*hi) > 0 {
+		return invalidRule[string]("QuantityBetween has a minimum (%s) above its maximum (%s)", min, max)
+	}
+
+	return func(v Value[string]) error {
+		q, err := ParseQuantity(v.value)
+		if err != nil {
+			return newError(v, "quantity", nil, opts)
+		}
+
+		if (lo != nil && q.Cmp(*lo) < 0) || (hi != nil && q.Cmp(*hi) > 0) {
+			return newError(v, "quantity_range", map[string]any{"min": min, "max": max}, opts)
+		}
+
+		return nil
+	}
+}
+
+// parseQuantityBound parses a bound of QuantityBetween,
+// returning nil for an empty (unbounded) bound, or false if
+// the bound is not a quantity.
+func parseQuantityBound(bound string) (*Quantity, bool) {
+	if bound == "" {
+		return nil, true
+	}
+
+	q, err := ParseQuantity(bound)
+	if err != nil {
+		return nil, false
+	}
+
+	return &q, true
+}
+
+// DurationBetween returns a validation that ensures the value
+// is a duration string (e.g. "30s" or "1h30m", see
+// time.ParseDuration) between min and max (inclusive). A min
+// above max is an invalid rule (see ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Timeout).Validate(valtra.DurationBetween(time.Second, time.Minute))
+func DurationBetween(min, max time.Duration, opts ...Option) func(Value[string]) error {
+	if min > max {
+		return invalidRule[string]("DurationBetween has a minimum (%s) above its maximum (%s)", min, max)
+	}
+
+	return func(v Value[string]) error {
+		d, err := time.ParseDuration(v.value)
+		if err != nil {
+			return newError(v, "duration", nil, opts)
+		}
+
+		if d < min || d > max {
+			return newError(v, "duration_range", map[string]any{"min": min, "max": max}, opts)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,130 @@
+package valtra_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestParseQuantity(t *testing.T) {
+	t.Run("suffixes are applied", func(t *testing.T) {
+		tests := map[string]float64{
+			"500m":  0.5,
+			"2Gi":   2 << 30,
+			"1.5k":  1500,
+			"1.5e3": 1500,
+			"-3":    -3,
+			".5":    0.5,
+			"100n":  100e-9,
+		}
+
+		for s, expected := range tests {
+			q, err := valtra.ParseQuantity(s)
+			if err != nil {
+				t.Errorf("Unexpected error for %q: %v", s, err)
+				continue
+			}
+			if q.Float64() != expected {
+				t.Errorf("Expected %q to be %v, got %v", s, expected, q.Float64())
+			}
+			if q.String() != s {
+				t.Errorf("Expected String to return %q, got %q", s, q.String())
+			}
+		}
+	})
+
+	t.Run("invalid quantities fail", func(t *testing.T) {
+		for _, s := range []string{"", "Gi", "1Gb", "1 Gi", "1e999999999"} {
+			if _, err := valtra.ParseQuantity(s); err == nil {
+				t.Errorf("Expected error for %q", s)
+			}
+		}
+	})
+
+	t.Run("binary and decimal suffixes compare exactly", func(t *testing.T) {
+		gi, _ := valtra.ParseQuantity("1Gi")
+		g, _ := valtra.ParseQuantity("1G")
+		m, _ := valtra.ParseQuantity("1024Mi")
+		if gi.Cmp(g) != 1 || gi.Cmp(m) != 0 {
+			t.Errorf("Unexpected comparison results: %d, %d", gi.Cmp(g), gi.Cmp(m))
+		}
+	})
+}
+
+func TestToQuantity(t *testing.T) {
+	t.Run("valid quantity is parsed", func(t *testing.T) {
+		q, err := valtra.ToQuantity()(valtra.Val("250m"))
+		if err != nil || q.Float64() != 0.25 {
+			t.Errorf("Expected 0.25, got %v (%v)", q.Float64(), err)
+		}
+	})
+
+	t.Run("invalid quantity fails", func(t *testing.T) {
+		_, err := valtra.ToQuantity()(valtra.Val("lots", "cpu"))
+		if err == nil || err.Error() != "cpu must be a valid quantity" {
+			t.Errorf("Expected quantity error, got %v", err)
+		}
+	})
+}
+
+func TestQuantityBetween(t *testing.T) {
+	t.Run("quantity in range passes", func(t *testing.T) {
+		v := valtra.Val("512Mi").Validate(valtra.QuantityBetween("64Mi", "16Gi"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("quantity out of range fails", func(t *testing.T) {
+		v := valtra.Val("17Gi", "memory").Validate(valtra.QuantityBetween("64Mi", "16Gi"))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail")
+		}
+		expected := "memory must be between 64Mi and 16Gi"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("empty bound is unbounded", func(t *testing.T) {
+		v := valtra.Val("100E").Validate(valtra.QuantityBetween("100m", ""))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("invalid bound fails", func(t *testing.T) {
+		v := valtra.Val("1").Validate(valtra.QuantityBetween("lots", ""))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid bound")
+		}
+	})
+}
+
+func TestDurationBetween(t *testing.T) {
+	t.Run("duration in range passes", func(t *testing.T) {
+		v := valtra.Val("30s").Validate(valtra.DurationBetween(time.Second, time.Minute))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("duration out of range fails", func(t *testing.T) {
+		v := valtra.Val("2m", "timeout").Validate(valtra.DurationBetween(time.Second, time.Minute))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail")
+		}
+		expected := "timeout must be between 1s and 1m0s"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("invalid duration fails", func(t *testing.T) {
+		v := valtra.Val("30").Validate(valtra.DurationBetween(time.Second, time.Minute))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid duration")
+		}
+	})
+}
@@ -0,0 +1,48 @@
+package valtra
+
+import (
+	"context"
+	"fmt"
+)
+
+// QuotaProvider reports the remaining quota of a usage key,
+// such as the number of projects an account may still create.
+type QuotaProvider interface {
+	// Remaining returns how much of the key's quota is left.
+	Remaining(ctx context.Context, key string) (int64, error)
+}
+
+// WithinQuota returns a context-aware validation that ensures
+// the value, a count or size being requested, does not exceed
+// the remaining quota of the key, so plan limits read like any
+// other rule.
+//
+// The remaining quota is available as the "remaining"
+// parameter. Errors from the provider are returned wrapped
+// with context, so that Remote treats network errors and
+// timeouts among them as transient.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(len(input.Projects), "projects").ValidateCtx(ctx,
+//	    valtra.WithinQuota[int](quotas, "projects:"+accountID),
+//	)
+func WithinQuota[T Integer](provider QuotaProvider, key string, opts ...Option) func(context.Context, Value[T]) error {
+	return func(ctx context.Context, v Value[T]) error {
+		remaining, err := provider.Remaining(ctx, key)
+		if err != nil {
+			return fmt.Errorf("valtra: cannot get remaining quota for %s: %w", v.name, err)
+		}
+
+		// Values too large for an int64 exceed any quota
+		n := int64(v.value)
+		if n > remaining || v.value > 0 && n < 0 {
+			return newError(v, "quota", map[string]any{"remaining": max(remaining, 0)}, opts)
+		}
+
+		return nil
+	}
+}
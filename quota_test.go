@@ -0,0 +1,63 @@
+package valtra_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// quotas is a QuotaProvider backed by a map.
+type quotas map[string]int64
+
+func (q quotas) Remaining(ctx context.Context, key string) (int64, error) {
+	remaining, ok := q[key]
+	if !ok {
+		return 0, errors.New("unknown key")
+	}
+
+	return remaining, nil
+}
+
+func TestWithinQuota(t *testing.T) {
+	provider := quotas{"projects": 2, "exhausted": -1}
+	ctx := context.Background()
+
+	t.Run("values within the quota pass", func(t *testing.T) {
+		for _, n := range []int{0, 1, 2} {
+			if v := valtra.Val(n).ValidateCtx(ctx, valtra.WithinQuota[int](provider, "projects")); !v.IsValid() {
+				t.Errorf("Expected %d to pass, got %v", n, v.Errors())
+			}
+		}
+	})
+
+	t.Run("values above the quota fail", func(t *testing.T) {
+		v := valtra.Val(3, "projects").ValidateCtx(ctx, valtra.WithinQuota[int](provider, "projects"))
+		if want := "projects exceeds the remaining quota of 2"; v.IsValid() || v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %v", want, v.Errors())
+		}
+
+		v = valtra.Val(1, "projects").ValidateCtx(ctx, valtra.WithinQuota[int](provider, "exhausted"))
+		if want := "projects exceeds the remaining quota of 0"; v.IsValid() || v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %v", want, v.Errors())
+		}
+	})
+
+	t.Run("values too large for an int64 fail", func(t *testing.T) {
+		huge := quotas{"bytes": math.MaxInt64}
+		if v := valtra.Val(uint64(math.MaxUint64)).ValidateCtx(ctx, valtra.WithinQuota[uint64](huge, "bytes")); v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+
+	t.Run("provider errors are returned", func(t *testing.T) {
+		v := valtra.Val(1).ValidateCtx(ctx, valtra.WithinQuota[int](provider, "unknown"))
+
+		var ve *valtra.ValidationError
+		if v.IsValid() || errors.As(v.FirstError(), &ve) {
+			t.Errorf("Expected a provider error, got %v", v.Errors())
+		}
+	})
+}
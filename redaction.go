@@ -0,0 +1,76 @@
+package valtra
+
+import (
+	"slices"
+	"sync/atomic"
+)
+
+// valueParams lists the parameters of built-in rules that
+// hold the value or parts of it, which redacted messages
+// leave out just like the value itself.
+var valueParams = []string{"domain", "duplicate", "first", "fixed", "keys", "second", "suggestion"}
+
+// redactionPolicy holds the policy set by SetRedactionPolicy.
+var redactionPolicy atomic.Pointer[func(field string) bool]
+
+// SetRedactionPolicy sets a package-level policy that decides,
+// by field name, which values are kept out of the messages of
+// all errors created from now on. A nil policy restores the
+// default, which redacts only classified values (see
+// Value.Classify).
+//
+// Built-in messages never include the value, but custom
+// messages can, through the {value} placeholder, or through
+// the parameters of the few rules that report parts of the
+// value, such as the duplicate found by Unique. For redacted
+// fields, these render as Redacted, and no "did you mean"
+// suggestion is given. The values are still available in the
+// error's Params.
+//
+// Example:
+//
+//	valtra.SetRedactionPolicy(valtra.DefaultRedactPattern.MatchString)
+func SetRedactionPolicy(policy func(field string) bool) {
+	if policy == nil {
+		redactionPolicy.Store(nil)
+		return
+	}
+
+	redactionPolicy.Store(&policy)
+}
+
+// WithValueInMessage sets whether the value may appear in the
+// error's message, overriding the policy set with
+// SetRedactionPolicy. Classified values never appear.
+//
+// Example:
+//
+//	valtra.Val(input.Token, "token").Validate(
+//	    valtra.MinLengthString(32, valtra.WithMessage("{name} {value} is too short"), valtra.WithValueInMessage(false)),
+//	)
+func WithValueInMessage(allowed bool) Option {
+	return func(o *options) {
+		o.valueInMessage = allowed
+		o.hasValueInMessage = true
+	}
+}
+
+// redacts reports whether the value of an error for the named
+// field must be kept out of its message.
+func redacts(field string, class Classification, o options) bool {
+	switch {
+	case class != ClassPublic:
+		return true
+	case o.hasValueInMessage:
+		return !o.valueInMessage
+	}
+
+	policy := redactionPolicy.Load()
+	return policy != nil && (*policy)(field)
+}
+
+// isValueParam reports whether the parameter holds the value
+// or parts of it.
+func isValueParam(key string) bool {
+	return slices.Contains(valueParams, key)
+}
@@ -0,0 +1,114 @@
+package valtra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestWithValueInMessage(t *testing.T) {
+	t.Run("values can be kept out of custom messages", func(t *testing.T) {
+		v := valtra.Val("abc", "token").Validate(
+			valtra.MinLengthString(32, valtra.WithMessage("{name} {value} is too short"), valtra.WithValueInMessage(false)),
+		)
+		if want := "token [REDACTED] is too short"; v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %q", want, v.FirstError())
+		}
+	})
+
+	t.Run("parameters holding parts of the value are redacted", func(t *testing.T) {
+		v := valtra.Val([]string{"secret", "secret"}, "tokens").Validate(valtra.Unique[string](valtra.WithValueInMessage(false)))
+		if msg := v.FirstError().Error(); strings.Contains(msg, "secret") {
+			t.Errorf("Expected the duplicate to be redacted, got %q", msg)
+		}
+	})
+
+	t.Run("suggestions are left out", func(t *testing.T) {
+		v := valtra.Val("gb", "country").Validate(valtra.CountryCodeISO3166(valtra.WithValueInMessage(false)))
+		if want := "country must be a valid ISO 3166-1 country code"; v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %q", want, v.FirstError())
+		}
+	})
+
+	t.Run("classified values stay redacted", func(t *testing.T) {
+		v := valtra.Val("abc", "token").Classify(valtra.ClassSecret).Validate(
+			valtra.MinLengthString(32, valtra.WithMessage("{value}"), valtra.WithValueInMessage(true)),
+		)
+		if v.FirstError().Error() != valtra.Redacted {
+			t.Errorf("Expected %q, got %q", valtra.Redacted, v.FirstError())
+		}
+	})
+}
+
+func TestSetRedactionPolicy(t *testing.T) {
+	valtra.SetRedactionPolicy(valtra.DefaultRedactPattern.MatchString)
+	t.Cleanup(func() { valtra.SetRedactionPolicy(nil) })
+
+	tooShort := valtra.WithMessage("{value} is too short")
+
+	t.Run("matching fields are redacted", func(t *testing.T) {
+		v := valtra.Val("hunter2", "password").Validate(valtra.MinLengthString(10, tooShort))
+		if want := "[REDACTED] is too short"; v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %q", want, v.FirstError())
+		}
+	})
+
+	t.Run("other fields are not", func(t *testing.T) {
+		v := valtra.Val("bob", "nickname").Validate(valtra.MinLengthString(10, tooShort))
+		if want := "bob is too short"; v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %q", want, v.FirstError())
+		}
+	})
+
+	t.Run("rules can opt out", func(t *testing.T) {
+		v := valtra.Val("hunter2", "password").Validate(valtra.MinLengthString(10, tooShort, valtra.WithValueInMessage(true)))
+		if want := "hunter2 is too short"; v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %q", want, v.FirstError())
+		}
+	})
+}
+
+func TestMessagesLeakNoValues(t *testing.T) {
+	const secret = "jane.secret"
+	hidden := valtra.WithValueInMessage(false)
+
+	tests := []struct {
+		name string
+		v    valtra.Validated
+	}{
+		{"unique", valtra.Val([]string{secret, secret}, "tags").Validate(valtra.Unique[string](hidden))},
+		{"allowed keys", valtra.Val(map[string]int{secret: 1}, "settings").Validate(valtra.AllowedKeys[string, int]([]string{"theme"}, hidden))},
+		{"not idempotent", valtra.Val(secret+" &", "bio").Transform(valtra.AssertIdempotent(valtra.EscapeHTML()))},
+		{"disposable email", valtra.Val("bob@"+secret+".com", "email").Validate(valtra.Email(valtra.DisposableDomains(func(string) bool { return true }), hidden))},
+		{"vcard", valtra.Val([]byte("BEGIN:VCARD\r\nVERSION:4.0\r\nFN:Jane\r\nEMAIL:"+secret+"@@example.com\r\nEND:VCARD\r\n"), "contacts").Validate(valtra.VCard(hidden))},
+		{"vcard version", valtra.Val([]byte("BEGIN:VCARD\r\nVERSION:"+secret+"\r\nFN:Jane\r\nEND:VCARD\r\n"), "contacts").Validate(valtra.VCard(hidden))},
+		{"rrule", valtra.Val("FREQ="+secret, "rule").Validate(valtra.RRule(hidden))},
+		{"rrule part", valtra.Val("FREQ=DAILY;UNTIL="+secret, "rule").Validate(valtra.RRule(hidden))},
+		{"rrule list", valtra.Val("FREQ=DAILY;BYHOUR=1,"+secret, "rule").Validate(valtra.RRule(hidden))},
+		{"rrule weekday", valtra.Val("FREQ=MONTHLY;BYDAY=1"+secret, "rule").Validate(valtra.RRule(hidden))},
+		{"rrule malformed", valtra.Val("FREQ=DAILY;"+secret, "rule").Validate(valtra.RRule(hidden))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.v.IsValid() {
+				t.Fatal("Expected validation to fail")
+			}
+			for _, err := range tt.v.Errors() {
+				if strings.Contains(err.Error(), secret) {
+					t.Errorf("Expected the value to be left out, got %q", err)
+				}
+			}
+		})
+	}
+
+	t.Run("fixes", func(t *testing.T) {
+		v := valtra.Val(" "+secret+"@Example.COM", "email").Validate(valtra.Email(valtra.AutoFix(), hidden))
+		for _, w := range v.Warnings() {
+			if strings.Contains(w.Error(), secret) {
+				t.Errorf("Expected the value to be left out, got %q", w)
+			}
+		}
+	})
+}
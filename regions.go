@@ -0,0 +1,57 @@
+package valtra
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// RulesByCountry returns a rule that applies a pack of rules
+// chosen by the country of the value, as returned by the
+// country function, so that rules such as tax ID, phone and
+// postal code formats switch per country in one declaration.
+//
+// Packs are keyed by upper case ISO 3166-1 alpha-2 codes,
+// such as "GB"; the country is upper cased before it is looked
+// up. Values from countries without a pack are validated
+// against the pack under the "*" key, if there is one, and
+// pass otherwise. As with When, the errors of a pack's rules
+// are joined into a single error.
+//
+// The rule's metadata lists the rules of every pack, in
+// country order, with their Country set.
+//
+// Example:
+//
+//	var addressSchema = valtra.NewSchema[Address]().Rules(
+//	    valtra.RulesByCountry(func(a Address) string { return a.Country }, map[string][]valtra.Rule[Address]{
+//	        "GB": {valtra.FieldRule("postcode", func(a Address) string { return a.Postcode }, ukPostcode)},
+//	        "US": {valtra.FieldRule("postcode", func(a Address) string { return a.Postcode }, zipCode)},
+//	    }),
+//	)
+func RulesByCountry[T any](country func(T) string, packs map[string][]Rule[T]) Rule[T] {
+	checks := make(map[string][]func(Value[T]) error, len(packs))
+	var info []RuleInfo
+	for _, c := range slices.Sorted(maps.Keys(packs)) {
+		checks[c] = make([]func(Value[T]) error, 0, len(packs[c]))
+		for _, r := range packs[c] {
+			checks[c] = append(checks[c], r.check)
+			for _, ri := range r.info {
+				ri.Country = c
+				info = append(info, ri)
+			}
+		}
+	}
+
+	return Rule[T]{
+		check: func(v Value[T]) error {
+			pack, ok := checks[strings.ToUpper(country(v.value))]
+			if !ok {
+				pack = checks["*"]
+			}
+
+			return runAll(v, pack)
+		},
+		info: info,
+	}
+}
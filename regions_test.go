@@ -0,0 +1,51 @@
+package valtra_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestRulesByCountry(t *testing.T) {
+	type address struct {
+		Country  string
+		Postcode string
+	}
+
+	postcode := func(a address) string { return a.Postcode }
+	rule := valtra.RulesByCountry(func(a address) string { return a.Country }, map[string][]valtra.Rule[address]{
+		"GB": {valtra.FieldRule("postcode", postcode, valtra.Describe("match", nil, valtra.Match(`^[A-Z]{1,2}[0-9][A-Z0-9]? ?[0-9][A-Z]{2}$`)))},
+		"US": {valtra.FieldRule("postcode", postcode, valtra.Describe("match", nil, valtra.Match(`^[0-9]{5}$`)))},
+		"VA": {},
+		"*":  {valtra.FieldRule("postcode", postcode, valtra.Describe("required", nil, valtra.Required[string]()))},
+	})
+
+	tests := []struct {
+		name  string
+		value address
+		valid bool
+	}{
+		{"valid UK postcode", address{"GB", "SW1A 1AA"}, true},
+		{"invalid UK postcode", address{"GB", "90210"}, false},
+		{"valid US postcode", address{"US", "90210"}, true},
+		{"lower case country", address{"us", "SW1A 1AA"}, false},
+		{"empty pack", address{"VA", ""}, true},
+		{"other country", address{"FR", "75001"}, true},
+		{"other country without postcode", address{"FR", ""}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if v := valtra.Val(tt.value).Validate(rule.Check); v.IsValid() != tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, v.Errors())
+			}
+		})
+	}
+
+	t.Run("packs are described by country", func(t *testing.T) {
+		info := rule.Info()
+		if len(info) != 3 || info[0].Country != "*" || info[1].Country != "GB" || info[2].Field != "postcode" {
+			t.Errorf("Unexpected info: %v", info)
+		}
+	})
+}
@@ -0,0 +1,102 @@
+package valtra
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+	"sync/atomic"
+)
+
+// registry holds the named rules registered with Register.
+//
+// The rules are kept in an immutable map that Register
+// replaces with an updated copy, so that looking rules up,
+// which happens whenever a Registered validation runs, needs
+// no lock. Registrations are serialised by mu, which also
+// guards the rule packs installed with Use.
+var registry struct {
+	mu    sync.Mutex
+	rules atomic.Pointer[map[string]any]
+	packs []RulePack
+}
+
+// Register registers a named, reusable validation rule, so a
+// shared rule vocabulary can be defined once and referenced
+// by name across packages and services.
+//
+// It is intended to be called from init functions or at
+// package level. Register panics if a rule with the same
+// name is already registered or if the rule is nil.
+//
+// Example:
+//
+//	func init() {
+//	    valtra.Register("slug", valtra.Match(`^[a-z0-9]+(?:-[a-z0-9]+)*$`))
+//	}
+func Register[T any](name string, rule func(Value[T]) error) {
+	if rule == nil {
+		panic("valtra: Register rule is nil")
+	}
+
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	rules := registeredRules()
+	if _, dup := rules[name]; dup {
+		panic("valtra: Register called twice for rule " + name)
+	}
+
+	rules = maps.Clone(rules)
+	if rules == nil {
+		rules = map[string]any{}
+	}
+	rules[name] = rule
+	registry.rules.Store(&rules)
+}
+
+// registeredRules returns the current map of registered rules,
+// which must not be modified.
+func registeredRules() map[string]any {
+	if rules := registry.rules.Load(); rules != nil {
+		return *rules
+	}
+
+	return nil
+}
+
+// Lookup returns the rule registered under the given name,
+// and whether a rule for values of type T was found.
+func Lookup[T any](name string) (func(Value[T]) error, bool) {
+	rule, ok := registeredRules()[name].(func(Value[T]) error)
+	return rule, ok
+}
+
+// Registered returns a validation that applies the rule
+// registered under the given name.
+//
+// The rule is looked up each time the validation runs, so
+// it can be referenced (e.g. in a package-level Schema)
+// before it is registered. If no rule for values of type T
+// is registered under the name, the validation fails with an
+// error wrapping ErrInvalidRule.
+//
+// Example:
+//
+//	valtra.Val(input.Slug).Validate(valtra.Registered[string]("slug"))
+func Registered[T any](name string) func(Value[T]) error {
+	return func(v Value[T]) error {
+		rule, ok := Lookup[T](name)
+		if !ok {
+			return fmt.Errorf("%w: no rule registered as %q for %T values", ErrInvalidRule, name, v.value)
+		}
+
+		return rule(v)
+	}
+}
+
+// lookupAny returns the rule registered under the given
+// name, regardless of the type of values it validates.
+func lookupAny(name string) (any, bool) {
+	rule, ok := registeredRules()[name]
+	return rule, ok
+}
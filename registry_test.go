@@ -0,0 +1,53 @@
+package valtra_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestRegister(t *testing.T) {
+	valtra.Register("test-slug", valtra.Match(`^[a-z0-9]+(?:-[a-z0-9]+)*$`))
+
+	t.Run("registered rule is applied", func(t *testing.T) {
+		v := valtra.Val("hello-world").Validate(valtra.Registered[string]("test-slug"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+
+		v = valtra.Val("Hello World").Validate(valtra.Registered[string]("test-slug"))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+
+	t.Run("registered rule can be looked up", func(t *testing.T) {
+		if _, ok := valtra.Lookup[string]("test-slug"); !ok {
+			t.Error("Expected rule to be found")
+		}
+		if _, ok := valtra.Lookup[int]("test-slug"); ok {
+			t.Error("Expected rule not to be found for another type")
+		}
+	})
+
+	t.Run("unknown rule fails", func(t *testing.T) {
+		v := valtra.Val(5).Validate(valtra.Registered[int]("test-slug"))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail for unknown rule")
+		}
+		if !strings.Contains(v.Errors()[0].Error(), `"test-slug"`) {
+			t.Errorf("Expected error to name the rule, got %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("duplicate registration panics", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("Expected duplicate registration to panic")
+			}
+		}()
+
+		valtra.Register("test-slug", valtra.Required[string]())
+	})
+}
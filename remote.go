@@ -0,0 +1,147 @@
+package valtra
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+)
+
+// RemoteOption configures how Remote runs a network-backed
+// validation.
+type RemoteOption func(*remoteOptions)
+
+// remoteOptions holds the configuration applied by
+// RemoteOption values.
+type remoteOptions struct {
+	timeout     time.Duration
+	retries     int
+	backoff     time.Duration
+	fallback    error
+	hasFallback bool
+	invalid     error
+}
+
+// WithTimeout limits the duration of each attempt of a remote
+// validation. A timed out attempt counts as a transient
+// failure.
+func WithTimeout(timeout time.Duration) RemoteOption {
+	return func(o *remoteOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithRetry retries a remote validation up to n more times
+// after a transient failure, waiting backoff before the first
+// retry and doubling the wait after each subsequent one. A
+// negative n or backoff makes it an invalid rule (see
+// ErrInvalidRule).
+func WithRetry(n int, backoff time.Duration) RemoteOption {
+	return func(o *remoteOptions) {
+		if n < 0 || backoff < 0 {
+			o.invalid = fmt.Errorf("%w: WithRetry has a negative count (%d) or backoff (%s)", ErrInvalidRule, n, backoff)
+		}
+		o.retries = n
+		o.backoff = backoff
+	}
+}
+
+// WithFallback sets the result of a remote validation when
+// all of its attempts fail transiently. A nil error lets the
+// value pass with a "remote_unavailable" warning (see
+// Value.Warnings), so an unavailable dependency does not block
+// the request. Called directly, the validation then returns a
+// non-nil error carrying the warning, which SplitWarnings
+// tells apart from a failure.
+func WithFallback(err error) RemoteOption {
+	return func(o *remoteOptions) {
+		o.fallback = err
+		o.hasFallback = true
+	}
+}
+
+// Remote wraps a context-aware validation that depends on a
+// network service (such as an MX lookup or an HTTP API), so
+// that transient failures degrade gracefully.
+//
+// An attempt fails transiently when the validation returns a
+// network error (a net.Error, such as a DNS, connection or
+// HTTP client error), or when it exceeds the WithTimeout
+// limit. Transient failures are retried as configured by
+// WithRetry, after which the WithFallback result is returned
+// (or the last error, if no fallback was set).
+//
+// Other errors, such as validation failures and invalid
+// rules, are returned as they are, and the parent context
+// being done stops any further attempts.
+//
+// Example:
+//
+//	valtra.Val(email).ValidateCtx(ctx, valtra.Remote(mxCheck,
+//	    valtra.WithTimeout(2*time.Second),
+//	    valtra.WithRetry(2, 100*time.Millisecond),
+//	    valtra.WithFallback(nil),
+//	))
+func Remote[T any](validation func(context.Context, Value[T]) error, opts ...RemoteOption) func(context.Context, Value[T]) error {
+	var o remoteOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.invalid != nil {
+		return func(context.Context, Value[T]) error {
+			return o.invalid
+		}
+	}
+
+	return func(ctx context.Context, v Value[T]) error {
+		var err error
+		wait := o.backoff
+		for attempt := 0; attempt <= o.retries; attempt++ {
+			if attempt > 0 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(wait):
+				}
+				wait *= 2
+			}
+
+			err = attemptRemote(ctx, v, validation, o.timeout)
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if !transient(err) {
+				return err
+			}
+		}
+
+		switch {
+		case !o.hasFallback:
+			return err
+		case o.fallback == nil:
+			return withWarnings(nil, []error{newError(v, "remote_unavailable", nil, []Option{WithSeverity(SeverityWarning)})})
+		default:
+			return o.fallback
+		}
+	}
+}
+
+// transient reports whether a remote validation's error is
+// worth retrying: a timeout or a network error.
+func transient(err error) bool {
+	var ne net.Error
+	return errors.Is(err, context.DeadlineExceeded) || errors.As(err, &ne)
+}
+
+// attemptRemote runs a single attempt of a remote validation,
+// bounded by the timeout if one is set.
+func attemptRemote[T any](ctx context.Context, v Value[T], validation func(context.Context, Value[T]) error, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	return validation(ctx, v)
+}
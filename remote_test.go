@@ -0,0 +1,120 @@
+package valtra_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestRemote(t *testing.T) {
+	errDNS := &net.DNSError{Err: "server misbehaving", Name: "example.com", IsTemporary: true}
+
+	flaky := func(failures int) (func(context.Context, valtra.Value[string]) error, *int) {
+		calls := 0
+		return func(ctx context.Context, v valtra.Value[string]) error {
+			calls++
+			if calls <= failures {
+				return errDNS
+			}
+			return nil
+		}, &calls
+	}
+
+	t.Run("retries transient failures", func(t *testing.T) {
+		rule, calls := flaky(2)
+		v := valtra.Val("a@example.com").ValidateCtx(context.Background(),
+			valtra.Remote(rule, valtra.WithRetry(2, time.Millisecond)),
+		)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass after retries, got errors: %v", v.Errors())
+		}
+		if *calls != 3 {
+			t.Errorf("Expected 3 attempts, got %d", *calls)
+		}
+	})
+
+	t.Run("returns last error when retries are exhausted", func(t *testing.T) {
+		rule, _ := flaky(5)
+		v := valtra.Val("a@example.com").ValidateCtx(context.Background(),
+			valtra.Remote(rule, valtra.WithRetry(1, time.Millisecond)),
+		)
+		if len(v.Errors()) != 1 || !errors.Is(v.Errors()[0], errDNS) {
+			t.Errorf("Expected dns error, got %v", v.Errors())
+		}
+	})
+
+	t.Run("fallback is used when retries are exhausted", func(t *testing.T) {
+		rule, _ := flaky(5)
+		v := valtra.Val("a@example.com").ValidateCtx(context.Background(),
+			valtra.Remote(rule, valtra.WithFallback(nil)),
+		)
+		if !v.IsValid() {
+			t.Errorf("Expected fallback to pass, got errors: %v", v.Errors())
+		}
+
+		var ve *valtra.ValidationError
+		if len(v.Warnings()) != 1 || !errors.As(v.Warnings()[0], &ve) || ve.Code != "remote_unavailable" {
+			t.Errorf("Expected a remote_unavailable warning, got %v", v.Warnings())
+		}
+
+		failure, warnings := valtra.SplitWarnings(valtra.Remote(rule, valtra.WithFallback(nil))(context.Background(), valtra.Val("a@example.com")))
+		if failure != nil || len(warnings) != 1 {
+			t.Errorf("Expected a direct call to pass with a warning, got %v and %v", failure, warnings)
+		}
+	})
+
+	t.Run("other errors are not transient", func(t *testing.T) {
+		calls := 0
+		errBug := errors.New("nil resolver")
+		rule := func(ctx context.Context, v valtra.Value[string]) error {
+			calls++
+			return errBug
+		}
+
+		v := valtra.Val("a@example.com").ValidateCtx(context.Background(),
+			valtra.Remote(rule, valtra.WithRetry(3, time.Millisecond), valtra.WithFallback(nil)),
+		)
+		if len(v.Errors()) != 1 || !errors.Is(v.Errors()[0], errBug) {
+			t.Errorf("Expected the error to be returned, got %v", v.Errors())
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", calls)
+		}
+	})
+
+	t.Run("validation failures are not retried", func(t *testing.T) {
+		calls := 0
+		rule := func(ctx context.Context, v valtra.Value[string]) error {
+			calls++
+			return valtra.Required[string]()(v)
+		}
+
+		v := valtra.Val("").ValidateCtx(context.Background(),
+			valtra.Remote(rule, valtra.WithRetry(3, time.Millisecond), valtra.WithFallback(nil)),
+		)
+		if v.IsValid() {
+			t.Error("Expected validation failure to be returned")
+		}
+		if calls != 1 {
+			t.Errorf("Expected 1 attempt, got %d", calls)
+		}
+	})
+
+	t.Run("timed out attempts use the fallback", func(t *testing.T) {
+		slow := func(ctx context.Context, v valtra.Value[string]) error {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+
+		v := valtra.Val("a@example.com").ValidateCtx(context.Background(),
+			valtra.Remote(slow, valtra.WithTimeout(time.Millisecond), valtra.WithFallback(nil)),
+		)
+		if !v.IsValid() {
+			t.Errorf("Expected fallback to pass, got errors: %v", v.Errors())
+		}
+	})
+}
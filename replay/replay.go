@@ -0,0 +1,211 @@
+// Package replay validates recorded payloads against a
+// valtra schema and summarises the results per rule.
+//
+// It is meant for estimating the impact of rule changes
+// before deploying them: record a corpus of real payloads,
+// replay it against the new schema, and inspect how many
+// payloads each rule would reject.
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// Summary holds the results of replaying a corpus of
+// payloads.
+type Summary struct {
+	// Total is the number of payloads replayed.
+	Total int
+	// Passed is the number of payloads the schema accepted.
+	Passed int
+	// Failed is the number of payloads the schema rejected.
+	Failed int
+	// Malformed is the number of payloads that could not be
+	// decoded. They are not counted in Total.
+	Malformed int
+	// Rules maps error codes to the number of payloads that
+	// failed with that code. Errors that are not validation
+	// errors are counted under "error".
+	Rules map[string]int
+}
+
+// PassRate returns the fraction of payloads that passed, or
+// 0 if no payloads were replayed.
+func (s Summary) PassRate() float64 {
+	if s.Total == 0 {
+		return 0
+	}
+
+	return float64(s.Passed) / float64(s.Total)
+}
+
+// FailureRate returns the fraction of payloads that failed
+// with the given error code, or 0 if no payloads were
+// replayed.
+func (s Summary) FailureRate(code string) float64 {
+	if s.Total == 0 {
+		return 0
+	}
+
+	return float64(s.Rules[code]) / float64(s.Total)
+}
+
+// String returns a human-readable report of the summary,
+// listing the rules by descending number of failures.
+func (s Summary) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d payloads: %d passed (%.1f%%), %d failed, %d malformed\n",
+		s.Total, s.Passed, s.PassRate()*100, s.Failed, s.Malformed)
+
+	codes := slices.SortedFunc(maps.Keys(s.Rules), func(a, b string) int {
+		if s.Rules[a] != s.Rules[b] {
+			return s.Rules[b] - s.Rules[a]
+		}
+		return strings.Compare(a, b)
+	})
+	for _, code := range codes {
+		fmt.Fprintf(&b, "  %s: %d (%.1f%%)\n", code, s.Rules[code], s.FailureRate(code)*100)
+	}
+
+	return b.String()
+}
+
+// NDJSON replays a stream of newline-delimited JSON payloads
+// against the schema. Blank lines are skipped.
+//
+// Payloads that cannot be decoded into T are counted as
+// malformed. Read errors are returned along with the summary
+// of the payloads replayed so far.
+//
+// Example:
+//
+//	f, _ := os.Open("requests.ndjson")
+//	summary, err := replay.NDJSON(f, userSchema)
+//	fmt.Print(summary)
+func NDJSON[T any](r io.Reader, schema valtra.Schema[T]) (Summary, error) {
+	s := Summary{Rules: map[string]int{}}
+	if err := replayLines(r, schema, &s); err != nil {
+		return s, err
+	}
+
+	return s, nil
+}
+
+// Dir replays every payload stored in the file system
+// against the schema, walking it recursively.
+//
+// Files with a ".json" extension hold a single payload each,
+// and files with a ".ndjson" or ".jsonl" extension hold one
+// payload per line. Other files are ignored.
+//
+// Example:
+//
+//	summary, err := replay.Dir(os.DirFS("testdata/requests"), userSchema)
+func Dir[T any](fsys fs.FS, schema valtra.Schema[T]) (Summary, error) {
+	s := Summary{Rules: map[string]int{}}
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+
+		switch path.Ext(name) {
+		case ".json":
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return err
+			}
+			replayPayload(data, schema, &s)
+		case ".ndjson", ".jsonl":
+			f, err := fsys.Open(name)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			if err := replayLines(f, schema, &s); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+
+		return nil
+	})
+
+	return s, err
+}
+
+// replayLines replays each non-blank line of the reader as a
+// payload, adding the results to the summary.
+func replayLines[T any](r io.Reader, schema valtra.Schema[T], s *Summary) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		replayPayload(line, schema, s)
+	}
+
+	return scanner.Err()
+}
+
+// replayPayload decodes a single payload and applies the
+// schema to it, adding the result to the summary.
+func replayPayload[T any](data []byte, schema valtra.Schema[T], s *Summary) {
+	var payload T
+	if err := json.Unmarshal(data, &payload); err != nil {
+		s.Malformed++
+		return
+	}
+
+	s.Total++
+	_, err := schema.Run(payload, "payload")
+	if err == nil {
+		s.Passed++
+		return
+	}
+
+	s.Failed++
+	for code := range codes(err) {
+		s.Rules[code]++
+	}
+}
+
+// codes returns the set of error codes found in the error,
+// looking through joined errors.
+func codes(err error) map[string]struct{} {
+	found := map[string]struct{}{}
+
+	var walk func(err error)
+	walk = func(err error) {
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			for _, e := range joined.Unwrap() {
+				walk(e)
+			}
+			return
+		}
+
+		var ve *valtra.ValidationError
+		if errors.As(err, &ve) {
+			found[ve.Code] = struct{}{}
+		} else {
+			found["error"] = struct{}{}
+		}
+	}
+	walk(err)
+
+	return found
+}
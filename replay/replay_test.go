@@ -0,0 +1,78 @@
+package replay_test
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/replay"
+)
+
+type payload struct {
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+var payloadSchema = valtra.NewSchema[payload]().Validate(
+	valtra.Derive(func(p payload) string { return p.Name }, valtra.Required[string]()),
+	valtra.Derive(func(p payload) int { return p.Age }, valtra.Min(18)),
+)
+
+func TestNDJSON(t *testing.T) {
+	t.Run("results are summarised per rule", func(t *testing.T) {
+		corpus := strings.Join([]string{
+			`{"name": "bobby", "age": 28}`,
+			`{"name": "", "age": 16}`,
+			``,
+			`{"name": "ana", "age": 17}`,
+			`{"name": "broken"`,
+		}, "\n")
+
+		s, err := replay.NDJSON(strings.NewReader(corpus), payloadSchema)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if s.Total != 3 || s.Passed != 1 || s.Failed != 2 || s.Malformed != 1 {
+			t.Errorf("Unexpected totals: %+v", s)
+		}
+		if s.Rules["min"] != 2 || s.Rules["required"] != 1 {
+			t.Errorf("Unexpected rule counts: %v", s.Rules)
+		}
+		if rate := s.FailureRate("min"); rate < 0.66 || rate > 0.67 {
+			t.Errorf("Expected min failure rate of 2/3, got %v", rate)
+		}
+	})
+
+	t.Run("report lists rules by failures", func(t *testing.T) {
+		corpus := `{"name": "", "age": 16}` + "\n" + `{"name": "ana", "age": 17}`
+
+		s, _ := replay.NDJSON(strings.NewReader(corpus), payloadSchema)
+		expected := "2 payloads: 0 passed (0.0%), 2 failed, 0 malformed\n" +
+			"  min: 2 (100.0%)\n" +
+			"  required: 1 (50.0%)\n"
+		if s.String() != expected {
+			t.Errorf("Expected %q, got %q", expected, s.String())
+		}
+	})
+}
+
+func TestDir(t *testing.T) {
+	t.Run("JSON and NDJSON files are replayed", func(t *testing.T) {
+		fsys := fstest.MapFS{
+			"a.json":          {Data: []byte(`{"name": "bobby", "age": 28}`)},
+			"nested/b.ndjson": {Data: []byte(`{"name": "ana", "age": 17}` + "\n" + `{"name": "bob", "age": 30}`)},
+			"notes.txt":       {Data: []byte(`not a payload`)},
+		}
+
+		s, err := replay.Dir(fsys, payloadSchema)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if s.Total != 3 || s.Passed != 2 || s.Rules["min"] != 1 {
+			t.Errorf("Unexpected summary: %+v", s)
+		}
+	})
+}
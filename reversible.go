@@ -0,0 +1,71 @@
+package valtra
+
+import (
+	"errors"
+	"slices"
+)
+
+// Reversible is a pair of transformations that undo each
+// other, so that one package handles both cleaning inputs for
+// storage and formatting stored values for display.
+//
+// Normalize turns a value into the form it is stored in, and
+// Denormalize turns a stored value back into the form it is
+// shown in. Schemas add the pair with TransformReversible, and
+// run the reverse direction with Denormalize.
+//
+// Example:
+//
+//	cents := valtra.Reversible[int]{
+//	    Normalize:   func(v valtra.Value[int]) (int, error) { return v.Value() * 100, nil },
+//	    Denormalize: func(v valtra.Value[int]) (int, error) { return v.Value() / 100, nil },
+//	}
+type Reversible[T any] struct {
+	Normalize   func(Value[T]) (T, error)
+	Denormalize func(Value[T]) (T, error)
+}
+
+// TransformReversible returns a new schema that applies the
+// Normalize transformations of the provided pairs after the
+// schema's existing steps, and whose Denormalize also applies
+// their Denormalize transformations.
+func (s Schema[T]) TransformReversible(pairs ...Reversible[T]) Schema[T] {
+	steps := slices.Clip(s.steps)
+	for _, pair := range pairs {
+		steps = append(steps, step[T]{transform: pair.Normalize, reverse: pair.Denormalize})
+	}
+
+	return Schema[T]{steps: steps}
+}
+
+// Denormalize applies the Denormalize transformations of the
+// schema's reversible pairs to the given value, in reverse
+// order, and returns the result along with any transformation
+// errors joined into a single error.
+//
+// Validations and transformations that are not reversible,
+// such as TrimSpace, are skipped, so a stored value can be
+// formatted for display with the schema that normalised it.
+//
+// Example:
+//
+//	display, err := phoneSchema.Denormalize(user.Phone, "phone")
+func (s Schema[T]) Denormalize(value T, name ...string) (T, error) {
+	v := s.denormalize(Val(value, name...))
+	return v.value, errors.Join(v.errs...)
+}
+
+// denormalize applies the reverse of the schema's reversible
+// transformations to the value, last step first.
+func (s Schema[T]) denormalize(v Value[T]) Value[T] {
+	for i := len(s.steps) - 1; i >= 0; i-- {
+		switch st := s.steps[i]; {
+		case st.reverse != nil:
+			v = v.Transform(st.reverse)
+		case st.denormalize != nil:
+			v = st.denormalize(v)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,72 @@
+package valtra_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// cents is a reversible transformation between whole units
+// and cents, for testing.
+var cents = valtra.Reversible[int]{
+	Normalize:   func(v valtra.Value[int]) (int, error) { return v.Value() * 100, nil },
+	Denormalize: func(v valtra.Value[int]) (int, error) { return v.Value() / 100, nil },
+}
+
+func TestTransformReversible(t *testing.T) {
+	t.Run("normalize is applied as a transformation", func(t *testing.T) {
+		got, err := valtra.NewSchema[int]().TransformReversible(cents).Run(12)
+		if err != nil || got != 1200 {
+			t.Errorf("Expected 1200, got %d (%v)", got, err)
+		}
+	})
+
+	t.Run("denormalize reverses the pairs in reverse order", func(t *testing.T) {
+		plusOne := valtra.Reversible[int]{
+			Normalize:   func(v valtra.Value[int]) (int, error) { return v.Value() + 1, nil },
+			Denormalize: func(v valtra.Value[int]) (int, error) { return v.Value() - 1, nil },
+		}
+		schema := valtra.NewSchema[int]().TransformReversible(cents, plusOne)
+
+		stored, _ := schema.Run(12)
+		got, err := schema.Denormalize(stored)
+		if err != nil || stored != 1201 || got != 12 {
+			t.Errorf("Expected 1201 and 12, got %d and %d (%v)", stored, got, err)
+		}
+	})
+
+	t.Run("validations and other transformations are skipped", func(t *testing.T) {
+		schema := valtra.NewSchema[int]().
+			Transform(func(v valtra.Value[int]) (int, error) { return v.Value() * 2, nil }).
+			TransformReversible(cents).
+			Validate(valtra.Max(10))
+
+		got, err := schema.Denormalize(1200)
+		if err != nil || got != 12 {
+			t.Errorf("Expected 12, got %d (%v)", got, err)
+		}
+	})
+
+	t.Run("errors are returned", func(t *testing.T) {
+		failing := valtra.Reversible[int]{
+			Normalize:   func(v valtra.Value[int]) (int, error) { return v.Value(), nil },
+			Denormalize: func(v valtra.Value[int]) (int, error) { return 0, errors.New("boom") },
+		}
+
+		got, err := valtra.NewSchema[int]().TransformReversible(failing).Denormalize(5)
+		if err == nil || err.Error() != "boom" || got != 5 {
+			t.Errorf("Expected the value unchanged with an error, got %d (%v)", got, err)
+		}
+	})
+
+	t.Run("shadowed schemas are reversed", func(t *testing.T) {
+		active := valtra.NewSchema[int]().TransformReversible(cents)
+		schema := active.Shadow(valtra.NewSchema[int](), func(valtra.ShadowReport[int]) {})
+
+		got, err := schema.Denormalize(1200)
+		if err != nil || got != 12 {
+			t.Errorf("Expected 12, got %d (%v)", got, err)
+		}
+	})
+}
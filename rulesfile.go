@@ -0,0 +1,237 @@
+package valtra
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RuleSet is a compiled set of declarative rules, read from a
+// rules file with LoadRules, which validates the fields of
+// structs as their `valtra` struct tags would.
+//
+// Rules files have a line per field, with the field's name, as
+// ValidateStruct names it, and its rules, in the tag syntax:
+//
+//	# signup rules
+//	email: required,email
+//	age: min=18,max=130
+//	address.city: required
+//
+// Empty lines and lines starting with "#" are ignored.
+type RuleSet struct {
+	rules map[string]string
+	order []string
+}
+
+// ParseRules compiles the rules of a rules file. Malformed lines
+// and unknown rules are reported with their line number, along
+// with ErrInvalidRule.
+func ParseRules(data []byte) (*RuleSet, error) {
+	rs := &RuleSet{rules: map[string]string{}}
+
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for n := 1; sc.Scan(); n++ {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		field, tag, ok := strings.Cut(line, ":")
+		field = strings.TrimSpace(field)
+		if !ok || field == "" {
+			return nil, fmt.Errorf("%w: line %d: expected \"field: rules\"", ErrInvalidRule, n)
+		}
+		if _, dup := rs.rules[field]; dup {
+			return nil, fmt.Errorf("%w: line %d: duplicate field %s", ErrInvalidRule, n, field)
+		}
+		for _, r := range ParseTag(tag) {
+			if !isTagRule(r.Name) {
+				return nil, fmt.Errorf("%w: line %d: unknown rule %q", ErrInvalidRule, n, r.Name)
+			}
+		}
+
+		rs.rules[field] = tag
+		rs.order = append(rs.order, field)
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("valtra: reading rules: %w", err)
+	}
+
+	return rs, nil
+}
+
+// LoadRules reads and compiles the rules file at the given path.
+func LoadRules(path string) (*RuleSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("valtra: loading rules: %w", err)
+	}
+
+	rs, err := ParseRules(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return rs, nil
+}
+
+// isTagRule reports whether the rule can be used in tags and
+// rules files, as a built-in or registered rule.
+func isTagRule(name string) bool {
+	switch name {
+	case "required", "omitempty", "min", "max", "oneof", "contains", "startswith", "endswith":
+		return true
+	}
+	if _, ok := stringTagRules[name]; ok {
+		return true
+	}
+	_, ok := lookupAny(name)
+	return ok
+}
+
+// Validate validates the fields of the struct (or the pointer
+// to one) with the rule set, as ValidateStruct does with struct
+// tags, which are ignored. Fields without rules are not
+// validated.
+func (rs *RuleSet) Validate(s any) *Collector {
+	c := NewCollector()
+
+	seen := map[visit]bool{}
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		seen[visit{rv.Pointer(), rv.Type()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		c.add("", fmt.Errorf("valtra: RuleSet.Validate expects a struct, got %T", s))
+		return c
+	}
+
+	validateStruct(c, rv, "", rs.rules, seen)
+	return c
+}
+
+// Describe returns the metadata of the rule set's rules, in
+// the order of the rules file, as DescribeStruct does for
+// struct tags.
+func (rs *RuleSet) Describe() []RuleInfo {
+	var info []RuleInfo
+	for _, field := range rs.order {
+		for _, r := range ParseTag(rs.rules[field]) {
+			ri := RuleInfo{Field: field, Name: r.Name}
+			if r.HasParam {
+				ri.Params = map[string]any{r.Name: r.Param}
+			}
+			info = append(info, ri)
+		}
+	}
+
+	return info
+}
+
+// rulesPollInterval is how often a RuleWatcher checks its file
+// for changes.
+const rulesPollInterval = 250 * time.Millisecond
+
+// RuleWatcher keeps a RuleSet up to date with its rules file
+// (see WatchRules).
+type RuleWatcher struct {
+	rules atomic.Pointer[RuleSet]
+	done  chan struct{}
+	once  sync.Once
+}
+
+// WatchRules loads the rules file at the given path, and keeps
+// watching it, recompiling the rules whenever the file changes
+// and atomically swapping them in, so validation can be tweaked
+// without a redeploy.
+//
+// onSwap, if not nil, is called after each reload with the new
+// rules, or with the error that kept the previous rules in
+// place, e.g. a rule misspelt in an edit. The file is polled for
+// changes, so swaps happen shortly after a change, not at once.
+// An error is returned if the file cannot be loaded initially.
+//
+// Example:
+//
+//	w, err := valtra.WatchRules("signup.rules", func(_ *valtra.RuleSet, err error) {
+//	    if err != nil {
+//	        log.Printf("keeping previous signup rules: %v", err)
+//	    }
+//	})
+//	if err != nil {
+//	    return err
+//	}
+//	defer w.Close()
+//
+//	c := w.Rules().Validate(signup)
+func WatchRules(path string, onSwap func(*RuleSet, error)) (*RuleWatcher, error) {
+	last, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("valtra: loading rules: %w", err)
+	}
+	rs, err := LoadRules(path)
+	if err != nil {
+		return nil, err
+	}
+
+	w := &RuleWatcher{done: make(chan struct{})}
+	w.rules.Store(rs)
+	go w.watch(path, last, onSwap)
+
+	return w, nil
+}
+
+// watch polls the rules file until the watcher is closed,
+// reloading it when its size or modification time changes. A
+// missing file is reported once, and reloaded when it is back.
+func (w *RuleWatcher) watch(path string, last os.FileInfo, onSwap func(*RuleSet, error)) {
+	ticker := time.NewTicker(rulesPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.done:
+			return
+		case <-ticker.C:
+		}
+
+		current, err := os.Stat(path)
+		switch {
+		case err != nil && last == nil:
+			continue
+		case err != nil:
+			err = fmt.Errorf("valtra: loading rules: %w", err)
+		case last != nil && current.ModTime().Equal(last.ModTime()) && current.Size() == last.Size():
+			continue
+		default:
+			var rs *RuleSet
+			if rs, err = LoadRules(path); err == nil {
+				w.rules.Store(rs)
+			}
+		}
+
+		last = current
+		if onSwap != nil {
+			onSwap(w.rules.Load(), err)
+		}
+	}
+}
+
+// Rules returns the current rules.
+func (w *RuleWatcher) Rules() *RuleSet {
+	return w.rules.Load()
+}
+
+// Close stops watching the rules file. The current rules stay
+// available from Rules.
+func (w *RuleWatcher) Close() {
+	w.once.Do(func() { close(w.done) })
+}
@@ -0,0 +1,108 @@
+package valtra_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+type rulesSignup struct {
+	Email   string `json:"email" valtra:"max=1"`
+	Age     int    `json:"age"`
+	Address struct {
+		City string `json:"city"`
+	} `json:"address"`
+}
+
+func TestParseRules(t *testing.T) {
+	rs, err := valtra.ParseRules([]byte("# signup\n\nemail: required,email\nage: min=18\naddress.city: required\n"))
+	if err != nil {
+		t.Fatalf("Expected the rules to parse, got %v", err)
+	}
+
+	t.Run("fields are validated by name", func(t *testing.T) {
+		var s rulesSignup
+		s.Email, s.Age, s.Address.City = "bob@example.com", 30, "Sofia"
+		if c := rs.Validate(&s); !c.IsValid() {
+			t.Errorf("Expected validation to pass, ignoring tags, got %v", c.Errors())
+		}
+
+		c := rs.Validate(rulesSignup{Email: "bob", Age: 15})
+		if got := len(c.ErrorsByField()); got != 3 {
+			t.Errorf("Expected errors for 3 fields, got %v", c.ErrorsByField())
+		}
+	})
+
+	t.Run("rules are described", func(t *testing.T) {
+		info := rs.Describe()
+		if len(info) != 4 || info[2].String() != "min=18" || info[3].Field != "address.city" {
+			t.Errorf("Unexpected rules: %v", info)
+		}
+	})
+
+	t.Run("invalid files are rejected", func(t *testing.T) {
+		for _, data := range []string{"email required", "email: required\nemail: email", "age: minimum=18"} {
+			if _, err := valtra.ParseRules([]byte(data)); !errors.Is(err, valtra.ErrInvalidRule) {
+				t.Errorf("Expected an invalid rule error for %q, got %v", data, err)
+			}
+		}
+	})
+}
+
+func TestWatchRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "signup.rules")
+	write := func(rules string, mod time.Time) {
+		if err := os.WriteFile(path, []byte(rules), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, mod, mod); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("age: min=18", time.Now().Add(-time.Hour))
+
+	swaps := make(chan error, 10)
+	w, err := valtra.WatchRules(path, func(_ *valtra.RuleSet, err error) { swaps <- err })
+	if err != nil {
+		t.Fatalf("Expected the rules to load, got %v", err)
+	}
+	defer w.Close()
+
+	wait := func() error {
+		select {
+		case err := <-swaps:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("Expected the rules to be reloaded")
+			return nil
+		}
+	}
+
+	if c := w.Rules().Validate(rulesSignup{Age: 20}); !c.IsValid() {
+		t.Fatalf("Expected the initial rules to pass, got %v", c.Errors())
+	}
+
+	write("age: min=21", time.Now())
+	if err := wait(); err != nil {
+		t.Fatalf("Expected the rules to be swapped, got %v", err)
+	}
+	if c := w.Rules().Validate(rulesSignup{Age: 20}); c.IsValid() {
+		t.Error("Expected the swapped rules to apply")
+	}
+
+	write("age: minimum=21", time.Now().Add(time.Minute))
+	if err := wait(); !errors.Is(err, valtra.ErrInvalidRule) {
+		t.Fatalf("Expected an invalid rule error, got %v", err)
+	}
+	if c := w.Rules().Validate(rulesSignup{Age: 20}); c.IsValid() {
+		t.Error("Expected the previous rules to stay in place")
+	}
+
+	if _, err := valtra.WatchRules(filepath.Join(t.TempDir(), "missing.rules"), nil); err == nil {
+		t.Error("Expected a missing file to be reported")
+	}
+}
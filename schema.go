@@ -0,0 +1,332 @@
+package valtra
+
+import (
+	"errors"
+	"maps"
+	"slices"
+)
+
+// Schema is a reusable pipeline of validations and
+// transformations for values of type T.
+//
+// Schemas are built once, typically at package level, and
+// applied to many values. Steps run in the order they were
+// added, exactly as if they had been passed to Value's
+// Validate and Transform methods.
+//
+// Schemas are immutable: Validate and Transform return new
+// schemas, so a schema can safely be extended and shared
+// between goroutines.
+//
+// Example:
+//
+//	var usernameSchema = valtra.NewSchema[string]().
+//	    Transform(valtra.TrimSpace(), valtra.Lowercase()).
+//	    Validate(valtra.Required[string](), valtra.MaxLengthString(20))
+//
+//	username := valtra.Val(input.Username, "username").Apply(usernameSchema)
+type Schema[T any] struct {
+	steps []step[T]
+}
+
+// step is a single validation or transformation of a
+// schema, or a nested pipeline applied as a whole. Exactly
+// one of validate, transform and apply is set.
+//
+// For reversible transformations, reverse undoes transform.
+// For nested pipelines, sanitize applies only the pipeline's
+// transformations, checkOnly only its validations, and
+// denormalize the reverse of its reversible transformations.
+type step[T any] struct {
+	validate    func(Value[T]) error
+	transform   func(Value[T]) (T, error)
+	reverse     func(Value[T]) (T, error)
+	apply       func(Value[T]) Value[T]
+	sanitize    func(Value[T]) Value[T]
+	checkOnly   func(Value[T]) Value[T]
+	denormalize func(Value[T]) Value[T]
+
+	// info describes the validation, if it was added as a
+	// described Rule.
+	info []RuleInfo
+	// phase is the phase the step is applied in.
+	phase Phase
+}
+
+// NewSchema creates and returns a new, empty Schema.
+func NewSchema[T any]() Schema[T] {
+	return Schema[T]{}
+}
+
+// Validate returns a new schema that applies the provided
+// validation functions after the schema's existing steps.
+func (s Schema[T]) Validate(validations ...func(Value[T]) error) Schema[T] {
+	steps := slices.Clip(s.steps)
+	for _, fn := range validations {
+		steps = append(steps, step[T]{validate: fn})
+	}
+
+	return Schema[T]{steps: steps}
+}
+
+// Transform returns a new schema that applies the provided
+// transformation functions after the schema's existing
+// steps.
+func (s Schema[T]) Transform(transformations ...func(Value[T]) (T, error)) Schema[T] {
+	steps := slices.Clip(s.steps)
+	for _, fn := range transformations {
+		steps = append(steps, step[T]{transform: fn})
+	}
+
+	return Schema[T]{steps: steps}
+}
+
+// Also returns a new schema that calls the given observers
+// after the schema's existing steps, as Value.Also does. They
+// are only called by Apply and Run, not by Sanitize or
+// CheckOnly.
+func (s Schema[T]) Also(observers ...func(Value[T])) Schema[T] {
+	steps := append(slices.Clip(s.steps), step[T]{apply: func(v Value[T]) Value[T] {
+		return v.Also(observers...)
+	}})
+
+	return Schema[T]{steps: steps}
+}
+
+// Apply applies the schema's steps to the given value, adding
+// any errors to the value's error list.
+//
+// It is equivalent to v.Apply(s).
+func (s Schema[T]) Apply(v Value[T]) Value[T] {
+	return s.inPhases(v, func(v Value[T], st step[T]) Value[T] {
+		switch {
+		case st.validate != nil:
+			return v.Validate(st.validate)
+		case st.transform != nil:
+			return v.Transform(st.transform)
+		default:
+			return st.apply(v)
+		}
+	})
+}
+
+// Run applies the schema to the given value, returning the
+// resulting value along with all errors joined into a single
+// error (or nil if it passed).
+//
+// The optional name parameter is used in error messages, as
+// with Val.
+//
+// Example:
+//
+//	username, err := usernameSchema.Run(input.Username, "username")
+func (s Schema[T]) Run(value T, name ...string) (T, error) {
+	v := s.Apply(Val(value, name...))
+	return v.value, errors.Join(v.errs...)
+}
+
+// Sanitize applies only the schema's transformations to the
+// given value, skipping its validations, and returns the
+// result along with any transformation errors joined into a
+// single error.
+//
+// It normalises values without enforcing the schema's rules,
+// e.g. when backfilling legacy records. Repairs made by rules
+// with AutoFix are validations, so they are skipped too.
+//
+// Example:
+//
+//	for i, u := range legacyUsers {
+//	    legacyUsers[i].Username, _ = usernameSchema.Sanitize(u.Username)
+//	}
+func (s Schema[T]) Sanitize(value T, name ...string) (T, error) {
+	v := s.sanitize(Val(value, name...))
+	return v.value, errors.Join(v.errs...)
+}
+
+// sanitize applies the schema's transformations to the
+// value.
+func (s Schema[T]) sanitize(v Value[T]) Value[T] {
+	for _, st := range s.steps {
+		switch {
+		case st.transform != nil:
+			v = v.Transform(st.transform)
+		case st.sanitize != nil:
+			v = st.sanitize(v)
+		}
+	}
+
+	return v
+}
+
+// CheckOnly applies only the schema's validations to the given
+// value, skipping its transformations, and returns all errors
+// joined into a single error (or nil if it passed).
+//
+// Validations see the value exactly as given, e.g. for preview
+// endpoints that must not change what the user typed. Rules
+// with AutoFix do not repair the value either, and fail as
+// they would without the option.
+//
+// Example:
+//
+//	if err := usernameSchema.CheckOnly(input.Username, "username"); err != nil {
+//	    preview.Errors = append(preview.Errors, err.Error())
+//	}
+func (s Schema[T]) CheckOnly(value T, name ...string) error {
+	return errors.Join(s.checkOnly(Val(value, name...)).errs...)
+}
+
+// checkOnly applies the schema's validations to the value.
+func (s Schema[T]) checkOnly(v Value[T]) Value[T] {
+	return s.inPhases(v, func(v Value[T], st step[T]) Value[T] {
+		switch {
+		case st.validate != nil:
+			if !v.stopped() {
+				if err, _ := SplitWarnings(check(v, st.validate)); err != nil {
+					v.errs = append(v.errs, err)
+				}
+			}
+		case st.checkOnly != nil:
+			v = st.checkOnly(v)
+		}
+
+		return v
+	})
+}
+
+// ShadowReport describes a value that a shadow schema
+// rejected.
+type ShadowReport[T any] struct {
+	// Field is the name of the value.
+	Field string
+	// Value is the value the shadow schema was applied to.
+	Value T
+	// Errors holds the shadow schema's errors.
+	Errors []error
+	// ActiveValid reports whether the active schema accepted
+	// the value, i.e. whether the shadow schema's rejection is
+	// a new failure.
+	ActiveValid bool
+}
+
+// Shadow returns a schema that applies s as usual, and also
+// applies the shadow schema to the same input value without
+// affecting the result.
+//
+// Whenever the shadow schema rejects a value, report is
+// called with its errors, so a stricter schema can be
+// evaluated against live traffic before it is rolled out.
+//
+// Example:
+//
+//	var nameSchema = activeSchema.Shadow(stricterSchema, func(r valtra.ShadowReport[string]) {
+//	    if r.ActiveValid {
+//	        slog.Warn("stricter schema would reject value", "field", r.Field, "errors", r.Errors)
+//	    }
+//	})
+func (s Schema[T]) Shadow(shadow Schema[T], report func(ShadowReport[T])) Schema[T] {
+	return Schema[T]{steps: []step[T]{{apply: func(v Value[T]) Value[T] {
+		active := s.Apply(v)
+
+		shadowed := shadow.Apply(Value[T]{value: v.value, name: v.name, absent: v.absent, pos: v.pos, class: v.class, meta: v.meta})
+		if !shadowed.IsValid() {
+			report(ShadowReport[T]{
+				Field:       v.name,
+				Value:       v.value,
+				Errors:      shadowed.errs,
+				ActiveValid: len(active.errs) == len(v.errs),
+			})
+		}
+
+		return active
+	}, sanitize: s.sanitize, checkOnly: s.checkOnly, denormalize: s.denormalize, info: s.Describe()}}}
+}
+
+// Apply applies the given schema's steps to the value.
+//
+// Example:
+//
+//	v := valtra.Val(input.Username, "username").Apply(usernameSchema)
+func (v Value[T]) Apply(s Schema[T]) Value[T] {
+	return s.Apply(v)
+}
+
+// VersionedSchema holds several versions of a schema, keyed
+// by API version, so a payload can be validated against the
+// rules of the version it was sent with.
+//
+// VersionedSchemas are created with Versioned.
+type VersionedSchema[T any] struct {
+	schemas map[string]Schema[T]
+}
+
+// Versioned creates and returns a VersionedSchema from the
+// given schemas, keyed by API version.
+//
+// It supports deprecation processes where rules are tightened
+// in a new API version while older versions keep their rules.
+// Satisfies reports which versions a payload would pass.
+//
+// Example:
+//
+//	var nameSchemas = valtra.Versioned(map[string]valtra.Schema[string]{
+//	    "v1": valtra.NewSchema[string]().Validate(valtra.Required[string]()),
+//	    "v2": valtra.NewSchema[string]().Validate(valtra.Required[string](), valtra.MaxLengthString(50)),
+//	})
+//
+//	name := nameSchemas.Apply(apiVersion, valtra.Val(input.Name, "name"))
+func Versioned[T any](schemas map[string]Schema[T]) VersionedSchema[T] {
+	return VersionedSchema[T]{schemas: maps.Clone(schemas)}
+}
+
+// Versions returns the known versions, sorted.
+func (vs VersionedSchema[T]) Versions() []string {
+	return slices.Sorted(maps.Keys(vs.schemas))
+}
+
+// Schema returns the schema for the given version, and
+// whether it exists.
+func (vs VersionedSchema[T]) Schema(version string) (Schema[T], bool) {
+	s, ok := vs.schemas[version]
+	return s, ok
+}
+
+// Apply applies the schema for the given version to the
+// value. If the version is unknown, an error is added to the
+// value's error list instead.
+func (vs VersionedSchema[T]) Apply(version string, v Value[T]) Value[T] {
+	s, ok := vs.schemas[version]
+	if !ok {
+		v.errs = append(v.errs, newError(v, "unknown_version", map[string]any{"version": version}, nil))
+		return v
+	}
+
+	return s.Apply(v)
+}
+
+// Run applies the schema for the given version to the given
+// value, returning the resulting value along with all errors
+// joined into a single error (or nil if it passed).
+func (vs VersionedSchema[T]) Run(version string, value T, name ...string) (T, error) {
+	v := vs.Apply(version, Val(value, name...))
+	return v.value, errors.Join(v.errs...)
+}
+
+// Satisfies returns the sorted versions whose schemas the
+// given value passes.
+//
+// Example:
+//
+//	// e.g. [v1] if a payload would break once v1 is retired
+//	versions := nameSchemas.Satisfies(input.Name)
+func (vs VersionedSchema[T]) Satisfies(value T) []string {
+	var versions []string
+	for _, version := range vs.Versions() {
+		if _, err := vs.schemas[version].Run(value); err == nil {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions
+}
@@ -0,0 +1,243 @@
+package valtra_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestSchema(t *testing.T) {
+	usernameSchema := valtra.NewSchema[string]().
+		Transform(valtra.TrimSpace(), valtra.Lowercase()).
+		Validate(valtra.Required[string](), valtra.MaxLengthString(5))
+
+	t.Run("steps are applied in order", func(t *testing.T) {
+		v := valtra.Val("  BOBBY ").Apply(usernameSchema)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+		if v.Value() != "bobby" {
+			t.Errorf("Expected %q, got %q", "bobby", v.Value())
+		}
+	})
+
+	t.Run("failures are reported", func(t *testing.T) {
+		v := valtra.Val("bobby27", "username").Apply(usernameSchema)
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+
+	t.Run("run returns joined error", func(t *testing.T) {
+		_, err := usernameSchema.Run("   ", "username")
+		if err == nil {
+			t.Fatal("Expected error")
+		}
+		if err.Error() != "username is required" {
+			t.Errorf("Expected %q, got %q", "username is required", err.Error())
+		}
+	})
+
+	t.Run("extending a schema does not modify it", func(t *testing.T) {
+		base := valtra.NewSchema[int]().Validate(valtra.Min(0))
+		a := base.Validate(valtra.Max(10))
+		b := base.Validate(valtra.Max(100))
+
+		if _, err := b.Run(50); err != nil {
+			t.Errorf("Expected extended schema to be independent, got error: %v", err)
+		}
+		if _, err := a.Run(50); err == nil {
+			t.Error("Expected extended schema to apply its own rules")
+		}
+		if _, err := base.Run(500); err != nil {
+			t.Errorf("Expected base schema to be unchanged, got error: %v", err)
+		}
+	})
+}
+
+func TestSchemaSanitize(t *testing.T) {
+	usernameSchema := valtra.NewSchema[string]().
+		Transform(valtra.TrimSpace()).
+		Validate(valtra.MaxLengthString(5)).
+		Transform(valtra.Lowercase())
+
+	t.Run("transformations are applied without validations", func(t *testing.T) {
+		got, err := usernameSchema.Sanitize("  Bobby Tables ")
+		if err != nil || got != "bobby tables" {
+			t.Errorf("Expected %q, got %q (error: %v)", "bobby tables", got, err)
+		}
+	})
+
+	t.Run("shadowed schemas are sanitised by the active schema", func(t *testing.T) {
+		s := usernameSchema.Shadow(valtra.NewSchema[string]().Transform(valtra.Uppercase()), func(valtra.ShadowReport[string]) {})
+		if got, _ := s.Sanitize(" BOBBY"); got != "bobby" {
+			t.Errorf("Expected %q, got %q", "bobby", got)
+		}
+	})
+
+	t.Run("transformation errors are returned", func(t *testing.T) {
+		failing := func(valtra.Value[string]) (string, error) { return "", errors.New("cannot normalise") }
+		s := valtra.NewSchema[string]().Transform(valtra.TrimSpace(), failing)
+		if got, err := s.Sanitize(" bobby "); err == nil || got != "bobby" {
+			t.Errorf("Expected error and %q, got %q (error: %v)", "bobby", got, err)
+		}
+	})
+}
+
+func TestSchemaCheckOnly(t *testing.T) {
+	usernameSchema := valtra.NewSchema[string]().
+		Transform(valtra.TrimSpace()).
+		Validate(valtra.Required[string](), valtra.MaxLengthString(5))
+
+	t.Run("validations see the raw value", func(t *testing.T) {
+		if err := usernameSchema.CheckOnly(" bobby ", "username"); err == nil || err.Error() != "username's length cannot be larger than 5" {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if err := usernameSchema.CheckOnly("bobby"); err != nil {
+			t.Errorf("Expected validation to pass, got %v", err)
+		}
+	})
+
+	t.Run("rules do not repair the value", func(t *testing.T) {
+		s := valtra.NewSchema[string]().Validate(valtra.Email(valtra.AutoFix()))
+		if err := s.CheckOnly(" bobby@example.com"); err == nil {
+			t.Error("Expected validation to fail")
+		}
+	})
+
+	t.Run("shadowed schemas are checked by the active schema", func(t *testing.T) {
+		s := usernameSchema.Shadow(valtra.NewSchema[string]().Validate(valtra.MinLengthString(10)), func(valtra.ShadowReport[string]) {})
+		if err := s.CheckOnly("bobby"); err != nil {
+			t.Errorf("Expected validation to pass, got %v", err)
+		}
+	})
+}
+
+func TestSchemaAlso(t *testing.T) {
+	var captured []string
+	schema := valtra.NewSchema[string]().
+		Transform(valtra.TrimSpace()).
+		Also(func(v valtra.Value[string]) { captured = append(captured, v.Value()) }).
+		Validate(valtra.Required[string]())
+
+	t.Run("observers run with the schema", func(t *testing.T) {
+		if _, err := schema.Run(" bobby "); err != nil {
+			t.Fatalf("Expected validation to pass, got %v", err)
+		}
+		if len(captured) != 1 || captured[0] != "bobby" {
+			t.Errorf("Expected the normalised value to be captured, got %q", captured)
+		}
+	})
+
+	t.Run("observers do not run for sanitize or check only", func(t *testing.T) {
+		captured = nil
+		schema.Sanitize(" bobby ")
+		_ = schema.CheckOnly(" bobby ")
+		if len(captured) != 0 {
+			t.Errorf("Expected no observer calls, got %q", captured)
+		}
+	})
+}
+
+func TestVersioned(t *testing.T) {
+	schemas := valtra.Versioned(map[string]valtra.Schema[string]{
+		"v1": valtra.NewSchema[string]().Validate(valtra.Required[string]()),
+		"v2": valtra.NewSchema[string]().Validate(valtra.Required[string](), valtra.MaxLengthString(5)),
+	})
+
+	t.Run("schema is selected by version", func(t *testing.T) {
+		if _, err := schemas.Run("v1", "bobby27"); err != nil {
+			t.Errorf("Expected v1 to pass, got error: %v", err)
+		}
+		if _, err := schemas.Run("v2", "bobby27"); err == nil {
+			t.Error("Expected v2 to fail")
+		}
+	})
+
+	t.Run("unknown version fails", func(t *testing.T) {
+		v := schemas.Apply("v3", valtra.Val("bobby", "name"))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail for unknown version")
+		}
+		expected := "name uses unknown version v3"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("satisfied versions are reported", func(t *testing.T) {
+		if versions := schemas.Satisfies("bobby27"); !slices.Equal(versions, []string{"v1"}) {
+			t.Errorf("Expected [v1], got %v", versions)
+		}
+		if versions := schemas.Satisfies("bob"); !slices.Equal(versions, []string{"v1", "v2"}) {
+			t.Errorf("Expected [v1 v2], got %v", versions)
+		}
+		if versions := schemas.Satisfies(""); len(versions) != 0 {
+			t.Errorf("Expected no versions, got %v", versions)
+		}
+	})
+}
+
+func TestSchemaShadow(t *testing.T) {
+	active := valtra.NewSchema[string]().Validate(valtra.Required[string]())
+	stricter := valtra.NewSchema[string]().Validate(valtra.Required[string](), valtra.MaxLengthString(5))
+
+	t.Run("shadow failures are reported but do not fail", func(t *testing.T) {
+		var reports []valtra.ShadowReport[string]
+		s := active.Shadow(stricter, func(r valtra.ShadowReport[string]) { reports = append(reports, r) })
+
+		v := valtra.Val("bobby27", "name").Apply(s)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+		if len(reports) != 1 {
+			t.Fatalf("Expected 1 report, got %d", len(reports))
+		}
+		if r := reports[0]; r.Field != "name" || r.Value != "bobby27" || !r.ActiveValid || len(r.Errors) != 1 {
+			t.Errorf("Unexpected report: %+v", r)
+		}
+	})
+
+	t.Run("active failures are still returned", func(t *testing.T) {
+		var reports []valtra.ShadowReport[string]
+		s := active.Shadow(stricter, func(r valtra.ShadowReport[string]) { reports = append(reports, r) })
+
+		v := valtra.Val("").Apply(s)
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if len(reports) != 1 || reports[0].ActiveValid {
+			t.Errorf("Expected report with ActiveValid false, got %+v", reports)
+		}
+	})
+
+	t.Run("shadow passes are not reported", func(t *testing.T) {
+		reported := false
+		s := active.Shadow(stricter, func(r valtra.ShadowReport[string]) { reported = true })
+
+		valtra.Val("bob").Apply(s)
+		if reported {
+			t.Error("Expected no report")
+		}
+	})
+
+	t.Run("shadow sees the input value", func(t *testing.T) {
+		var seen string
+		s := active.Transform(valtra.Uppercase()).Shadow(stricter, func(r valtra.ShadowReport[string]) { seen = r.Value })
+
+		v := valtra.Val("bobby27").Apply(s)
+		if v.Value() != "BOBBY27" || seen != "bobby27" {
+			t.Errorf("Expected active result %q and shadow input %q, got %q and %q", "BOBBY27", "bobby27", v.Value(), seen)
+		}
+	})
+	t.Run("active rules are described", func(t *testing.T) {
+		described := valtra.NewSchema[int]().Rules(valtra.Describe("min", map[string]any{"min": 18}, valtra.Min(18)))
+		s := described.Shadow(valtra.NewSchema[int](), func(valtra.ShadowReport[int]) {})
+
+		if info := s.Describe(); len(info) != 1 || info[0].String() != "min=18" {
+			t.Errorf("Expected the active schema's rules, got %v", info)
+		}
+	})
+}
@@ -0,0 +1,219 @@
+package valtra
+
+import (
+	"math"
+	"strings"
+	"unicode"
+)
+
+// ScoreRule scores how plausible a value is, from 0 (certainly
+// invalid) to 1 (certainly valid), for heuristic checks that
+// cannot give a clear yes or no, such as spotting spam.
+//
+// Scores can be used directly for graded decisions, or turned
+// into validations with MinScore.
+//
+// Example:
+//
+//	score := valtra.PlausibleText()(valtra.Val(input.Comment))
+//	if score < 0.5 {
+//	    queueForModeration(input)
+//	}
+type ScoreRule[T any] func(Value[T]) float64
+
+// MinScore returns a validation that ensures the value scores
+// at least min with the given rule.
+//
+// The score, rounded to two decimal places, is available as
+// the "score" parameter. Using several thresholds with
+// different severities allows graded decisions.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Comment).Validate(
+//	    valtra.MinScore(valtra.PlausibleText(), 0.2),
+//	    valtra.MinScore(valtra.PlausibleText(), 0.6, valtra.WithSeverity(valtra.SeverityWarning)),
+//	)
+func MinScore[T any](rule ScoreRule[T], min float64, opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		score := clampScore(rule(v))
+		if score < min {
+			return newError(v, "min_score", map[string]any{"score": math.Round(score*100) / 100, "min": min}, opts)
+		}
+
+		return nil
+	}
+}
+
+// MeanScore returns a score rule that averages the scores of
+// the given rules. It scores 1 if there are no rules.
+//
+// Example:
+//
+//	bioScore := valtra.MeanScore(valtra.PlausibleText(), languageScore)
+func MeanScore[T any](rules ...ScoreRule[T]) ScoreRule[T] {
+	return func(v Value[T]) float64 {
+		if len(rules) == 0 {
+			return 1
+		}
+
+		var total float64
+		for _, rule := range rules {
+			total += clampScore(rule(v))
+		}
+
+		return total / float64(len(rules))
+	}
+}
+
+// clampScore limits the score to the range 0 to 1.
+func clampScore(score float64) float64 {
+	if math.IsNaN(score) {
+		return 0
+	}
+
+	return min(max(score, 0), 1)
+}
+
+// PlausibleName returns a score rule for how likely the value
+// is a real person's name.
+//
+// Digits and symbols, letters repeated three or more times in
+// a row, keyboard runs such as "asdf", Latin names without
+// vowels (for names with at least three Latin letters), and
+// very short or long names lower the score.
+//
+// Example:
+//
+//	valtra.Val(input.FullName).Validate(valtra.MinScore(valtra.PlausibleName(), 0.5))
+func PlausibleName() ScoreRule[string] {
+	return func(v Value[string]) float64 {
+		name := strings.ToLower(strings.TrimSpace(v.value))
+		if name == "" {
+			return 0
+		}
+
+		score := 1.0
+		var digits, symbols, latin, vowels, runes int
+		var prev rune
+		var repeat int
+		for _, r := range name {
+			runes++
+			switch {
+			case unicode.IsDigit(r):
+				digits++
+			case unicode.IsLetter(r) || unicode.IsMark(r):
+				if r < unicode.MaxASCII {
+					latin++
+					if strings.ContainsRune("aeiouy", r) {
+						vowels++
+					}
+				}
+			case !unicode.IsSpace(r) && !strings.ContainsRune("-'.’", r):
+				symbols++
+			}
+
+			if r == prev && unicode.IsLetter(r) {
+				repeat++
+				if repeat == 2 {
+					score *= 0.4
+				}
+			} else {
+				repeat = 0
+			}
+			prev = r
+		}
+
+		if digits > 0 {
+			score *= 0.2
+		}
+		if symbols > 0 {
+			score *= 0.3
+		}
+		if latin >= 3 && vowels == 0 {
+			score *= 0.5
+		}
+		if runes < 2 || runes > 70 {
+			score *= 0.5
+		}
+		for _, run := range keyboardRuns {
+			if strings.Contains(name, run) {
+				score *= 0.3
+				break
+			}
+		}
+
+		return score
+	}
+}
+
+// keyboardRuns holds sequences of adjacent keys that are
+// typed to fill in forms.
+var keyboardRuns = []string{"qwert", "asdf", "zxcv", "hjkl", "uiop", "werty"}
+
+// spamPhrases holds phrases common in spam.
+var spamPhrases = []string{"act now", "buy now", "casino", "click here", "free money", "limited time", "make money", "viagra", "you have won", "100% free"}
+
+// PlausibleText returns a score rule for how likely the value
+// is genuine text rather than spam, e.g. for comments and
+// contact forms.
+//
+// Links, shouting, repeated characters and punctuation (such
+// as "!!!"), and phrases common in spam lower the score.
+//
+// Example:
+//
+//	valtra.Val(input.Message).Validate(valtra.MinScore(valtra.PlausibleText(), 0.4))
+func PlausibleText() ScoreRule[string] {
+	return func(v Value[string]) float64 {
+		text := strings.ToLower(v.value)
+		score := 1.0
+
+		links := strings.Count(text, "http://") + strings.Count(text, "https://") + strings.Count(text, "www.")
+		score *= math.Pow(0.7, float64(links))
+
+		var letters, upper int
+		for _, r := range v.value {
+			if unicode.IsLetter(r) {
+				letters++
+				if unicode.IsUpper(r) {
+					upper++
+				}
+			}
+		}
+		if letters > 10 && upper*2 > letters {
+			score *= 0.5
+		}
+
+		var prev rune
+		var repeat int
+		var repeated, punctuation bool
+		for _, r := range text {
+			if r == prev {
+				repeat++
+				repeated = repeated || repeat == 4
+				punctuation = punctuation || repeat == 2 && (unicode.IsPunct(r) || unicode.IsSymbol(r))
+			} else {
+				repeat = 0
+			}
+			prev = r
+		}
+		if repeated {
+			score *= 0.6
+		}
+		if punctuation {
+			score *= 0.7
+		}
+
+		for _, phrase := range spamPhrases {
+			if strings.Contains(text, phrase) {
+				score *= 0.5
+			}
+		}
+
+		return score
+	}
+}
@@ -0,0 +1,95 @@
+package valtra_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestMinScore(t *testing.T) {
+	half := func(valtra.Value[string]) float64 { return 0.456 }
+
+	t.Run("scores at the threshold pass", func(t *testing.T) {
+		v := valtra.Val("x").Validate(valtra.MinScore(half, 0.4))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("lower scores fail with the score", func(t *testing.T) {
+		v := valtra.Val("x", "comment").Validate(valtra.MinScore(half, 0.6))
+		if v.IsValid() || v.Errors()[0].Error() != "comment does not look genuine (score 0.46, minimum 0.6)" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("scores are clamped", func(t *testing.T) {
+		over := func(valtra.Value[string]) float64 { return 3 }
+		v := valtra.Val("x").Validate(valtra.MinScore(over, 1))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestMeanScore(t *testing.T) {
+	low := func(valtra.Value[int]) float64 { return 0.2 }
+	high := func(valtra.Value[int]) float64 { return 0.8 }
+
+	if got := valtra.MeanScore(low, high)(valtra.Val(1)); got != 0.5 {
+		t.Errorf("Expected 0.5, got %v", got)
+	}
+	if got := valtra.MeanScore[int]()(valtra.Val(1)); got != 1 {
+		t.Errorf("Expected 1 without rules, got %v", got)
+	}
+}
+
+func TestPlausibleName(t *testing.T) {
+	tests := []struct {
+		name      string
+		plausible bool
+	}{
+		{"Bobby Tables", true},
+		{"Mary-Jane O'Neill", true},
+		{"José Álvarez", true},
+		{"李小龙", true},
+		{"asdfgh", false},
+		{"xxxxx", false},
+		{"R2D2", false},
+		{"<script>", false},
+		{"Brrr Wxyz", false},
+		{"", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := valtra.PlausibleName()(valtra.Val(tt.name))
+			if (score >= 0.5) != tt.plausible {
+				t.Errorf("Unexpected score %v", score)
+			}
+		})
+	}
+}
+
+func TestPlausibleText(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		plausible bool
+	}{
+		{"regular message", "Hi, I'd like to ask about your opening hours on Sunday.", true},
+		{"single link", "The docs are at https://example.com/docs, thanks!", true},
+		{"many links", "https://a.example https://b.example www.c.example", false},
+		{"shouting with punctuation", "AMAZING OFFER FOR YOU!!!", false},
+		{"spam phrases", "Click here to claim free money", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			score := valtra.PlausibleText()(valtra.Val(tt.text))
+			if (score >= 0.5) != tt.plausible {
+				t.Errorf("Unexpected score %v", score)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package valtra
+
+import (
+	"strconv"
+	"unique"
+	"unsafe"
+)
+
+// scratchErrors and scratchBytes are the sizes of the chunks
+// a scratch allocates errors and message bytes in.
+const (
+	scratchErrors = 64
+	scratchBytes  = 4 << 10
+)
+
+// scratch holds the buffers used to create the errors of
+// failed validations in bulk runs.
+//
+// With chunks set, errors and strings are allocated in chunks,
+// rather than one by one, so that runs with many failures make
+// few, larger allocations (see WithScratch). Chunks are only
+// ever appended to, so the errors and strings handed out stay
+// valid for as long as they are referenced.
+//
+// With intern set, messages are built in a reused buffer and
+// interned, so that identical messages share their memory
+// (see InternMessages).
+//
+// A scratch is not safe for concurrent use.
+type scratch struct {
+	chunks bool
+	errs   []ValidationError
+	buf    []byte
+
+	intern bool
+	tmp    []byte
+}
+
+// newError returns a zeroed ValidationError, from the current
+// chunk if errors are allocated in chunks.
+func (s *scratch) newError() *ValidationError {
+	if !s.chunks {
+		return &ValidationError{}
+	}
+	if len(s.errs) == 0 {
+		s.errs = make([]ValidationError, scratchErrors)
+	}
+
+	e := &s.errs[0]
+	s.errs = s.errs[1:]
+	return e
+}
+
+// message returns the message built by appending to a buffer
+// with build, which is expected to need about size bytes.
+func (s *scratch) message(size int, build func([]byte) []byte) string {
+	if !s.intern {
+		return s.appendString(size, build)
+	}
+
+	s.tmp = build(s.tmp[:0])
+	return unique.Make(unsafe.String(unsafe.SliceData(s.tmp), len(s.tmp))).Value()
+}
+
+// appendString returns the string built by appending to the
+// current chunk with build, which is expected to need about
+// size bytes.
+func (s *scratch) appendString(size int, build func([]byte) []byte) string {
+	if cap(s.buf)-len(s.buf) < size {
+		s.buf = make([]byte, 0, max(scratchBytes, size))
+	}
+
+	start := len(s.buf)
+	s.buf = build(s.buf)
+	if len(s.buf) == start {
+		return ""
+	}
+
+	return unsafe.String(&s.buf[start], len(s.buf)-start)
+}
+
+// indexedName returns name followed by the index in brackets,
+// e.g. "rows[42]".
+func (s *scratch) indexedName(name string, i int) string {
+	return s.appendString(len(name)+8, func(b []byte) []byte {
+		b = append(b, name...)
+		b = append(b, '[')
+		b = strconv.AppendInt(b, int64(i), 10)
+		return append(b, ']')
+	})
+}
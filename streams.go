@@ -0,0 +1,290 @@
+package valtra
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"iter"
+	"math"
+	"strings"
+)
+
+// ReadAndValidate reads at most maxBytes from the reader into
+// a Value[[]byte] and applies all provided validation
+// functions to it.
+//
+// It allows request bodies and other streams to be validated
+// without buffering them twice. If the reader holds more than
+// maxBytes, a "too_large" error is added and the validations
+// are skipped. Read errors are added to the value's error
+// list as they are. A negative maxBytes is reported as an
+// error wrapping ErrInvalidRule, without reading.
+//
+// Example:
+//
+//	v := valtra.ReadAndValidate(r.Body, 1<<20, valtra.DetectedContentTypes("image/png"))
+//	if !v.IsValid() {
+//	    return v.Errors()[0]
+//	}
+func ReadAndValidate(r io.Reader, maxBytes int64, validations ...func(Value[[]byte]) error) Value[[]byte] {
+	v := Val[[]byte](nil)
+	if maxBytes < 0 {
+		v.errs = append(v.errs, invalidRule[[]byte]("ReadAndValidate has a negative limit (%d)", maxBytes)(v))
+		return v
+	}
+
+	// One byte past the limit is read to tell whether the
+	// reader exceeds it, unless that would overflow.
+	limit := maxBytes
+	if limit < math.MaxInt64 {
+		limit++
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit))
+	if err != nil {
+		v.errs = append(v.errs, err)
+		return v
+	}
+
+	if int64(len(data)) > maxBytes {
+		v.errs = append(v.errs, newError(v, "too_large", map[string]any{"max": maxBytes}, nil))
+		return v
+	}
+
+	v.value = data
+	return v.Validate(validations...)
+}
+
+// StreamSHA256Equals returns a validation that copies the
+// reader to dst while computing its SHA-256 digest, and
+// ensures the digest matches the expected hex-encoded value.
+//
+// It allows large uploads to be integrity-checked while they
+// are being stored, without buffering them in memory. Note
+// that the data is written to dst before the digest can be
+// checked, so callers should discard it when the validation
+// fails. Copy errors are returned as they are.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val[io.Reader](r.Body, "upload").Validate(valtra.StreamSHA256Equals(file, checksum))
+func StreamSHA256Equals(dst io.Writer, expected string, opts ...Option) func(Value[io.Reader]) error {
+	return func(v Value[io.Reader]) error {
+		h := sha256.New()
+		if _, err := io.Copy(io.MultiWriter(dst, h), v.value); err != nil {
+			return err
+		}
+
+		if !strings.EqualFold(hex.EncodeToString(h.Sum(nil)), expected) {
+			return newError(v, "sha256", map[string]any{"expected": expected}, opts)
+		}
+
+		return nil
+	}
+}
+
+// MaxBytes returns a transformation that limits the reader to
+// n bytes without buffering it.
+//
+// The returned reader yields the data as is, but fails with a
+// "too_large" *ValidationError as soon as the stream turns out
+// to be larger than n bytes. The limit is therefore enforced
+// by whatever consumes the reader, e.g. StreamSHA256Equals or
+// a decoder, rather than by the transformation itself.
+//
+// A negative n is reported as an error wrapping
+// ErrInvalidRule. Options such as WithMessage can be provided
+// as the last parameters.
+//
+// Example:
+//
+//	body := valtra.Val[io.Reader](r.Body, "body").Transform(valtra.MaxBytes(10 << 20))
+//	err := json.NewDecoder(body.Value()).Decode(&payload) // fails for bodies over 10 MiB
+func MaxBytes(n int64, opts ...Option) func(Value[io.Reader]) (io.Reader, error) {
+	if n < 0 {
+		invalid := invalidRule[io.Reader]("MaxBytes has a negative limit (%d)", n)
+		return func(v Value[io.Reader]) (io.Reader, error) {
+			return nil, invalid(v)
+		}
+	}
+
+	return func(v Value[io.Reader]) (io.Reader, error) {
+		return &maxBytesReader{v: v, remaining: n, max: n, opts: opts}, nil
+	}
+}
+
+// maxBytesReader is the reader returned by MaxBytes.
+type maxBytesReader struct {
+	v         Value[io.Reader]
+	remaining int64
+	max       int64
+	opts      []Option
+	err       error
+}
+
+// Read reads from the underlying reader, reading one byte
+// past the limit to tell whether the stream exceeds it. The
+// comparison is arranged so that a limit of math.MaxInt64
+// cannot overflow.
+func (r *maxBytesReader) Read(p []byte) (int, error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.remaining < int64(len(p))-1 {
+		p = p[:r.remaining+1]
+	}
+
+	n, err := r.v.value.Read(p)
+	if int64(n) > r.remaining {
+		n = int(r.remaining)
+		r.err = newError(r.v, "too_large", map[string]any{"max": r.max}, r.opts)
+		err = r.err
+	}
+	r.remaining -= int64(n)
+
+	return n, err
+}
+
+// Lines returns an iterator that applies the schema to each
+// line of the reader, without buffering the whole input.
+//
+// Values are named "line" and positioned at their line, in
+// the file with the optional name (see Value.At). Line
+// endings are stripped. If reading fails, a final value holding
+// the read error is yielded.
+//
+// Example:
+//
+//	for line := range valtra.Lines(f, hostSchema, "hosts.txt") {
+//	    if !line.IsValid() {
+//	        fmt.Println(line.Err()) // hosts.txt:3: line must be a valid hostname
+//	    }
+//	}
+func Lines(r io.Reader, schema Schema[string], file ...string) iter.Seq[Value[string]] {
+	return func(yield func(Value[string]) bool) {
+		pos := Position{File: sourceName(file)}
+
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			pos.Line++
+			if !yield(schema.Apply(Val(scanner.Text(), "line").At(pos))) {
+				return
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			pos.Line++
+			v := Val("", "line").At(pos)
+			v.errs = append(v.errs, err)
+			yield(v)
+		}
+	}
+}
+
+// JSONLines returns an iterator that decodes each non-blank
+// line of the reader, such as newline-delimited JSON (NDJSON)
+// records, into a T and applies the schema to it.
+//
+// Values are named "record" and positioned as with Lines.
+// Lines that cannot be decoded yield a value with a "json"
+// error, and the schema is not applied to them.
+//
+// Example:
+//
+//	for event := range valtra.JSONLines(r.Body, eventSchema) {
+//	    if event.IsValid() {
+//	        store(event.Value())
+//	    }
+//	}
+func JSONLines[T any](r io.Reader, schema Schema[T], file ...string) iter.Seq[Value[T]] {
+	return func(yield func(Value[T]) bool) {
+		for line := range Lines(r, Schema[string]{}, file...) {
+			if line.IsValid() && strings.TrimSpace(line.value) == "" {
+				continue
+			}
+
+			v := Val(*new(T), "record").At(line.pos)
+			v.errs = line.errs
+			if line.IsValid() {
+				if err := json.Unmarshal([]byte(line.value), &v.value); err != nil {
+					v.errs = append(v.errs, newError(Val(line.value, "record").At(line.pos), "json", nil, nil))
+				} else {
+					v = schema.Apply(v)
+				}
+			}
+
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// CSVRecords returns an iterator that applies the schema to
+// each record of the CSV data read from r (as parsed by
+// encoding/csv, so records can span lines).
+//
+// Values are named "record" and positioned at the line the
+// record starts on, as with Lines. Records with the wrong
+// number of fields or invalid quoting yield a value with a
+// "csv" error, and the schema is not applied to them. If
+// reading fails, a final value holding the read error is
+// yielded.
+//
+// Example:
+//
+//	for row := range valtra.CSVRecords(f, rowSchema, "users.csv") {
+//	    row.Collect(c)
+//	}
+func CSVRecords(r io.Reader, schema Schema[[]string], file ...string) iter.Seq[Value[[]string]] {
+	return func(yield func(Value[[]string]) bool) {
+		pos := Position{File: sourceName(file)}
+
+		cr := csv.NewReader(r)
+		for {
+			record, err := cr.Read()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			var parseErr *csv.ParseError
+			switch {
+			case errors.As(err, &parseErr):
+				pos.Line = parseErr.StartLine
+			case err == nil:
+				pos.Line, _ = cr.FieldPos(0)
+			}
+
+			v := Val(record, "record").At(pos)
+			switch {
+			case parseErr != nil:
+				v.errs = append(v.errs, newError(v, "csv", map[string]any{"reason": parseErr.Err.Error()}, nil))
+			case err != nil:
+				v.errs = append(v.errs, err)
+			default:
+				v = schema.Apply(v)
+			}
+
+			if !yield(v) || (err != nil && parseErr == nil) {
+				return
+			}
+		}
+	}
+}
+
+// sourceName returns the optional file name passed to the
+// streaming iterators.
+func sourceName(file []string) string {
+	if len(file) > 0 {
+		return file[0]
+	}
+
+	return ""
+}
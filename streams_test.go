@@ -0,0 +1,215 @@
+package valtra_test
+
+import (
+	"errors"
+	"io"
+	"math"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestReadAndValidate(t *testing.T) {
+	t.Run("reader within limit is validated", func(t *testing.T) {
+		v := valtra.ReadAndValidate(strings.NewReader("hello"), 10, valtra.MinLengthSlice[byte](3))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+		if string(v.Value()) != "hello" {
+			t.Errorf("Expected 'hello', got %q", v.Value())
+		}
+	})
+
+	t.Run("validations apply to read data", func(t *testing.T) {
+		v := valtra.ReadAndValidate(strings.NewReader("hi"), 10, valtra.MinLengthSlice[byte](3))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for short data")
+		}
+	})
+
+	t.Run("reader above limit fails as too large", func(t *testing.T) {
+		v := valtra.ReadAndValidate(strings.NewReader("hello world"), 5)
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail for oversized reader")
+		}
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) || ve.Code != "too_large" {
+			t.Errorf("Expected too_large error, got %v", v.Errors()[0])
+		}
+	})
+
+	t.Run("read error is collected", func(t *testing.T) {
+		readErr := errors.New("connection reset")
+		v := valtra.ReadAndValidate(iotest.ErrReader(readErr), 5)
+		if len(v.Errors()) != 1 || !errors.Is(v.Errors()[0], readErr) {
+			t.Errorf("Expected read error, got %v", v.Errors())
+		}
+	})
+
+	t.Run("the largest limit does not overflow", func(t *testing.T) {
+		v := valtra.ReadAndValidate(strings.NewReader("hello"), math.MaxInt64)
+		if !v.IsValid() || string(v.Value()) != "hello" {
+			t.Errorf("Expected 'hello' to pass, got %q (errors: %v)", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("negative limits are invalid", func(t *testing.T) {
+		v := valtra.ReadAndValidate(strings.NewReader("hello"), -1)
+		if len(v.Errors()) != 1 || !errors.Is(v.Errors()[0], valtra.ErrInvalidRule) {
+			t.Errorf("Expected ErrInvalidRule, got %v", v.Errors())
+		}
+	})
+}
+
+func TestStreamSHA256Equals(t *testing.T) {
+	// SHA-256 of "hello"
+	digest := "2cf24dba5fb0a30e26e83b2ac5b9e29e1b161e5c1fa7425e73043362938b9824"
+
+	t.Run("matching digest passes and copies data", func(t *testing.T) {
+		var dst strings.Builder
+		v := valtra.Val[io.Reader](strings.NewReader("hello")).Validate(valtra.StreamSHA256Equals(&dst, digest))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+		if dst.String() != "hello" {
+			t.Errorf("Expected data to be copied, got %q", dst.String())
+		}
+	})
+
+	t.Run("digest comparison ignores case", func(t *testing.T) {
+		v := valtra.Val[io.Reader](strings.NewReader("hello")).Validate(valtra.StreamSHA256Equals(io.Discard, strings.ToUpper(digest)))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("mismatched digest fails", func(t *testing.T) {
+		v := valtra.Val[io.Reader](strings.NewReader("tampered")).Validate(valtra.StreamSHA256Equals(io.Discard, digest))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for mismatched digest")
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Upload is corrupted"
+		v := valtra.Val[io.Reader](strings.NewReader("tampered")).Validate(valtra.StreamSHA256Equals(io.Discard, digest, valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestMaxBytes(t *testing.T) {
+	t.Run("streams within the limit pass through", func(t *testing.T) {
+		v := valtra.Val[io.Reader](strings.NewReader("hello"), "body").Transform(valtra.MaxBytes(5))
+		data, err := io.ReadAll(v.Value())
+		if err != nil || string(data) != "hello" {
+			t.Errorf("Unexpected result %q (error: %v)", data, err)
+		}
+	})
+
+	t.Run("larger streams fail when read", func(t *testing.T) {
+		v := valtra.Val[io.Reader](strings.NewReader("hello world"), "body").Transform(valtra.MaxBytes(5))
+		data, err := io.ReadAll(v.Value())
+
+		var ve *valtra.ValidationError
+		if !errors.As(err, &ve) || ve.Code != "too_large" || len(data) != 5 {
+			t.Errorf("Expected too_large after 5 bytes, got %q (error: %v)", data, err)
+		}
+	})
+
+	t.Run("the largest limit does not overflow", func(t *testing.T) {
+		v := valtra.Val[io.Reader](strings.NewReader("hello"), "body").Transform(valtra.MaxBytes(math.MaxInt64))
+		data, err := io.ReadAll(v.Value())
+		if err != nil || string(data) != "hello" {
+			t.Errorf("Unexpected result %q (error: %v)", data, err)
+		}
+	})
+
+	t.Run("negative limits are invalid", func(t *testing.T) {
+		v := valtra.Val[io.Reader](strings.NewReader("hello"), "body").Transform(valtra.MaxBytes(-1))
+		if v.IsValid() || !errors.Is(v.Errors()[0], valtra.ErrInvalidRule) {
+			t.Errorf("Expected ErrInvalidRule, got %v", v.Errors())
+		}
+	})
+}
+
+func TestLines(t *testing.T) {
+	schema := valtra.NewSchema[string]().Validate(valtra.Hostname())
+
+	var errs []string
+	count := 0
+	for line := range valtra.Lines(strings.NewReader("example.com\nnot a host\r\napi.example.com\n"), schema, "hosts.txt") {
+		count++
+		for _, err := range line.Errors() {
+			errs = append(errs, err.Error())
+		}
+	}
+
+	if count != 3 || len(errs) != 1 || errs[0] != "hosts.txt:2: line must be a valid hostname" {
+		t.Errorf("Unexpected result: %d lines, errors %v", count, errs)
+	}
+
+	t.Run("iteration can stop early", func(t *testing.T) {
+		count := 0
+		for range valtra.Lines(strings.NewReader("a\nb\nc\n"), valtra.NewSchema[string]()) {
+			count++
+			break
+		}
+		if count != 1 {
+			t.Errorf("Expected 1 line, got %d", count)
+		}
+	})
+}
+
+func TestJSONLines(t *testing.T) {
+	type event struct {
+		Name string `json:"name"`
+	}
+
+	schema := valtra.NewSchema[event]().Validate(
+		valtra.Field("name", func(e event) string { return e.Name }, valtra.Required[string]()),
+	)
+
+	var got []string
+	for v := range valtra.JSONLines(strings.NewReader("{\"name\":\"signup\"}\n\n{\"name\":\"\"}\nnot json\n"), schema, "events.ndjson") {
+		if v.IsValid() {
+			got = append(got, v.Value().Name)
+		} else {
+			got = append(got, v.Err().Error())
+		}
+	}
+
+	want := []string{"signup", "events.ndjson:3: name is required", "events.ndjson:4: record must be valid JSON"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestCSVRecords(t *testing.T) {
+	schema := valtra.NewSchema[[]string]().Validate(
+		valtra.Field("email", func(r []string) string { return r[1] }, valtra.Email()),
+	)
+
+	input := "1,bobby@example.com\n2,\"multi\nline\"\n3,x,extra\n4,alice@example.com\n"
+
+	var got []string
+	for v := range valtra.CSVRecords(strings.NewReader(input), schema, "users.csv") {
+		if v.IsValid() {
+			got = append(got, v.Value()[0])
+		} else {
+			got = append(got, v.Err().Error())
+		}
+	}
+
+	if len(got) != 4 || got[0] != "1" || got[1] != "users.csv:2: email must be in correct email format" ||
+		got[2] != "users.csv:4: record must be a valid CSV record (wrong number of fields)" || got[3] != "4" {
+		t.Errorf("Unexpected results: %q", got)
+	}
+}
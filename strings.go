@@ -0,0 +1,228 @@
+package valtra
+
+import (
+	"math/big"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// Alpha returns a validation that ensures the value is not
+// empty and consists of letters only.
+//
+// Letters from all scripts are allowed, so "Дончо" passes.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("Bobby").Validate(valtra.Alpha())
+func Alpha(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if v.value == "" || strings.IndexFunc(v.value, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 {
+			return newError(v, "alpha", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// Alphanumeric returns a validation that ensures the value is
+// not empty and consists of letters and digits only.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("bobby27").Validate(valtra.Alphanumeric())
+func Alphanumeric(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !isAlphanumeric(v.value) {
+			return newError(v, "alphanumeric", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// NumericString returns a validation that ensures the value
+// is not empty and consists of the ASCII digits 0-9 only.
+//
+// It is useful for codes such as PINs or postal codes, which
+// must keep their leading zeros. To parse numbers, use a
+// transformation instead.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("0042").Validate(valtra.NumericString())
+func NumericString(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if v.value == "" || strings.IndexFunc(v.value, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+			return newError(v, "numeric", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// decimalRegex matches plain decimal numbers, e.g. "-12.50".
+var decimalRegex = regexp.MustCompile(`^[+-]?[0-9]+(?:\.[0-9]+)?$`)
+
+// NumericStringRange returns a validation that ensures the
+// value is a decimal number (e.g. "-12.50") between min and
+// max (inclusive).
+//
+// Numbers are compared exactly using math/big rather than
+// floats, so it suits monetary amounts and other values sent
+// as strings to avoid precision loss. An empty min or max
+// leaves that side of the range unbounded. If min or max is
+// not a decimal number, or min is above max, the validation
+// always fails with an error wrapping ErrInvalidRule.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Amount).Validate(valtra.NumericStringRange("0.01", "10000.00"))
+func NumericStringRange(min, max string, opts ...Option) func(Value[string]) error {
+	lo, ok := parseDecimalBound(min)
+	if !ok {
+		return invalidRule[string]("NumericStringRange bound %q is not a decimal number", min)
+	}
+	hi, ok := parseDecimalBound(max)
+	if !ok {
+		return invalidRule[string]("NumericStringRange bound %q is not a decimal number", max)
+	}
+	if lo != nil && hi != nil && lo.Cmp(hi) > 0 {
+		return invalidRule[string]("NumericStringRange has a minimum (%s) above its maximum (%s)", min, max)
+	}
+
+	return func(v Value[string]) error {
+		if !decimalRegex.MatchString(v.value) {
+			return newError(v, "decimal", nil, opts)
+		}
+
+		n, _ := new(big.Rat).SetString(v.value)
+		if (lo != nil && n.Cmp(lo) < 0) || (hi != nil && n.Cmp(hi) > 0) {
+			return newError(v, "decimal_range", map[string]any{"min": min, "max": max}, opts)
+		}
+
+		return nil
+	}
+}
+
+// parseDecimalBound parses a bound of NumericStringRange,
+// returning nil for an empty (unbounded) bound, or false if
+// the bound is not a decimal number.
+func parseDecimalBound(bound string) (*big.Rat, bool) {
+	if bound == "" {
+		return nil, true
+	}
+	if !decimalRegex.MatchString(bound) {
+		return nil, false
+	}
+
+	n, _ := new(big.Rat).SetString(bound)
+	return n, true
+}
+
+// ASCII returns a validation that ensures the value consists
+// of ASCII characters only.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("hello").Validate(valtra.ASCII())
+func ASCII(opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		for i := 0; i < len(v.value); i++ {
+			if v.value[i] > unicode.MaxASCII {
+				return newError(v, "ascii", nil, opts)
+			}
+		}
+
+		return nil
+	}
+}
+
+// Contains returns a validation that ensures the value
+// contains the given substring.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("hello world").Validate(valtra.Contains("world"))
+func Contains(substr string, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !strings.Contains(v.value, substr) {
+			return newError(v, "contains", map[string]any{"substr": substr}, opts)
+		}
+
+		return nil
+	}
+}
+
+// HasPrefix returns a validation that ensures the value
+// begins with the given prefix.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("sk_live_123").Validate(valtra.HasPrefix("sk_"))
+func HasPrefix(prefix string, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !strings.HasPrefix(v.value, prefix) {
+			return newError(v, "has_prefix", map[string]any{"prefix": prefix}, opts)
+		}
+
+		return nil
+	}
+}
+
+// HasSuffix returns a validation that ensures the value
+// ends with the given suffix.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("photo.png").Validate(valtra.HasSuffix(".png"))
+func HasSuffix(suffix string, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !strings.HasSuffix(v.value, suffix) {
+			return newError(v, "has_suffix", map[string]any{"suffix": suffix}, opts)
+		}
+
+		return nil
+	}
+}
+
+// isAlphanumeric reports whether s is not empty and consists
+// of Unicode letters and digits only. ASCII strings, the
+// common case, are checked byte by byte.
+func isAlphanumeric(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c > unicode.MaxASCII {
+			return strings.IndexFunc(s[i:], func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) < 0
+		}
+		if !isASCIILetter(c) && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return s != ""
+}
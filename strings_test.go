@@ -0,0 +1,180 @@
+package valtra_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestAlpha(t *testing.T) {
+	t.Run("letters pass", func(t *testing.T) {
+		v := valtra.Val("Дончо").Validate(valtra.Alpha())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("digits fail", func(t *testing.T) {
+		v := valtra.Val("bobby27").Validate(valtra.Alpha())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for digits")
+		}
+	})
+
+	t.Run("empty string fails", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Alpha())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for empty string")
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Letters only"
+		v := valtra.Val("a b").Validate(valtra.Alpha(valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestAlphanumeric(t *testing.T) {
+	t.Run("letters and digits pass", func(t *testing.T) {
+		v := valtra.Val("bobby27").Validate(valtra.Alphanumeric())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("symbols fail", func(t *testing.T) {
+		v := valtra.Val("bobby_27").Validate(valtra.Alphanumeric())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for symbols")
+		}
+	})
+}
+
+func TestNumericString(t *testing.T) {
+	t.Run("digits pass", func(t *testing.T) {
+		v := valtra.Val("0042").Validate(valtra.NumericString())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("signs and decimals fail", func(t *testing.T) {
+		for _, s := range []string{"-1", "1.5", "", "٣"} {
+			if valtra.Val(s).Validate(valtra.NumericString()).IsValid() {
+				t.Errorf("Expected validation to fail for %q", s)
+			}
+		}
+	})
+}
+
+func TestNumericStringRange(t *testing.T) {
+	t.Run("amount in range passes", func(t *testing.T) {
+		v := valtra.Val("9999.99").Validate(valtra.NumericStringRange("0.01", "10000.00"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("comparison is exact", func(t *testing.T) {
+		v := valtra.Val("10000.000000000000000001").Validate(valtra.NumericStringRange("0", "10000"))
+		if v.IsValid() {
+			t.Error("Expected validation to fail just above the maximum")
+		}
+	})
+
+	t.Run("empty bound is unbounded", func(t *testing.T) {
+		v := valtra.Val("-123456789012345678901234567890").Validate(valtra.NumericStringRange("", "0"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("non-decimal value fails", func(t *testing.T) {
+		for _, s := range []string{"", "1e3", "1/3", "12.", "abc"} {
+			v := valtra.Val(s).Validate(valtra.NumericStringRange("", ""))
+			if v.IsValid() {
+				t.Errorf("Expected validation to fail for %q", s)
+			}
+		}
+	})
+
+	t.Run("invalid bound fails", func(t *testing.T) {
+		v := valtra.Val("5").Validate(valtra.NumericStringRange("1e3", ""))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid bound")
+		}
+	})
+}
+
+func TestASCII(t *testing.T) {
+	t.Run("ASCII passes", func(t *testing.T) {
+		v := valtra.Val("hello, world!").Validate(valtra.ASCII())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("non-ASCII fails", func(t *testing.T) {
+		v := valtra.Val("héllo").Validate(valtra.ASCII())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-ASCII")
+		}
+	})
+}
+
+func TestContains(t *testing.T) {
+	t.Run("substring present passes", func(t *testing.T) {
+		v := valtra.Val("hello world").Validate(valtra.Contains("world"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("substring missing fails", func(t *testing.T) {
+		v := valtra.Val("hello", "greeting").Validate(valtra.Contains("world"))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail for missing substring")
+		}
+		if v.Errors()[0].Error() != "greeting must contain world" {
+			t.Errorf("Unexpected error message: %q", v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestHasPrefix(t *testing.T) {
+	t.Run("prefix present passes", func(t *testing.T) {
+		v := valtra.Val("sk_live_123").Validate(valtra.HasPrefix("sk_"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("prefix missing fails", func(t *testing.T) {
+		v := valtra.Val("pk_live_123").Validate(valtra.HasPrefix("sk_"))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for missing prefix")
+		}
+	})
+}
+
+func TestHasSuffix(t *testing.T) {
+	t.Run("suffix present passes", func(t *testing.T) {
+		v := valtra.Val("photo.png").Validate(valtra.HasSuffix(".png"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("suffix missing fails", func(t *testing.T) {
+		v := valtra.Val("photo.exe").Validate(valtra.HasSuffix(".png"))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for missing suffix")
+		}
+	})
+}
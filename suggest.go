@@ -0,0 +1,75 @@
+package valtra
+
+import (
+	"reflect"
+	"strings"
+	"unicode/utf8"
+)
+
+// suggest returns the candidate closest to the input, for
+// "did you mean" hints, and whether one is close enough to be
+// worth suggesting.
+//
+// Candidates are compared case-insensitively by Levenshtein
+// distance, and are close enough if at most a third of their
+// runes (and at least one) would need to change. Ties go to
+// the earliest candidate.
+func suggest(input string, candidates []string) (string, bool) {
+	input = strings.ToLower(input)
+
+	best, bestDist := "", -1
+	for _, c := range candidates {
+		d := levenshtein(input, strings.ToLower(c))
+		if d > max(1, utf8.RuneCountInString(c)/3) {
+			continue
+		}
+		if bestDist < 0 || d < bestDist {
+			best, bestDist = c, d
+		}
+	}
+
+	return best, bestDist >= 0
+}
+
+// suggestValue returns a suggestion for a value from the given
+// allowed values, if the values are strings (or have a string
+// underlying type).
+func suggestValue[T any](value T, values []T) (string, bool) {
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.String {
+		return "", false
+	}
+
+	candidates := make([]string, len(values))
+	for i, v := range values {
+		candidates[i] = reflect.ValueOf(v).String()
+	}
+
+	return suggest(rv.String(), candidates)
+}
+
+// levenshtein returns the number of single-rune insertions,
+// deletions and substitutions needed to turn a into b.
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+
+	prev := make([]int, len(br)+1)
+	curr := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(br)]
+}
@@ -0,0 +1,427 @@
+package valtra
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stringTagRules maps the parameterless struct tag rules for
+// string fields to their validations.
+var stringTagRules = map[string]func(Value[string]) error{
+	"email":        Email(),
+	"url":          URL(),
+	"uri":          URI(),
+	"uuid":         UUID(),
+	"ulid":         ULID(),
+	"alpha":        Alpha(),
+	"alphanumeric": Alphanumeric(),
+	"numeric":      NumericString(),
+	"ascii":        ASCII(),
+	"hostname":     Hostname(),
+	"ip":           IP(),
+	"ipv4":         IPv4(),
+	"ipv6":         IPv6(),
+	"cidr":         CIDR(),
+	"mac":          MACAddress(),
+	"json":         JSON(),
+	"base64":       Base64(0),
+}
+
+// timeType is the reflect.Type of time.Time, which is treated
+// as a single value rather than a nested struct.
+var timeType = reflect.TypeFor[time.Time]()
+
+// ValidateStruct validates the fields of a struct (or a
+// pointer to one) according to their `valtra` struct tags,
+// and returns a Collector holding any errors.
+//
+// Tags hold comma-separated rules, which resolve to the
+// built-in validations:
+//
+//   - required: the field is not its zero value (or nil)
+//   - omitempty: skip the remaining rules if the field is empty
+//   - min=n, max=n: bounds for numbers, or the number of
+//     characters, elements or entries of strings, slices and maps
+//   - oneof=a b c: the field is one of the space-separated values
+//   - contains=s, startswith=s, endswith=s: string contents
+//   - email, url, uri, uuid, ulid, alpha, alphanumeric, numeric,
+//     ascii, hostname, ip, ipv4, ipv6, cidr, mac, json, base64:
+//     the respective string validations
+//
+// Any other rule is looked up in the registry (see Register
+// and Use), with rule packs' factories given the parameter.
+// Unknown rules and invalid parameters are reported as errors
+// in the collector.
+//
+// Fields are named after their json tag, or their Go name if
+// they have none. Nested structs are validated recursively,
+// with their fields named e.g. "address.city". Unexported
+// fields and fields tagged `valtra:"-"` are skipped.
+//
+// It eases migrating from tag-based validation packages, but
+// uses reflection, so the typed validations are faster.
+//
+// Example:
+//
+//	type User struct {
+//	    Email string `json:"email" valtra:"required,email"`
+//	    Age   int    `json:"age" valtra:"min=18,max=130"`
+//	}
+//
+//	if c := valtra.ValidateStruct(user); !c.IsValid() {
+//	    return c.Errors()
+//	}
+func ValidateStruct(s any) *Collector {
+	c := NewCollector()
+
+	seen := map[visit]bool{}
+	rv := reflect.ValueOf(s)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		seen[visit{rv.Pointer(), rv.Type()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		c.add("", fmt.Errorf("valtra: ValidateStruct expects a struct, got %T", s))
+		return c
+	}
+
+	validateStruct(c, rv, "", nil, seen)
+	return c
+}
+
+// visit identifies a struct reached through a pointer. The
+// type is part of it, since a struct and its first field share
+// an address.
+type visit struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// validateStruct validates the fields of the struct, prefixing
+// their names with the given prefix. The rules of each field
+// are read from its tag, or, if rules is not nil, from rules by
+// the field's name. Pointers being followed are tracked in
+// seen, so cyclic values are only validated once.
+func validateStruct(c *Collector, rv reflect.Value, prefix string, rules map[string]string, seen map[visit]bool) {
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		tag := sf.Tag.Get("valtra")
+		if !sf.IsExported() || tag == "-" {
+			continue
+		}
+
+		name := prefix + structFieldName(sf)
+		if rules != nil {
+			tag = rules[name]
+		}
+		fv := rv.Field(i)
+		if tag != "" {
+			validateField(c, fv, name, tag)
+		}
+
+		var followed []visit
+		for fv.Kind() == reflect.Pointer && !fv.IsNil() {
+			followed = append(followed, visit{fv.Pointer(), fv.Type()})
+			fv = fv.Elem()
+		}
+		if fv.Kind() != reflect.Struct || fv.Type() == timeType || slices.ContainsFunc(followed, func(p visit) bool { return seen[p] }) {
+			continue
+		}
+
+		for _, p := range followed {
+			seen[p] = true
+		}
+		if sf.Anonymous && tag == "" {
+			validateStruct(c, fv, prefix, rules, seen)
+		} else {
+			validateStruct(c, fv, name+".", rules, seen)
+		}
+		for _, p := range followed {
+			delete(seen, p)
+		}
+	}
+}
+
+// structFieldName returns the name used for the field in
+// error messages.
+func structFieldName(sf reflect.StructField) string {
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+
+	return sf.Name
+}
+
+// validateField applies the rules of the tag to the field.
+func validateField(c *Collector, fv reflect.Value, name string, tag string) {
+	for rule := range strings.SplitSeq(tag, ",") {
+		rule, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+
+		switch rule {
+		case "":
+			continue
+		case "omitempty":
+			if fv.IsZero() {
+				return
+			}
+			continue
+		case "required":
+			if fv.IsZero() {
+				c.add(name, newError(Value[any]{value: fv.Interface(), name: name}, "required", nil, nil))
+				return
+			}
+			continue
+		}
+
+		// Rules other than required cannot apply to nil pointers
+		v := fv
+		for v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				return
+			}
+			v = v.Elem()
+		}
+
+		if err := applyTagRule(v, name, rule, param); err != nil {
+			c.add(name, err)
+		}
+	}
+}
+
+// applyTagRule applies a single tag rule with its parameter to
+// the field.
+func applyTagRule(fv reflect.Value, name, rule, param string) error {
+	if fn, ok := stringTagRules[rule]; ok && fv.Kind() == reflect.String {
+		return fn(Val(fv.String(), name))
+	}
+
+	switch rule {
+	case "min", "max":
+		return applyBoundTag(fv, name, rule, param)
+	case "oneof":
+		return applyOneOfTag(fv, name, param)
+	case "contains", "startswith", "endswith":
+		if fv.Kind() != reflect.String {
+			break
+		}
+
+		v := Val(fv.String(), name)
+		switch rule {
+		case "contains":
+			return Contains(param)(v)
+		case "startswith":
+			return HasPrefix(param)(v)
+		default:
+			return HasSuffix(param)(v)
+		}
+	}
+
+	if registered, ok := lookupAny(rule); ok {
+		if factory, ok := registered.(RuleFactory); ok {
+			var err error
+			if registered, err = factory(param); err != nil {
+				return fmt.Errorf("valtra: invalid %s parameter %q for field %s: %w", rule, param, name, err)
+			}
+		}
+		if ok, err := applyRegisteredTag(registered, fv, name); ok {
+			err, _ = SplitWarnings(err)
+			return err
+		}
+
+		return fmt.Errorf("valtra: rule %q cannot be applied to field %s of type %s", rule, name, fv.Type())
+	}
+
+	return fmt.Errorf("valtra: unknown rule %q for field %s of type %s", rule, name, fv.Type())
+}
+
+// applyBoundTag applies a min or max tag rule to the field.
+func applyBoundTag(fv reflect.Value, name, rule, param string) error {
+	invalid := fmt.Errorf("valtra: invalid %s parameter %q for field %s", rule, param, name)
+
+	switch fv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(param, 10, 64)
+		if err != nil {
+			return invalid
+		}
+		return bound(Val(fv.Int(), name), rule, n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(param, 10, 64)
+		if err != nil {
+			return invalid
+		}
+		return bound(Val(fv.Uint(), name), rule, n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return invalid
+		}
+		return bound(Val(fv.Float(), name), rule, n)
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return invalid
+		}
+
+		if fv.Kind() == reflect.String {
+			if rule == "min" {
+				return MinRunes(n)(Val(fv.String(), name))
+			}
+			return MaxRunes(n)(Val(fv.String(), name))
+		}
+
+		v := Value[any]{value: fv.Interface(), name: name}
+		if rule == "min" && fv.Len() < n {
+			return newError(v, "min_length", map[string]any{"min": n}, nil)
+		}
+		if rule == "max" && fv.Len() > n {
+			return newError(v, "max_length", map[string]any{"max": n}, nil)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("valtra: rule %q cannot be applied to field %s of type %s", rule, name, fv.Type())
+}
+
+// bound applies Min or Max, depending on the rule, to the
+// value.
+func bound[T Ordered](v Value[T], rule string, n T) error {
+	if rule == "min" {
+		return Min(n)(v)
+	}
+
+	return Max(n)(v)
+}
+
+// applyOneOfTag applies a oneof tag rule to the field.
+func applyOneOfTag(fv reflect.Value, name, param string) error {
+	options := strings.Fields(param)
+
+	switch fv.Kind() {
+	case reflect.String:
+		return OneOf(options)(Val(fv.String(), name))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		values := make([]int64, len(options))
+		for i, o := range options {
+			n, err := strconv.ParseInt(o, 10, 64)
+			if err != nil {
+				return fmt.Errorf("valtra: invalid oneof parameter %q for field %s", param, name)
+			}
+			values[i] = n
+		}
+		return OneOf(values)(Val(fv.Int(), name))
+	}
+
+	return fmt.Errorf("valtra: rule \"oneof\" cannot be applied to field %s of type %s", name, fv.Type())
+}
+
+// applyRegisteredTag applies a registered rule to the field,
+// reporting whether the rule validates values of the field's
+// type.
+//
+// Rules for string, int, int64, float64 and bool values also
+// apply to fields of named types with those underlying kinds.
+func applyRegisteredTag(rule any, fv reflect.Value, name string) (bool, error) {
+	switch fn := rule.(type) {
+	case func(Value[string]) error:
+		if fv.Kind() == reflect.String {
+			return true, fn(Val(fv.String(), name))
+		}
+	case func(Value[int]) error:
+		if fv.Kind() == reflect.Int {
+			return true, fn(Val(int(fv.Int()), name))
+		}
+	case func(Value[int64]) error:
+		if fv.Kind() == reflect.Int64 {
+			return true, fn(Val(fv.Int(), name))
+		}
+	case func(Value[float64]) error:
+		if fv.Kind() == reflect.Float64 {
+			return true, fn(Val(fv.Float(), name))
+		}
+	case func(Value[bool]) error:
+		if fv.Kind() == reflect.Bool {
+			return true, fn(Val(fv.Bool(), name))
+		}
+	case func(Value[time.Time]) error:
+		if t, ok := fv.Interface().(time.Time); ok {
+			return true, fn(Val(t, name))
+		}
+	}
+
+	return false, nil
+}
+
+// TagRule is a single rule of a `valtra` struct tag, such as
+// "min=18".
+type TagRule struct {
+	Name  string
+	Param string
+	// HasParam reports whether the rule was written with an
+	// "=", which distinguishes "min=" from "min".
+	HasParam bool
+}
+
+// String formats the rule as it is written in tags.
+func (r TagRule) String() string {
+	if !r.HasParam {
+		return r.Name
+	}
+
+	return r.Name + "=" + r.Param
+}
+
+// ParseTag parses the rules of a `valtra` struct tag, as
+// ValidateStruct reads them: rules are separated by commas,
+// and whitespace around them, as well as empty rules, are
+// ignored.
+//
+// Example:
+//
+//	valtra.ParseTag("required, min=18") // [required min=18]
+func ParseTag(tag string) []TagRule {
+	var rules []TagRule
+	for rule := range strings.SplitSeq(tag, ",") {
+		name, param, hasParam := strings.Cut(strings.TrimSpace(rule), "=")
+		if name == "" {
+			continue
+		}
+
+		rules = append(rules, TagRule{Name: name, Param: param, HasParam: hasParam})
+	}
+
+	return rules
+}
+
+// FormatTag returns the canonical form of a `valtra` struct
+// tag, so that tags written by hand and by generators can be
+// normalised and compared: whitespace that ValidateStruct
+// ignores is removed, as are empty rules, and the values of
+// oneof rules are separated by single spaces. The rules, and
+// what they mean, are left unchanged, and formatting a
+// canonical tag returns it as it is.
+//
+// Example:
+//
+//	valtra.FormatTag(" required,, oneof=a   b ,min=1") // "required,oneof=a b,min=1"
+func FormatTag(tag string) string {
+	if strings.TrimSpace(tag) == "-" {
+		return "-"
+	}
+
+	rules := ParseTag(tag)
+	formatted := make([]string, len(rules))
+	for i, r := range rules {
+		if r.Name == "oneof" {
+			r.Param = strings.Join(strings.Fields(r.Param), " ")
+		}
+		formatted[i] = r.String()
+	}
+
+	return strings.Join(formatted, ",")
+}
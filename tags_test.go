@@ -0,0 +1,184 @@
+package valtra_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+type tagAddress struct {
+	City    string `json:"city" valtra:"required"`
+	Country string `json:"country" valtra:"oneof=BG GB DE"`
+}
+
+type tagUser struct {
+	Email    string      `json:"email" valtra:"required,email"`
+	Age      int         `json:"age" valtra:"min=18,max=130"`
+	Name     string      `valtra:"min=3,max=5"`
+	Tags     []string    `json:"tags" valtra:"max=2"`
+	Website  string      `json:"website" valtra:"omitempty,url"`
+	Nickname *string     `json:"nickname" valtra:"min=2"`
+	Address  tagAddress  `json:"address"`
+	Billing  *tagAddress `json:"billing"`
+	internal string      `valtra:"required"`
+}
+
+func validTagUser() tagUser {
+	return tagUser{
+		Email:   "bobby@example.com",
+		Age:     28,
+		Name:    "Дончо",
+		Tags:    []string{"go"},
+		Address: tagAddress{City: "Sofia", Country: "BG"},
+	}
+}
+
+// codes returns the field and code of each validation error
+// in the collector, e.g. "age:min".
+func codes(c *valtra.Collector) []string {
+	var codes []string
+	for _, err := range c.Errors() {
+		var ve *valtra.ValidationError
+		if errors.As(err, &ve) {
+			codes = append(codes, ve.Field+":"+ve.Code)
+		} else {
+			codes = append(codes, err.Error())
+		}
+	}
+
+	return codes
+}
+
+func TestValidateStruct(t *testing.T) {
+	t.Run("valid struct passes", func(t *testing.T) {
+		u := validTagUser()
+		if c := valtra.ValidateStruct(&u); !c.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", c.Errors())
+		}
+	})
+
+	t.Run("failing fields are reported", func(t *testing.T) {
+		nickname := "b"
+		u := validTagUser()
+		u.Email = "bobby"
+		u.Age = 16
+		u.Name = "Bo"
+		u.Tags = []string{"a", "b", "c"}
+		u.Website = "not a url"
+		u.Nickname = &nickname
+		u.Address.City = ""
+		u.Billing = &tagAddress{City: "London", Country: "FR"}
+
+		got := strings.Join(codes(valtra.ValidateStruct(u)), " ")
+		expected := "email:email age:min Name:min_length tags:max_length website:url nickname:min_length address.city:required billing.country:one_of"
+		if got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("required stops further rules", func(t *testing.T) {
+		u := validTagUser()
+		u.Email = ""
+
+		got := codes(valtra.ValidateStruct(u))
+		if len(got) != 1 || got[0] != "email:required" {
+			t.Errorf("Expected [email:required], got %v", got)
+		}
+	})
+
+	t.Run("registered rules are resolved", func(t *testing.T) {
+		valtra.Register("test-even", func(v valtra.Value[int]) error {
+			if v.Value()%2 != 0 {
+				return errors.New(v.Name() + " must be even")
+			}
+			return nil
+		})
+
+		type order struct {
+			Quantity int `json:"quantity" valtra:"test-even"`
+		}
+
+		c := valtra.ValidateStruct(order{Quantity: 3})
+		if c.IsValid() || c.Errors()[0].Error() != "quantity must be even" {
+			t.Errorf("Expected registered rule to fail, got %v", c.Errors())
+		}
+	})
+
+	t.Run("unknown rule is reported", func(t *testing.T) {
+		type order struct {
+			Quantity int `valtra:"bogus"`
+		}
+
+		c := valtra.ValidateStruct(order{})
+		if c.IsValid() || !strings.Contains(c.Errors()[0].Error(), `unknown rule "bogus"`) {
+			t.Errorf("Expected unknown rule error, got %v", c.Errors())
+		}
+	})
+
+	t.Run("non-struct is reported", func(t *testing.T) {
+		if c := valtra.ValidateStruct(5); c.IsValid() {
+			t.Error("Expected error for non-struct")
+		}
+	})
+
+	t.Run("cyclic values are validated once", func(t *testing.T) {
+		type node struct {
+			Name string `json:"name" valtra:"required"`
+			Next *node  `json:"next"`
+		}
+
+		n := &node{}
+		n.Next = n
+		shared := &node{Name: "x"}
+		pair := struct {
+			A *node `json:"a"`
+			B *node `json:"b"`
+		}{shared, shared}
+
+		if c := valtra.ValidateStruct(n); len(c.Errors()) != 1 {
+			t.Errorf("Expected a single error, got %v", c.Errors())
+		}
+		if c := valtra.ValidateStruct(&pair); !c.IsValid() {
+			t.Errorf("Expected shared values to pass, got %v", c.Errors())
+		}
+	})
+}
+
+func TestFormatTag(t *testing.T) {
+	tests := []struct {
+		tag  string
+		want string
+	}{
+		{"required,email", "required,email"},
+		{" required , min=18 ", "required,min=18"},
+		{"required,,omitempty,", "required,omitempty"},
+		{"oneof=admin   user  guest", "oneof=admin user guest"},
+		{"contains= x ", "contains= x"},
+		{"min=", "min="},
+		{" - ", "-"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			got := valtra.FormatTag(tt.tag)
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+			if again := valtra.FormatTag(got); again != got {
+				t.Errorf("Expected formatting to be idempotent, got %q", again)
+			}
+		})
+	}
+
+	t.Run("rules are parsed as written", func(t *testing.T) {
+		rules := valtra.ParseTag("required, min=18,min=")
+		want := []valtra.TagRule{{Name: "required"}, {Name: "min", Param: "18", HasParam: true}, {Name: "min", HasParam: true}}
+		if !reflect.DeepEqual(rules, want) {
+			t.Errorf("Expected %v, got %v", want, rules)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package valtra
+
+import (
+	"errors"
+	"math/rand/v2"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactPattern matches the names of fields that
+// commonly hold secrets or personal data. Capture redacts
+// these fields unless a different pattern is configured.
+var DefaultRedactPattern = regexp.MustCompile(`(?i)pass|secret|token|key|ssn|card|cvv|cvc|iban|account|email|phone|address|birth|dob`)
+
+// Redacted replaces the values of redacted fields in
+// captured snapshots, and classified values in error
+// messages.
+const Redacted = "[REDACTED]"
+
+// Capture configures sampled snapshots of payloads that fail
+// validation, so widespread client errors can be debugged
+// without logging personal data.
+//
+// A Capture is attached to a Collector with
+// Collector.Capture, and snapshots are emitted by
+// Collector.Report.
+type Capture struct {
+	// Rate is the fraction of rejected payloads to capture,
+	// from 0 (none) to 1 (all).
+	Rate float64
+	// RedactFields lists names of fields whose values are
+	// always redacted. An entry matches a field by its full
+	// name, its last segment ("email" matches "user.email"),
+	// or as a glob ("user.*").
+	RedactFields []string
+	// RedactPattern redacts fields whose names match it.
+	// DefaultRedactPattern is used if it is nil.
+	RedactPattern *regexp.Regexp
+	// Hook receives the captured snapshots.
+	Hook func(Snapshot)
+}
+
+// Snapshot is a redacted copy of a rejected payload.
+type Snapshot struct {
+	// Fields maps the names of all collected values to their
+	// (possibly redacted) values.
+	Fields map[string]any
+	// Errors holds the collected errors. The messages of
+	// errors for redacted fields leave their values out.
+	Errors []error
+}
+
+// capturedField is a value recorded by a Collector with a
+// Capture attached.
+type capturedField struct {
+	name  string
+	value any
+	class Classification
+}
+
+// redact reports whether the value of the named field must
+// be redacted.
+func (cp *Capture) redact(name string) bool {
+	last := name[strings.LastIndexByte(name, '.')+1:]
+	for _, field := range cp.RedactFields {
+		if matched, _ := path.Match(field, name); matched || field == name || field == last {
+			return true
+		}
+	}
+
+	pattern := cp.RedactPattern
+	if pattern == nil {
+		pattern = DefaultRedactPattern
+	}
+
+	return pattern.MatchString(name)
+}
+
+// snapshot builds a redacted snapshot of the given fields and
+// errors, which are owned by the fields named in owners.
+func (cp *Capture) snapshot(fields []capturedField, errs []error, owners []string) Snapshot {
+	s := Snapshot{
+		Fields: make(map[string]any, len(fields)),
+		Errors: make([]error, len(errs)),
+	}
+
+	redacted := map[string]bool{}
+	for _, f := range fields {
+		if f.class != ClassPublic || cp.redact(f.name) {
+			s.Fields[f.name] = Redacted
+			redacted[f.name] = true
+		} else {
+			s.Fields[f.name] = f.value
+		}
+	}
+
+	for i, err := range errs {
+		if redacted[owners[i]] || cp.redact(owners[i]) {
+			err = redactError(err)
+		}
+		s.Errors[i] = err
+	}
+
+	return s
+}
+
+// redactError returns the error with its messages rendered
+// without the value, as for redacted fields. The parameters
+// holding the value are replaced by Redacted, and errors they
+// hold, such as those of Or's alternatives, are redacted in
+// turn. Errors other than validation errors cannot be rendered
+// again, so their message is replaced by Redacted, while
+// errors.Is and errors.As still see the original.
+func redactError(err error) error {
+	switch e := err.(type) {
+	case interface{ Unwrap() []error }:
+		errs := e.Unwrap()
+		redacted := make([]error, len(errs))
+		for i, e := range errs {
+			redacted[i] = redactError(e)
+		}
+		return errors.Join(redacted...)
+	case *ValidationError:
+		r := *e
+		r.redacted, r.value = true, Redacted
+		if e.Params != nil {
+			r.Params = make(map[string]any, len(e.Params))
+			for k, p := range e.Params {
+				r.Params[k] = redactParam(k, p)
+			}
+			if errs, ok := r.Params["errors"].([]error); ok && r.rule == "or" {
+				r.Params["reasons"] = orReasons(errs)
+			}
+		}
+		if r.custom != "" {
+			r.Message = interpolate(r.custom, &r)
+		} else {
+			r.Message = render(&r, activeLocale())
+		}
+		return &r
+	default:
+		return redactedError{err}
+	}
+}
+
+// redactParam returns the parameter of a validation error for
+// redactError.
+func redactParam(key string, p any) any {
+	switch x := p.(type) {
+	case error:
+		return redactError(x)
+	case []error:
+		redacted := make([]error, len(x))
+		for i, err := range x {
+			redacted[i] = redactError(err)
+		}
+		return redacted
+	}
+
+	if isValueParam(key) {
+		return Redacted
+	}
+
+	return p
+}
+
+// redactedError hides the message of an error for a redacted
+// field.
+type redactedError struct{ err error }
+
+func (e redactedError) Error() string { return Redacted }
+func (e redactedError) Unwrap() error { return e.err }
+
+// sampled reports whether the current payload should be
+// captured.
+func (cp *Capture) sampled() bool {
+	return cp.Rate >= 1 || rand.Float64() < cp.Rate
+}
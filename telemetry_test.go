@@ -0,0 +1,138 @@
+package valtra_test
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestCapture(t *testing.T) {
+	t.Run("rejected payload is captured with redaction", func(t *testing.T) {
+		var snapshots []valtra.Snapshot
+		c := valtra.NewCollector().Capture(&valtra.Capture{
+			Rate:         1,
+			RedactFields: []string{"nickname"},
+			Hook:         func(s valtra.Snapshot) { snapshots = append(snapshots, s) },
+		})
+
+		valtra.Val("bobby@example.com", "email").Validate(valtra.Email()).Collect(c)
+		valtra.Val("hunter2", "password").Collect(c)
+		valtra.Val("bob", "nickname").Collect(c)
+		valtra.Val(15, "age").Validate(valtra.Min(18)).Collect(c)
+		c.Report()
+
+		if len(snapshots) != 1 {
+			t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+		}
+
+		s := snapshots[0]
+		if s.Fields["email"] != valtra.Redacted || s.Fields["password"] != valtra.Redacted || s.Fields["nickname"] != valtra.Redacted {
+			t.Errorf("Expected sensitive fields to be redacted, got %v", s.Fields)
+		}
+		if s.Fields["age"] != 15 {
+			t.Errorf("Expected age to be captured, got %v", s.Fields["age"])
+		}
+		if len(s.Errors) != 1 {
+			t.Errorf("Expected 1 error, got %v", s.Errors)
+		}
+	})
+
+	t.Run("custom redact pattern replaces the default", func(t *testing.T) {
+		var snapshot valtra.Snapshot
+		c := valtra.NewCollector().Capture(&valtra.Capture{
+			Rate:          1,
+			RedactPattern: regexp.MustCompile(`^age$`),
+			Hook:          func(s valtra.Snapshot) { snapshot = s },
+		})
+
+		valtra.Val("bobby@example.com", "email").Collect(c)
+		valtra.Val(15, "age").Validate(valtra.Min(18)).Collect(c)
+		c.Report()
+
+		if snapshot.Fields["email"] != "bobby@example.com" || snapshot.Fields["age"] != valtra.Redacted {
+			t.Errorf("Unexpected redaction: %v", snapshot.Fields)
+		}
+	})
+
+	t.Run("valid payload is not captured", func(t *testing.T) {
+		captured := false
+		c := valtra.NewCollector().Capture(&valtra.Capture{
+			Rate: 1,
+			Hook: func(s valtra.Snapshot) { captured = true },
+		})
+
+		valtra.Val(25, "age").Validate(valtra.Min(18)).Collect(c)
+		c.Report()
+
+		if captured {
+			t.Error("Expected valid payload not to be captured")
+		}
+	})
+
+	t.Run("zero rate captures nothing", func(t *testing.T) {
+		captured := false
+		c := valtra.NewCollector().Capture(&valtra.Capture{
+			Hook: func(s valtra.Snapshot) { captured = true },
+		})
+
+		valtra.Val(15, "age").Validate(valtra.Min(18)).Collect(c)
+		c.Report()
+
+		if captured {
+			t.Error("Expected no snapshot with zero rate")
+		}
+	})
+}
+
+func TestCaptureRedactsErrors(t *testing.T) {
+	var snapshot valtra.Snapshot
+	c := valtra.NewCollector().Capture(&valtra.Capture{
+		Rate:          1,
+		RedactFields:  []string{"nickname", "billing.*"},
+		RedactPattern: regexp.MustCompile(`^$`),
+		Hook:          func(s valtra.Snapshot) { snapshot = s },
+	})
+
+	leaky := valtra.WithMessage("{value} is too short")
+	valtra.Val("bobsecret", "nickname").Validate(valtra.MinLengthString(20, leaky)).Collect(c.WithPrefix("user"))
+	valtra.Val("1 Secret Lane", "street").Validate(valtra.MinLengthString(20, leaky)).Collect(c.WithPrefix("billing"))
+	valtra.Val("bobsecret", "alias").Validate(func(v valtra.Value[string]) error {
+		return errors.New(v.Value() + " is taken")
+	}).Classify(valtra.ClassPII).Collect(c)
+	type contact struct{ Phone, Email string }
+	valtra.Val(contact{Phone: "secret-phone", Email: "secret-email"}, "nickname").Validate(valtra.Or(
+		valtra.Field("phone", func(c contact) string { return c.Phone }, valtra.MinLengthString(20, leaky)),
+		valtra.Field("email", func(c contact) string { return c.Email }, valtra.MinLengthString(20, leaky)),
+	)).Collect(c)
+	valtra.Val("bob", "login").Validate(valtra.MinLengthString(20, leaky)).Collect(c)
+	c.Report()
+
+	if snapshot.Fields["user.nickname"] != valtra.Redacted || snapshot.Fields["billing.street"] != valtra.Redacted {
+		t.Errorf("Expected prefixed fields to be redacted, got %v", snapshot.Fields)
+	}
+
+	for _, err := range snapshot.Errors {
+		if msg := err.Error(); strings.Contains(msg, "secret") || strings.Contains(msg, "Secret") {
+			t.Errorf("Expected the value to be left out, got %q", msg)
+		}
+	}
+
+	var ve *valtra.ValidationError
+	if !errors.As(snapshot.Errors[len(snapshot.Errors)-2], &ve) || ve.Code != "or" {
+		t.Fatalf("Expected an or error, got %v", snapshot.Errors)
+	}
+	for _, err := range ve.Params["errors"].([]error) {
+		if msg := err.Error(); strings.Contains(msg, "secret") {
+			t.Errorf("Expected the value to be left out of alternatives, got %q", msg)
+		}
+	}
+	if got := snapshot.Errors[len(snapshot.Errors)-1].Error(); got != "bob is too short" {
+		t.Errorf("Expected errors of other fields to be unchanged, got %q", got)
+	}
+	if !errors.Is(snapshot.Errors[0], &valtra.ValidationError{Code: "min_length"}) {
+		t.Errorf("Expected redacted errors to keep their code, got %v", snapshot.Errors[0])
+	}
+}
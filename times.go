@@ -0,0 +1,170 @@
+package valtra
+
+import "time"
+
+// Before returns a validation that ensures the value is
+// strictly before t.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.DateOfBirth).Validate(valtra.Before(time.Now()))
+func Before(t time.Time, opts ...Option) func(Value[time.Time]) error {
+	return func(v Value[time.Time]) error {
+		if !v.value.Before(t) {
+			return newError(v, "before", map[string]any{"before": t}, opts)
+		}
+
+		return nil
+	}
+}
+
+// After returns a validation that ensures the value is
+// strictly after t.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.StartsAt).Validate(valtra.After(time.Now()))
+func After(t time.Time, opts ...Option) func(Value[time.Time]) error {
+	return func(v Value[time.Time]) error {
+		if !v.value.After(t) {
+			return newError(v, "after", map[string]any{"after": t}, opts)
+		}
+
+		return nil
+	}
+}
+
+// BetweenTime returns a validation that ensures the value is
+// between start and end (inclusive). If start is after end,
+// it fails every value with an error wrapping ErrInvalidRule.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Appointment).Validate(valtra.BetweenTime(opening, closing))
+func BetweenTime(start, end time.Time, opts ...Option) func(Value[time.Time]) error {
+	if start.After(end) {
+		return invalidRule[time.Time]("BetweenTime has a start (%s) after its end (%s)", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+
+	return func(v Value[time.Time]) error {
+		if v.value.Before(start) || v.value.After(end) {
+			return newError(v, "between_time", map[string]any{"start": start, "end": end}, opts)
+		}
+
+		return nil
+	}
+}
+
+// NotZeroTime returns a validation that ensures the value is
+// not the zero time, which is what an unset time.Time field
+// holds.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.ScheduledAt).Validate(valtra.NotZeroTime())
+func NotZeroTime(opts ...Option) func(Value[time.Time]) error {
+	return func(v Value[time.Time]) error {
+		if v.value.IsZero() {
+			return newError(v, "required", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// DateFormat returns a validation that ensures the value can
+// be parsed as a time using the given layout (see
+// time.Parse).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.DateOfBirth).Validate(valtra.DateFormat(time.DateOnly))
+func DateFormat(layout string, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if _, err := time.Parse(layout, v.value); err != nil {
+			return newError(v, "date_format", map[string]any{"layout": layout}, opts)
+		}
+
+		return nil
+	}
+}
+
+// WithinDailyWindow returns a validation that ensures the
+// value falls inside a recurring daily window of local time
+// in the given location, e.g. delivery slots from "08:00" to
+// "20:00" in Europe/Sofia.
+//
+// Start and end are given as "15:04" or "15:04:05". The start
+// is inclusive and the end exclusive, and a window whose end
+// is before its start spans midnight (e.g. "22:00" to
+// "06:00"). The value is compared by its wall-clock time in
+// the location, so windows stay correct across daylight
+// saving time changes. A malformed start or end, an empty
+// window (start equal to end), or a nil location, makes it an
+// invalid rule (see ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	sofia, _ := time.LoadLocation("Europe/Sofia")
+//	valtra.Val(input.DeliveryAt).Validate(valtra.WithinDailyWindow("08:00", "20:00", sofia))
+func WithinDailyWindow(start, end string, loc *time.Location, opts ...Option) func(Value[time.Time]) error {
+	from, fromOK := parseClock(start)
+	to, toOK := parseClock(end)
+	switch {
+	case !fromOK:
+		return invalidRule[time.Time]("WithinDailyWindow has an invalid start %q", start)
+	case !toOK:
+		return invalidRule[time.Time]("WithinDailyWindow has an invalid end %q", end)
+	case from == to:
+		return invalidRule[time.Time]("WithinDailyWindow has an empty window (%s to %s)", start, end)
+	case loc == nil:
+		return invalidRule[time.Time]("WithinDailyWindow has no location")
+	}
+
+	return func(v Value[time.Time]) error {
+		h, m, sec := v.value.In(loc).Clock()
+		clock := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(sec)*time.Second
+
+		within := clock >= from && clock < to
+		if to < from {
+			within = clock >= from || clock < to
+		}
+		if !within {
+			return newError(v, "daily_window", map[string]any{"start": start, "end": end, "location": loc}, opts)
+		}
+
+		return nil
+	}
+}
+
+// parseClock parses a time of day given as "15:04" or
+// "15:04:05", returning it as the duration since midnight,
+// and whether it is valid.
+func parseClock(clock string) (time.Duration, bool) {
+	for _, layout := range []string{"15:04", "15:04:05"} {
+		if t, err := time.Parse(layout, clock); err == nil {
+			h, m, s := t.Clock()
+			return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second, true
+		}
+	}
+
+	return 0, false
+}
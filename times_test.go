@@ -0,0 +1,181 @@
+package valtra_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+var (
+	noon     = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	midnight = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestBefore(t *testing.T) {
+	t.Run("earlier time passes", func(t *testing.T) {
+		v := valtra.Val(midnight).Validate(valtra.Before(noon))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("equal time fails", func(t *testing.T) {
+		v := valtra.Val(noon).Validate(valtra.Before(noon))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for equal time")
+		}
+	})
+
+	t.Run("error message formats the time", func(t *testing.T) {
+		v := valtra.Val(noon, "date of birth").Validate(valtra.Before(midnight))
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail")
+		}
+		expected := "date of birth must be before 2025-06-01T00:00:00Z"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestAfter(t *testing.T) {
+	t.Run("later time passes", func(t *testing.T) {
+		v := valtra.Val(noon).Validate(valtra.After(midnight))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("earlier time fails", func(t *testing.T) {
+		v := valtra.Val(midnight).Validate(valtra.After(noon))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for earlier time")
+		}
+	})
+}
+
+func TestBetweenTime(t *testing.T) {
+	t.Run("time in range passes", func(t *testing.T) {
+		v := valtra.Val(midnight.Add(time.Hour)).Validate(valtra.BetweenTime(midnight, noon))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("bounds are inclusive", func(t *testing.T) {
+		v := valtra.Val(noon).Validate(valtra.BetweenTime(midnight, noon))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("time out of range fails", func(t *testing.T) {
+		v := valtra.Val(noon.Add(time.Second)).Validate(valtra.BetweenTime(midnight, noon))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for time out of range")
+		}
+	})
+}
+
+func TestNotZeroTime(t *testing.T) {
+	t.Run("set time passes", func(t *testing.T) {
+		v := valtra.Val(noon).Validate(valtra.NotZeroTime())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("zero time fails", func(t *testing.T) {
+		v := valtra.Val(time.Time{}).Validate(valtra.NotZeroTime())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for zero time")
+		}
+	})
+}
+
+func TestDateFormat(t *testing.T) {
+	t.Run("matching date passes", func(t *testing.T) {
+		v := valtra.Val("1990-02-27").Validate(valtra.DateFormat(time.DateOnly))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("impossible date fails", func(t *testing.T) {
+		v := valtra.Val("1990-02-30").Validate(valtra.DateFormat(time.DateOnly))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for impossible date")
+		}
+	})
+
+	t.Run("wrong format fails", func(t *testing.T) {
+		v := valtra.Val("27/02/1990").Validate(valtra.DateFormat(time.DateOnly))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for wrong format")
+		}
+	})
+}
+
+func TestWithinDailyWindow(t *testing.T) {
+	sofia, err := time.LoadLocation("Europe/Sofia")
+	if err != nil {
+		t.Skipf("time zone database unavailable: %v", err)
+	}
+
+	deliverySlots := valtra.WithinDailyWindow("08:00", "20:00", sofia)
+
+	t.Run("time inside window passes", func(t *testing.T) {
+		v := valtra.Val(time.Date(2025, 6, 1, 8, 0, 0, 0, sofia)).Validate(deliverySlots)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("end is exclusive", func(t *testing.T) {
+		v := valtra.Val(time.Date(2025, 6, 1, 20, 0, 0, 0, sofia), "delivery").Validate(deliverySlots)
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail at the end of the window")
+		}
+		expected := "delivery must be between 08:00 and 20:00 (Europe/Sofia time)"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("time in another zone is converted", func(t *testing.T) {
+		// 05:30 UTC is 08:30 in Sofia in summer (UTC+3) and
+		// 07:30 in winter (UTC+2)
+		summer := valtra.Val(time.Date(2025, 7, 1, 5, 30, 0, 0, time.UTC)).Validate(deliverySlots)
+		if !summer.IsValid() {
+			t.Errorf("Expected summer validation to pass, got errors: %v", summer.Errors())
+		}
+
+		winter := valtra.Val(time.Date(2025, 1, 1, 5, 30, 0, 0, time.UTC)).Validate(deliverySlots)
+		if winter.IsValid() {
+			t.Error("Expected winter validation to fail")
+		}
+	})
+
+	t.Run("window spanning midnight", func(t *testing.T) {
+		night := valtra.WithinDailyWindow("22:00", "06:00", sofia)
+		for _, h := range []int{23, 0, 5} {
+			v := valtra.Val(time.Date(2025, 6, 1, h, 0, 0, 0, sofia)).Validate(night)
+			if !v.IsValid() {
+				t.Errorf("Expected validation to pass at %02d:00, got errors: %v", h, v.Errors())
+			}
+		}
+
+		v := valtra.Val(time.Date(2025, 6, 1, 12, 0, 0, 0, sofia)).Validate(night)
+		if v.IsValid() {
+			t.Error("Expected validation to fail at noon")
+		}
+	})
+
+	t.Run("malformed window fails", func(t *testing.T) {
+		v := valtra.Val(noon).Validate(valtra.WithinDailyWindow("8am", "20:00", time.UTC))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for malformed window")
+		}
+	})
+}
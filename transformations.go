@@ -1,6 +1,14 @@
 package valtra
 
-import "strings"
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
 
 // Uppercase returns a transformation that converts the
 // value to upper case.
@@ -49,3 +57,360 @@ func Capitalise() func(Value[string]) (string, error) {
 		return strings.ToUpper(v.value[:1]) + strings.ToLower(v.value[1:]), nil
 	}
 }
+
+// ParseInt returns a conversion that parses the value as a
+// base 10 integer, for use with Convert.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	page := valtra.Convert(valtra.Val(r.FormValue("page"), "page"), valtra.ParseInt()).Validate(valtra.Min(1))
+func ParseInt(opts ...Option) func(Value[string]) (int, error) {
+	return func(v Value[string]) (int, error) {
+		n, err := strconv.Atoi(v.value)
+		if err != nil {
+			return 0, newError(v, "int", nil, opts)
+		}
+
+		return n, nil
+	}
+}
+
+// ParseFloat returns a conversion that parses the value as a
+// floating-point number, for use with Convert.
+//
+// Infinities and NaN are rejected, as they are rarely valid
+// inputs.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	price := valtra.Convert(valtra.Val(r.FormValue("price"), "price"), valtra.ParseFloat()).Validate(valtra.Positive[float64]())
+func ParseFloat(opts ...Option) func(Value[string]) (float64, error) {
+	return func(v Value[string]) (float64, error) {
+		f, err := strconv.ParseFloat(v.value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return 0, newError(v, "float", nil, opts)
+		}
+
+		return f, nil
+	}
+}
+
+// ParseBool returns a conversion that parses the value as a
+// boolean, for use with Convert.
+//
+// It accepts the values accepted by strconv.ParseBool, e.g.
+// "true", "1", "false" and "0".
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	subscribe := valtra.Convert(valtra.Val(r.FormValue("subscribe"), "subscribe"), valtra.ParseBool())
+func ParseBool(opts ...Option) func(Value[string]) (bool, error) {
+	return func(v Value[string]) (bool, error) {
+		b, err := strconv.ParseBool(v.value)
+		if err != nil {
+			return false, newError(v, "bool", nil, opts)
+		}
+
+		return b, nil
+	}
+}
+
+// ParseTime returns a conversion that parses the value as a
+// time using the given layout (see time.Parse), for use with
+// Convert.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	dob := valtra.Convert(valtra.Val(r.FormValue("dob"), "date of birth"), valtra.ParseTime(time.DateOnly)).
+//	    Validate(valtra.Before(time.Now()))
+func ParseTime(layout string, opts ...Option) func(Value[string]) (time.Time, error) {
+	return func(v Value[string]) (time.Time, error) {
+		t, err := time.Parse(layout, v.value)
+		if err != nil {
+			return time.Time{}, newError(v, "date_format", map[string]any{"layout": layout}, opts)
+		}
+
+		return t, nil
+	}
+}
+
+// CollapseWhitespace returns a transformation that trims
+// leading and trailing white space and replaces every run of
+// white space inside the value with a single space.
+//
+// Example:
+//
+//	valtra.Val("  Bobby \t  Donev ").Transform(valtra.CollapseWhitespace())  // "Bobby Donev"
+func CollapseWhitespace() func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		return strings.Join(strings.Fields(v.value), " "), nil
+	}
+}
+
+// Truncate returns a transformation that shortens the value
+// to at most n characters (runes), without splitting
+// multi-byte characters.
+//
+// Example:
+//
+//	valtra.Val(input.Bio).Transform(valtra.Truncate(160))
+func Truncate(n int) func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		if utf8.RuneCountInString(v.value) <= n {
+			return v.value, nil
+		}
+
+		i := 0
+		for range n {
+			_, size := utf8.DecodeRuneInString(v.value[i:])
+			i += size
+		}
+
+		return v.value[:i], nil
+	}
+}
+
+// TitleCase returns a transformation that converts the first
+// letter of every word in the value to title case and the
+// remaining letters to lower case.
+//
+// Words are separated by any character that is not a letter,
+// digit or apostrophe, so "o'neil-smith" becomes
+// "O'neil-Smith".
+//
+// Example:
+//
+//	valtra.Val("bobby DONEV").Transform(valtra.TitleCase())  // "Bobby Donev"
+func TitleCase() func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		var b strings.Builder
+		b.Grow(len(v.value))
+
+		inWord := false
+		for _, r := range v.value {
+			switch {
+			case unicode.IsLetter(r) && !inWord:
+				b.WriteRune(unicode.ToTitle(r))
+			case unicode.IsLetter(r):
+				b.WriteRune(unicode.ToLower(r))
+			default:
+				b.WriteRune(r)
+			}
+			inWord = unicode.IsLetter(r) || unicode.IsDigit(r) || r == '\''
+		}
+
+		return b.String(), nil
+	}
+}
+
+// diacriticsFrom and diacriticsTo list Latin letters with
+// diacritics and, at the same rune positions, the letters
+// they decompose to.
+const (
+	diacriticsFrom = "ÀÁÂÃÄÅÇÈÉÊËÌÍÎÏÑÒÓÔÕÖÙÚÛÜÝàáâãäå" +
+		"çèéêëìíîïñòóôõöùúûüýÿĀāĂăĄąĆćĈĉĊ" +
+		"ċČčĎďĒēĔĕĖėĘęĚěĜĝĞğĠġĢģĤĥĨĩĪīĬĭĮ" +
+		"įİĴĵĶķĹĺĻļĽľŃńŅņŇňŌōŎŏŐőŔŕŖŗŘřŚś" +
+		"ŜŝŞşŠšŢţŤťŨũŪūŬŭŮůŰűŲųŴŵŶŷŸŹźŻżŽ" +
+		"žƠơƯưǍǎǏǐǑǒǓǔǕǖǗǘǙǚǛǜǞǟǠǡǦǧǨǩǪǫǬ" +
+		"ǭǰǴǵǸǹǺǻȀȁȂȃȄȅȆȇȈȉȊȋȌȍȎȏȐȑȒȓȔȕȖȗ" +
+		"ȘșȚțȞȟȦȧȨȩȪȫȬȭȮȯȰȱȲȳḀḁḂḃḄḅḆḇḈḉḊḋ" +
+		"ḌḍḎḏḐḑḒḓḔḕḖḗḘḙḚḛḜḝḞḟḠḡḢḣḤḥḦḧḨḩḪḫ" +
+		"ḬḭḮḯḰḱḲḳḴḵḶḷḸḹḺḻḼḽḾḿṀṁṂṃṄṅṆṇṈṉṊṋ" +
+		"ṌṍṎṏṐṑṒṓṔṕṖṗṘṙṚṛṜṝṞṟṠṡṢṣṤṥṦṧṨṩṪṫ" +
+		"ṬṭṮṯṰṱṲṳṴṵṶṷṸṹṺṻṼṽṾṿẀẁẂẃẄẅẆẇẈẉẊẋ" +
+		"ẌẍẎẏẐẑẒẓẔẕẖẗẘẙẠạẢảẤấẦầẨẩẪẫẬậẮắẰằ" +
+		"ẲẳẴẵẶặẸẹẺẻẼẽẾếỀềỂểỄễỆệỈỉỊịỌọỎỏỐố" +
+		"ỒồỔổỖỗỘộỚớỜờỞởỠỡỢợỤụỦủỨứỪừỬửỮữỰự" +
+		"ỲỳỴỵỶỷỸỹ"
+	diacriticsTo = "AAAAAACEEEEIIIINOOOOOUUUUYaaaaaa" +
+		"ceeeeiiiinooooouuuuyyAaAaAaCcCcC" +
+		"cCcDdEeEeEeEeEeGgGgGgGgHhIiIiIiI" +
+		"iIJjKkLlLlLlNnNnNnOoOoOoRrRrRrSs" +
+		"SsSsSsTtTtUuUuUuUuUuUuWwYyYZzZzZ" +
+		"zOoUuAaIiOoUuUuUuUuUuAaAaGgKkOoO" +
+		"ojGgNnAaAaAaEeEeIiIiOoOoRrRrUuUu" +
+		"SsTtHhAaEeOoOoOoOoYyAaBbBbBbCcDd" +
+		"DdDdDdDdEeEeEeEeEeFfGgHhHhHhHhHh" +
+		"IiIiKkKkKkLlLlLlLlMmMmMmNnNnNnNn" +
+		"OoOoOoOoPpPpRrRrRrRrSsSsSsSsSsTt" +
+		"TtTtTtUuUuUuUuUuVvVvWwWwWwWwWwXx" +
+		"XxYyZzZzZzhtwyAaAaAaAaAaAaAaAaAa" +
+		"AaAaAaEeEeEeEeEeEeEeEeIiIiOoOoOo" +
+		"OoOoOoOoOoOoOoOoOoUuUuUuUuUuUuUu" +
+		"YyYyYyYy"
+)
+
+// diacriticReplacements maps Latin letters with diacritics,
+// and letters such as "ß" that have no decomposition, to
+// their plain ASCII equivalents.
+var diacriticReplacements = sync.OnceValue(func() map[rune]string {
+	replacements := map[rune]string{
+		'ß': "ss", 'Æ': "AE", 'æ': "ae", 'Œ': "OE", 'œ': "oe",
+		'Ø': "O", 'ø': "o", 'Đ': "D", 'đ': "d", 'Ł': "L", 'ł': "l",
+		'Þ': "Th", 'þ': "th", 'Ħ': "H", 'ħ': "h", 'ı': "i",
+	}
+
+	to := []rune(diacriticsTo)
+	for i, r := range []rune(diacriticsFrom) {
+		replacements[r] = string(to[i])
+	}
+
+	return replacements
+})
+
+// RemoveDiacritics returns a transformation that replaces
+// Latin letters with diacritics by their plain equivalents
+// (e.g. "é" with "e" and "ß" with "ss") and removes combining
+// marks.
+//
+// Letters from other scripts are left unchanged.
+//
+// Example:
+//
+//	valtra.Val("Crème Brûlée").Transform(valtra.RemoveDiacritics())  // "Creme Brulee"
+func RemoveDiacritics() func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		return removeDiacritics(v.value), nil
+	}
+}
+
+// removeDiacritics implements RemoveDiacritics.
+func removeDiacritics(s string) string {
+	replacements := diacriticReplacements()
+
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if plain, ok := replacements[r]; ok {
+			b.WriteString(plain)
+		} else if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// Slugify returns a transformation that converts the value
+// into a URL-friendly slug, e.g. "Crème Brûlée Recipe!"
+// becomes "creme-brulee-recipe".
+//
+// Diacritics are removed, letters are lowercased, and every
+// run of characters other than letters and digits is replaced
+// with a single hyphen. Letters from non-Latin scripts are
+// kept.
+//
+// Example:
+//
+//	valtra.Val(input.Title).Transform(valtra.Slugify())
+func Slugify() func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		var b strings.Builder
+		b.Grow(len(v.value))
+
+		hyphen := false
+		for _, r := range removeDiacritics(v.value) {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				hyphen = b.Len() > 0
+				continue
+			}
+
+			if hyphen {
+				b.WriteByte('-')
+				hyphen = false
+			}
+			b.WriteRune(unicode.ToLower(r))
+		}
+
+		return b.String(), nil
+	}
+}
+
+// Sanitize returns a transformation that strips control
+// characters from the value, keeping tabs and newlines.
+//
+// Invisible bidirectional formatting characters, which can
+// be used to disguise text (e.g. file extensions), are
+// stripped as well.
+//
+// Example:
+//
+//	valtra.Val(input.Comment).Transform(valtra.Sanitize())
+func Sanitize() func(Value[string]) (string, error) {
+	return func(v Value[string]) (string, error) {
+		return strings.Map(func(r rune) rune {
+			if (unicode.IsControl(r) && r != '\t' && r != '\n') || unicode.Is(unicode.Bidi_Control, r) {
+				return -1
+			}
+
+			return r
+		}, v.value), nil
+	}
+}
+
+// AssertIdempotent returns a transformation that applies the
+// transformations in order, then applies them again to the
+// result and fails if that changes it.
+//
+// Normalisation should be idempotent: data that is stored,
+// loaded and normalised again must not keep changing, as it
+// would with e.g. double escaping. The two results are
+// available as the "first" and "second" parameters. An error
+// from a transformation in either pass is returned as is.
+//
+// It can guard a chain at runtime, or check it against sample
+// inputs in tests.
+//
+// Example:
+//
+//	normalise := valtra.AssertIdempotent(valtra.TrimSpace(), valtra.EscapeHTML())
+//	for _, sample := range []string{"a & b", " <b> "} {
+//	    if v := valtra.Val(sample, "bio").Transform(normalise); !v.IsValid() {
+//	        t.Error(v.Errors()[0]) // bio changes when normalised again
+//	    }
+//	}
+func AssertIdempotent[T comparable](transformations ...func(Value[T]) (T, error)) func(Value[T]) (T, error) {
+	apply := func(v Value[T]) (T, error) {
+		for _, fn := range transformations {
+			newVal, err := fn(v)
+			if err != nil {
+				return v.value, err
+			}
+			v.value = newVal
+		}
+
+		return v.value, nil
+	}
+
+	return func(v Value[T]) (T, error) {
+		first, err := apply(v)
+		if err != nil {
+			return first, err
+		}
+
+		again := v
+		again.value = first
+		second, err := apply(again)
+		if err != nil {
+			return first, err
+		}
+		if second != first {
+			return first, newError(v, "not_idempotent", map[string]any{"first": first, "second": second}, nil)
+		}
+
+		return first, nil
+	}
+}
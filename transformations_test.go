@@ -1,8 +1,10 @@
 package valtra_test
 
 import (
+	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/bobch27/valtra-go"
 )
@@ -80,3 +82,189 @@ func TestTransformWithError(t *testing.T) {
 		}
 	})
 }
+
+func TestParseInt(t *testing.T) {
+	t.Run("integer is parsed", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("-42"), valtra.ParseInt())
+		if !v.IsValid() || v.Value() != -42 {
+			t.Errorf("Expected -42, got %d with errors: %v", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("invalid integer fails with name", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("4.2", "page"), valtra.ParseInt())
+		if v.IsValid() {
+			t.Fatal("Expected conversion to fail")
+		}
+		if v.Errors()[0].Error() != "page must be a whole number" {
+			t.Errorf("Expected %q, got %q", "page must be a whole number", v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestParseFloat(t *testing.T) {
+	t.Run("number is parsed", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("4.25"), valtra.ParseFloat())
+		if !v.IsValid() || v.Value() != 4.25 {
+			t.Errorf("Expected 4.25, got %v with errors: %v", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("non-finite numbers fail", func(t *testing.T) {
+		for _, s := range []string{"NaN", "Inf", "-Inf", "1e400", "abc"} {
+			v := valtra.Convert(valtra.Val(s), valtra.ParseFloat())
+			if v.IsValid() {
+				t.Errorf("Expected conversion to fail for %q", s)
+			}
+		}
+	})
+}
+
+func TestParseBool(t *testing.T) {
+	t.Run("boolean is parsed", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("1"), valtra.ParseBool())
+		if !v.IsValid() || !v.Value() {
+			t.Errorf("Expected true, got %v with errors: %v", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("invalid boolean fails", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("yes"), valtra.ParseBool())
+		if v.IsValid() {
+			t.Error("Expected conversion to fail")
+		}
+	})
+}
+
+func TestParseTime(t *testing.T) {
+	t.Run("time is parsed", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("1990-02-27"), valtra.ParseTime(time.DateOnly))
+		if !v.IsValid() || v.Value() != time.Date(1990, 2, 27, 0, 0, 0, 0, time.UTC) {
+			t.Errorf("Expected 1990-02-27, got %v with errors: %v", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("invalid time fails", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("27/02/1990", "date of birth"), valtra.ParseTime(time.DateOnly))
+		if v.IsValid() {
+			t.Fatal("Expected conversion to fail")
+		}
+		expected := "date of birth must be a date in the format 2006-01-02"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestCollapseWhitespace(t *testing.T) {
+	t.Run("runs of white space are collapsed", func(t *testing.T) {
+		v := valtra.Val("  Bobby \t\n Donev ").Transform(valtra.CollapseWhitespace())
+		if v.Value() != "Bobby Donev" {
+			t.Errorf("Expected %q, got %q", "Bobby Donev", v.Value())
+		}
+	})
+}
+
+func TestTruncate(t *testing.T) {
+	t.Run("long string is truncated by characters", func(t *testing.T) {
+		v := valtra.Val("Дончо Донев").Transform(valtra.Truncate(5))
+		if v.Value() != "Дончо" {
+			t.Errorf("Expected %q, got %q", "Дончо", v.Value())
+		}
+	})
+
+	t.Run("short string is unchanged", func(t *testing.T) {
+		v := valtra.Val("Bob").Transform(valtra.Truncate(5))
+		if v.Value() != "Bob" {
+			t.Errorf("Expected %q, got %q", "Bob", v.Value())
+		}
+	})
+}
+
+func TestTitleCase(t *testing.T) {
+	t.Run("words are title cased", func(t *testing.T) {
+		v := valtra.Val("bobby DONEV o'neil-smith").Transform(valtra.TitleCase())
+		if v.Value() != "Bobby Donev O'neil-Smith" {
+			t.Errorf("Expected %q, got %q", "Bobby Donev O'neil-Smith", v.Value())
+		}
+	})
+}
+
+func TestRemoveDiacritics(t *testing.T) {
+	t.Run("diacritics are removed", func(t *testing.T) {
+		v := valtra.Val("Crème Brûlée, Straße, Łódź, Tiếng Việt, é").Transform(valtra.RemoveDiacritics())
+		expected := "Creme Brulee, Strasse, Lodz, Tieng Viet, e"
+		if v.Value() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Value())
+		}
+	})
+
+	t.Run("other scripts are unchanged", func(t *testing.T) {
+		v := valtra.Val("Дончо").Transform(valtra.RemoveDiacritics())
+		if v.Value() != "Дончо" {
+			t.Errorf("Expected %q, got %q", "Дончо", v.Value())
+		}
+	})
+}
+
+func TestSlugify(t *testing.T) {
+	t.Run("title is slugified", func(t *testing.T) {
+		v := valtra.Val("  Crème Brûlée -- Recipe #1! ").Transform(valtra.Slugify())
+		if v.Value() != "creme-brulee-recipe-1" {
+			t.Errorf("Expected %q, got %q", "creme-brulee-recipe-1", v.Value())
+		}
+	})
+}
+
+func TestSanitize(t *testing.T) {
+	t.Run("control characters are stripped", func(t *testing.T) {
+		v := valtra.Val("hello\x00\x1b[31m\tworld\n\u202eexe.txt").Transform(valtra.Sanitize())
+		expected := "hello[31m\tworld\nexe.txt"
+		if v.Value() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Value())
+		}
+	})
+}
+
+func TestAssertIdempotent(t *testing.T) {
+	t.Run("idempotent chain passes", func(t *testing.T) {
+		normalise := valtra.AssertIdempotent(valtra.TrimSpace(), valtra.CollapseWhitespace(), valtra.Lowercase())
+		for _, sample := range []string{"", "  Bob  ", "Дончо   ДОНЕВ", "\tA\nB "} {
+			v := valtra.Val(sample).Transform(normalise)
+			if !v.IsValid() {
+				t.Errorf("Expected %q to pass, got %v", sample, v.Errors())
+			}
+		}
+	})
+
+	t.Run("chain is applied", func(t *testing.T) {
+		v := valtra.Val("  Bob ").Transform(valtra.AssertIdempotent(valtra.TrimSpace(), valtra.Uppercase()))
+		if v.Value() != "BOB" {
+			t.Errorf("Expected %q, got %q", "BOB", v.Value())
+		}
+	})
+
+	t.Run("double escaping is reported", func(t *testing.T) {
+		v := valtra.Val("a & b", "bio").Transform(valtra.AssertIdempotent(valtra.EscapeHTML()))
+		want := "bio changes when normalised again"
+		if len(v.Errors()) != 1 || v.Errors()[0].Error() != want {
+			t.Errorf("Expected %q, got %v", want, v.Errors())
+		}
+	})
+
+	t.Run("values the chain leaves alone pass", func(t *testing.T) {
+		v := valtra.Val("plain").Transform(valtra.AssertIdempotent(valtra.EscapeHTML()))
+		if !v.IsValid() {
+			t.Errorf("Expected no errors, got %v", v.Errors())
+		}
+	})
+
+	t.Run("transformation errors are returned", func(t *testing.T) {
+		v := valtra.Val("abc").Transform(valtra.AssertIdempotent(func(v valtra.Value[string]) (string, error) {
+			return "", errors.New("boom")
+		}))
+		if len(v.Errors()) != 1 || v.Errors()[0].Error() != "boom" {
+			t.Errorf("Expected the transformation's error, got %v", v.Errors())
+		}
+	})
+}
@@ -0,0 +1,77 @@
+package valtra
+
+// Pair holds two values that are validated together, such as
+// a start and end date or a minimum and maximum price.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// Vals2 creates a new Value[Pair[A, B]] that wraps two values,
+// so that rules can check them together and produce a single
+// error naming both.
+//
+// The optional names identify the two values in error
+// messages, and are combined as "first and second". A single
+// name is used as is. Default is "values".
+//
+// Example:
+//
+//	v := valtra.Vals2(input.Start, input.End, "start", "end").Validate(valtra.Ascending[int]())
+//	// start and end must be in ascending order
+func Vals2[A, B any](a A, b B, names ...string) Value[Pair[A, B]] {
+	valName := "values"
+	switch {
+	case len(names) > 1 && names[0] != "" && names[1] != "":
+		valName = names[0] + " and " + names[1]
+	case len(names) > 0 && names[0] != "":
+		valName = names[0]
+	}
+
+	return Value[Pair[A, B]]{
+		value: Pair[A, B]{First: a, Second: b},
+		name:  valName,
+		errs:  []error{},
+	}
+}
+
+// Ascending returns a validation that ensures the first value
+// of a pair is not larger than the second.
+//
+// Works with all numeric types defined by the Ordered
+// constraint.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Vals2(10, 20, "min price", "max price").Validate(valtra.Ascending[int]())
+func Ascending[T Ordered](opts ...Option) func(Value[Pair[T, T]]) error {
+	return func(v Value[Pair[T, T]]) error {
+		if v.value.First > v.value.Second {
+			return newError(v, "ascending", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// PairFunc returns a validation that ensures the predicate
+// holds for the two values of a pair.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Vals2(start, end, "start", "end").Validate(valtra.PairFunc(time.Time.Before))
+func PairFunc[A, B any](predicate func(A, B) bool, opts ...Option) func(Value[Pair[A, B]]) error {
+	return func(v Value[Pair[A, B]]) error {
+		if !predicate(v.value.First, v.value.Second) {
+			return newError(v, "pair", nil, opts)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,81 @@
+package valtra_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestVals2(t *testing.T) {
+	t.Run("names are combined", func(t *testing.T) {
+		v := valtra.Vals2(1, 2, "start", "end")
+		if v.Name() != "start and end" {
+			t.Errorf("Expected 'start and end', got %q", v.Name())
+		}
+	})
+
+	t.Run("default name", func(t *testing.T) {
+		v := valtra.Vals2(1, "a")
+		if v.Name() != "values" {
+			t.Errorf("Expected 'values', got %q", v.Name())
+		}
+	})
+
+	t.Run("pair is returned on collect", func(t *testing.T) {
+		c := valtra.NewCollector()
+		p := valtra.Vals2(1, "a").Collect(c)
+		if p.First != 1 || p.Second != "a" {
+			t.Errorf("Expected pair (1, a), got %v", p)
+		}
+	})
+}
+
+func TestAscending(t *testing.T) {
+	t.Run("ascending pair passes", func(t *testing.T) {
+		v := valtra.Vals2(10, 20).Validate(valtra.Ascending[int]())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("descending pair fails with both names", func(t *testing.T) {
+		v := valtra.Vals2(20, 10, "min price", "max price").Validate(valtra.Ascending[int]())
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail for descending pair")
+		}
+		if v.Errors()[0].Error() != "min price and max price must be in ascending order" {
+			t.Errorf("Unexpected error message: %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Minimum cannot exceed maximum"
+		v := valtra.Vals2(20, 10).Validate(valtra.Ascending[int](valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestPairFunc(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+
+	t.Run("predicate holds passes", func(t *testing.T) {
+		v := valtra.Vals2(start, end).Validate(valtra.PairFunc(time.Time.Before))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("predicate fails", func(t *testing.T) {
+		v := valtra.Vals2(end, start).Validate(valtra.PairFunc(time.Time.Before))
+		if v.IsValid() {
+			t.Error("Expected validation to fail when predicate does not hold")
+		}
+	})
+}
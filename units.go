@@ -0,0 +1,144 @@
+package valtra
+
+import "fmt"
+
+// Unit is a unit of measurement supported by Measurement.
+type Unit string
+
+// Units supported by Measurement and ToUnit, grouped by the
+// quantity they measure.
+const (
+	Celsius    Unit = "C"
+	Fahrenheit Unit = "F"
+	Kelvin     Unit = "K"
+
+	Gram     Unit = "g"
+	Kilogram Unit = "kg"
+	Pound    Unit = "lb"
+	Ounce    Unit = "oz"
+
+	Metre     Unit = "m"
+	Kilometre Unit = "km"
+	Mile      Unit = "mi"
+	Foot      Unit = "ft"
+)
+
+// unitConversion describes how to convert a unit to and from
+// the base unit of its dimension.
+type unitConversion struct {
+	dimension string
+	scale     float64
+	offset    float64
+}
+
+// units holds the conversions of the supported units, where
+// base = value*scale + offset.
+var units = map[Unit]unitConversion{
+	Celsius:    {"temperature", 1, 0},
+	Fahrenheit: {"temperature", 5.0 / 9, -32 * 5.0 / 9},
+	Kelvin:     {"temperature", 1, -273.15},
+
+	Gram:     {"mass", 0.001, 0},
+	Kilogram: {"mass", 1, 0},
+	Pound:    {"mass", 0.45359237, 0},
+	Ounce:    {"mass", 0.028349523125, 0},
+
+	Metre:     {"length", 0.001, 0},
+	Kilometre: {"length", 1, 0},
+	Mile:      {"length", 1.609344, 0},
+	Foot:      {"length", 0.0003048, 0},
+}
+
+// Measure is an amount in a unit of measurement, e.g.
+// Measure{Amount: 98.6, Unit: valtra.Fahrenheit}.
+type Measure struct {
+	Amount float64
+	Unit   Unit
+}
+
+// In returns the measure converted to the given unit, or an
+// error if the units measure different quantities or are not
+// supported.
+func (m Measure) In(unit Unit) (Measure, error) {
+	from, ok := units[m.Unit]
+	if !ok {
+		return Measure{}, fmt.Errorf("valtra: unsupported unit %q", m.Unit)
+	}
+	to, ok := units[unit]
+	if !ok {
+		return Measure{}, fmt.Errorf("valtra: unsupported unit %q", unit)
+	}
+	if from.dimension != to.dimension {
+		return Measure{}, fmt.Errorf("valtra: cannot convert %s (%s) to %s (%s)", m.Unit, from.dimension, unit, to.dimension)
+	}
+
+	base := m.Amount*from.scale + from.offset
+	return Measure{Amount: (base - to.offset) / to.scale, Unit: unit}, nil
+}
+
+// String returns the measure formatted as e.g. "37.5C".
+func (m Measure) String() string {
+	return fmt.Sprintf("%g%s", m.Amount, m.Unit)
+}
+
+// Measurement returns a validation that ensures the value,
+// converted to the given unit, is between min and max
+// (inclusive).
+//
+// Limits are declared once in a single unit, and inputs in
+// any other unit of the same quantity are converted before
+// being compared, so 98.6°F passes a 35–42°C range. Values in
+// units that cannot be converted fail. An unsupported unit, or
+// a min above max, makes it an invalid rule (see
+// ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(valtra.Measure{Amount: 98.6, Unit: valtra.Fahrenheit}).
+//	    Validate(valtra.Measurement(valtra.Celsius, 35, 42))
+func Measurement(unit Unit, min, max float64, opts ...Option) func(Value[Measure]) error {
+	if _, ok := units[unit]; !ok {
+		return invalidRule[Measure]("Measurement has an unsupported unit %q", unit)
+	}
+	if min > max {
+		return invalidRule[Measure]("Measurement has a minimum (%g) above its maximum (%g)", min, max)
+	}
+
+	return func(v Value[Measure]) error {
+		m, err := v.value.In(unit)
+		if err != nil {
+			return newError(v, "unit", map[string]any{"unit": unit}, opts)
+		}
+
+		// Allow for floating-point error at the limits
+		const epsilon = 1e-9
+		if m.Amount < min-epsilon || m.Amount > max+epsilon {
+			return newError(v, "measurement", map[string]any{"min": Measure{min, unit}, "max": Measure{max, unit}}, opts)
+		}
+
+		return nil
+	}
+}
+
+// ToUnit returns a transformation that normalises the value
+// to the given unit.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Weight).Transform(valtra.ToUnit(valtra.Kilogram))
+func ToUnit(unit Unit, opts ...Option) func(Value[Measure]) (Measure, error) {
+	return func(v Value[Measure]) (Measure, error) {
+		m, err := v.value.In(unit)
+		if err != nil {
+			return v.value, newError(v, "unit", map[string]any{"unit": unit}, opts)
+		}
+
+		return m, nil
+	}
+}
@@ -0,0 +1,82 @@
+package valtra_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+func TestMeasureIn(t *testing.T) {
+	tests := []struct {
+		from     valtra.Measure
+		to       valtra.Unit
+		expected float64
+	}{
+		{valtra.Measure{Amount: 212, Unit: valtra.Fahrenheit}, valtra.Celsius, 100},
+		{valtra.Measure{Amount: 0, Unit: valtra.Celsius}, valtra.Kelvin, 273.15},
+		{valtra.Measure{Amount: 1, Unit: valtra.Kilogram}, valtra.Pound, 2.20462262},
+		{valtra.Measure{Amount: 1, Unit: valtra.Mile}, valtra.Metre, 1609.344},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from.String()+" in "+string(tt.to), func(t *testing.T) {
+			m, err := tt.from.In(tt.to)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if math.Abs(m.Amount-tt.expected) > 1e-6 || m.Unit != tt.to {
+				t.Errorf("Expected %v%s, got %v", tt.expected, tt.to, m)
+			}
+		})
+	}
+
+	t.Run("different quantities cannot be converted", func(t *testing.T) {
+		if _, err := (valtra.Measure{Amount: 1, Unit: valtra.Kilogram}).In(valtra.Mile); err == nil {
+			t.Error("Expected error converting mass to length")
+		}
+	})
+}
+
+func TestMeasurement(t *testing.T) {
+	bodyTemperature := valtra.Measurement(valtra.Celsius, 35, 42)
+
+	t.Run("value in another unit passes", func(t *testing.T) {
+		v := valtra.Val(valtra.Measure{Amount: 98.6, Unit: valtra.Fahrenheit}).Validate(bodyTemperature)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("limit converted exactly passes", func(t *testing.T) {
+		v := valtra.Val(valtra.Measure{Amount: 95, Unit: valtra.Fahrenheit}).Validate(bodyTemperature)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("value out of range fails", func(t *testing.T) {
+		v := valtra.Val(valtra.Measure{Amount: 110, Unit: valtra.Fahrenheit}, "temperature").Validate(bodyTemperature)
+		if v.IsValid() {
+			t.Fatal("Expected validation to fail")
+		}
+		expected := "temperature must be between 35C and 42C"
+		if v.Errors()[0].Error() != expected {
+			t.Errorf("Expected %q, got %q", expected, v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("incompatible unit fails", func(t *testing.T) {
+		v := valtra.Val(valtra.Measure{Amount: 37, Unit: valtra.Kilogram}).Validate(bodyTemperature)
+		if v.IsValid() {
+			t.Error("Expected validation to fail for incompatible unit")
+		}
+	})
+}
+
+func TestToUnit(t *testing.T) {
+	v := valtra.Val(valtra.Measure{Amount: 2000, Unit: valtra.Gram}).Transform(valtra.ToUnit(valtra.Kilogram))
+	if !v.IsValid() || v.Value() != (valtra.Measure{Amount: 2, Unit: valtra.Kilogram}) {
+		t.Errorf("Expected 2kg, got %v with errors: %v", v.Value(), v.Errors())
+	}
+}
@@ -1,34 +1,157 @@
 package valtra
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
 	"regexp"
 	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+	"unsafe"
+
+	"github.com/bobch27/valtra-go/constraint"
 )
 
+// ErrInvalidRule is returned by rules created with parameters
+// that make no sense, such as Between(10, 1), a negative
+// length or an empty OneOf set. Such rules fail every value
+// with an error wrapping ErrInvalidRule, rather than one that
+// blames the input.
+var ErrInvalidRule = errors.New("valtra: invalid rule")
+
+// invalidRule returns a rule that fails every value with an
+// error wrapping ErrInvalidRule, explaining what is wrong with
+// the rule.
+func invalidRule[T any](format string, args ...any) func(Value[T]) error {
+	err := fmt.Errorf("%w: "+format, append([]any{ErrInvalidRule}, args...)...)
+	return func(Value[T]) error {
+		return err
+	}
+}
+
+// negativeLength returns an invalid rule if the length
+// parameter of the named rule is negative, or nil otherwise.
+func negativeLength[T any](rule string, n int) func(Value[T]) error {
+	if n >= 0 {
+		return nil
+	}
+
+	return invalidRule[T]("%s has a negative length (%d)", rule, n)
+}
+
 // Required returns a validation that ensures the value is
 // not the zero value for its type.
 //
 // For strings, this means non-empty. For numbers, this means
 // non-zero. For pointers, this means non-nil.
 //
-// An optional custom error message can be provided as the
-// parameter.
+// Options such as WithMessage can be provided as the
+// parameters.
 //
 // Example:
 //
 //	valtra.Val("").Validate(valtra.Required[string]())  // fails
 //	valtra.Val("John").Validate(valtra.Required[string]())  // passes
-func Required[T comparable](errMssg ...string) func(Value[T]) error {
+func Required[T comparable](opts ...Option) func(Value[T]) error {
 	return func(v Value[T]) error {
 		var zero T
 		if v.value == zero {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+			return newError(v, "required", nil, opts)
+		}
 
-			return fmt.Errorf("%s is required", v.name)
+		return nil
+	}
+}
+
+// NotZero returns a validation that ensures the value is not
+// the zero value for its type, like Required, but for any
+// type, including structs with slice fields and functions.
+//
+// The check uses reflection, so for slices and maps the zero
+// value is nil; an empty but non-nil slice passes.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Handler, "handler").Validate(valtra.NotZero[http.HandlerFunc]())
+func NotZero[T any](opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if reflect.ValueOf(&v.value).Elem().IsZero() {
+			return newError(v, "not_zero", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// IsZero returns a validation that ensures the value is the
+// zero value for its type, e.g. for fields that clients must
+// not set. Like NotZero, it works for any type.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.ID, "id").Validate(valtra.IsZero[string]()) // set by the server
+func IsZero[T any](opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if !reflect.ValueOf(&v.value).Elem().IsZero() {
+			return newError(v, "is_zero", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// Equals returns a validation that ensures the value equals
+// the expected value, e.g. for confirmation fields.
+//
+// The default error message does not include the expected
+// value, which may be secret, but it is available as the
+// "expected" parameter.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.ConfirmPassword, "confirm_password").Validate(valtra.Equals(input.Password))
+func Equals[T comparable](expected T, opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if v.value != expected {
+			return newError(v, "equals", map[string]any{"expected": expected}, opts)
+		}
+
+		return nil
+	}
+}
+
+// NotEquals returns a validation that ensures the value
+// differs from the given value, e.g. so a new password is not
+// the old one.
+//
+// As with Equals, the value is available as the "expected"
+// parameter, but not included in the default error message.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.NewPassword, "new_password").Validate(valtra.NotEquals(input.OldPassword))
+func NotEquals[T comparable](value T, opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if v.value == value {
+			return newError(v, "not_equals", map[string]any{"expected": value}, opts)
 		}
 
 		return nil
@@ -37,11 +160,18 @@ func Required[T comparable](errMssg ...string) func(Value[T]) error {
 
 // Ordered is a constraint that permits all numeric types
 // that support comparison operations (<, >, <=, >=).
-type Ordered interface {
-	~int | ~int8 | ~int16 | ~int32 | ~int64 |
-		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
-		~float32 | ~float64
-}
+//
+// It is an alias of constraint.Ordered, for validators
+// outside valtra that need not import this package.
+type Ordered = constraint.Ordered
+
+// Integer is a constraint that permits all integer types. It
+// is an alias of constraint.Integer.
+type Integer = constraint.Integer
+
+// Float is a constraint that permits all floating-point
+// types. It is an alias of constraint.Float.
+type Float = constraint.Float
 
 // Max returns a validation that ensures the value does
 // not exceed the given maximum.
@@ -49,21 +179,16 @@ type Ordered interface {
 // Works with all numeric types defined by the Ordered
 // constraint.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
 //	valtra.Val(100).Validate(valtra.Max(100))
-func Max[T Ordered](max T, errMssg ...string) func(Value[T]) error {
+func Max[T Ordered](max T, opts ...Option) func(Value[T]) error {
 	return func(v Value[T]) error {
 		if v.value > max {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
-
-			return fmt.Errorf("%s cannot be larger than %v", v.name, max)
+			return newError(v, "max", map[string]any{"max": max}, opts)
 		}
 
 		return nil
@@ -76,171 +201,647 @@ func Max[T Ordered](max T, errMssg ...string) func(Value[T]) error {
 // Works with all numeric types defined by the Ordered
 // constraint.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
 //	valtra.Val(5).Validate(valtra.Min(1))
-func Min[T Ordered](min T, errMssg ...string) func(Value[T]) error {
+func Min[T Ordered](min T, opts ...Option) func(Value[T]) error {
 	return func(v Value[T]) error {
 		if v.value < min {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+			return newError(v, "min", map[string]any{"min": min}, opts)
+		}
+
+		return nil
+	}
+}
+
+// Between returns a validation that ensures the value is
+// within the given inclusive range.
+//
+// Works with all numeric types defined by the Ordered
+// constraint. If min is above max, the validation fails
+// every value with an error wrapping ErrInvalidRule.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(8080).Validate(valtra.Between(1, 65535))
+func Between[T Ordered](min T, max T, opts ...Option) func(Value[T]) error {
+	if min > max {
+		return invalidRule[T]("Between has a minimum (%v) above its maximum (%v)", min, max)
+	}
 
-			return fmt.Errorf("%s cannot be smaller than %v", v.name, min)
+	return func(v Value[T]) error {
+		if v.value < min || v.value > max {
+			return newError(v, "between", map[string]any{"min": min, "max": max}, opts)
 		}
 
 		return nil
 	}
 }
 
-// MaxLengthString returns a validation that ensures the
-// length of a string does not exceed the given maximum.
+// MinString returns a validation that ensures the string
+// does not sort before the given minimum, comparing bytes
+// lexicographically, e.g. for ranges of codes or keys.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
-//	valtra.Val("username").Validate(valtra.MaxLengthString(20))
-func MaxLengthString(max int, errMssg ...string) func(Value[string]) error {
+//	valtra.Val(input.Version, "version").Validate(valtra.MinString("v2"))
+func MinString(min string, opts ...Option) func(Value[string]) error {
 	return func(v Value[string]) error {
-		if len(v.value) > max {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+		if v.value < min {
+			return newError(v, "min_string", map[string]any{"min": min}, opts)
+		}
+
+		return nil
+	}
+}
 
-			return fmt.Errorf("%s's length cannot be larger than %v", v.name, max)
+// MaxString returns a validation that ensures the string
+// does not sort after the given maximum, as with MinString.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Initial, "initial").Validate(valtra.MaxString("M"))
+func MaxString(max string, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if v.value > max {
+			return newError(v, "max_string", map[string]any{"max": max}, opts)
 		}
 
 		return nil
 	}
 }
 
-// MaxLengthSlice returns a validation that ensures the
-// length of a slice does not exceed the given maximum.
+// Comparator returns an option that makes GreaterThan and
+// LessThan order values with cmp, which returns a negative
+// number if a < b, zero if a == b and a positive number if
+// a > b, as with cmp.Compare.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Its type must match the values being compared, or the rule
+// is invalid (see ErrInvalidRule).
 //
 // Example:
 //
-//	valtra.Val([]int{1}).Validate(valtra.MaxLengthSlice(2))
-func MaxLengthSlice[T any](max int, errMssg ...string) func(Value[[]T]) error {
-	return func(v Value[[]T]) error {
-		if len(v.value) > max {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+//	byLength := func(a, b string) int { return cmp.Compare(len(a), len(b)) }
+//	valtra.Val(input.Name).Validate(valtra.LessThan("a long name", valtra.Comparator(byLength)))
+func Comparator[T any](cmp func(a, b T) int) Option {
+	return func(o *options) {
+		o.comparator = cmp
+	}
+}
+
+// GreaterThan returns a validation that ensures the value is
+// strictly greater than the bound.
+//
+// Unlike Min, it works with any type that can be ordered:
+// numbers and strings, types with a Compare method such as
+// time.Time, or any type with a Comparator option. For types
+// that cannot be ordered, the rule is invalid (see
+// ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.EndsAt, "ends_at").Validate(valtra.GreaterThan(input.StartsAt))
+func GreaterThan[T any](bound T, opts ...Option) func(Value[T]) error {
+	compare, invalid := comparison[T]("GreaterThan", applyOptions(opts))
+	if invalid != nil {
+		return invalid
+	}
 
-			return fmt.Errorf("%s's length cannot be larger than %v", v.name, max)
+	return func(v Value[T]) error {
+		if c := compare(v.value, bound); c <= 0 {
+			return newError(v, "greater_than", map[string]any{"bound": bound}, opts)
 		}
 
 		return nil
 	}
 }
 
-// MaxLengthMap returns a validation that ensures the
-// length of a map does not exceed the given maximum.
+// LessThan returns a validation that ensures the value is
+// strictly less than the bound, as with GreaterThan.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
-//	valtra.Val(map[string]int{"no": 1}).Validate(valtra.MaxLengthMap(2))
-func MaxLengthMap[K comparable, V any](max int, errMssg ...string) func(Value[map[K]V]) error {
-	return func(v Value[map[K]V]) error {
-		if len(v.value) > max {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+//	valtra.Val(input.Code, "code").Validate(valtra.LessThan("N"))
+func LessThan[T any](bound T, opts ...Option) func(Value[T]) error {
+	compare, invalid := comparison[T]("LessThan", applyOptions(opts))
+	if invalid != nil {
+		return invalid
+	}
+
+	return func(v Value[T]) error {
+		if c := compare(v.value, bound); c >= 0 {
+			return newError(v, "less_than", map[string]any{"bound": bound}, opts)
+		}
+
+		return nil
+	}
+}
+
+// comparison returns the function GreaterThan and LessThan
+// order values with: the Comparator option, the values'
+// Compare method, or the natural order of numbers and strings,
+// in that order of preference. It returns an invalid rule
+// instead if T cannot be ordered.
+func comparison[T any](rule string, o options) (func(a, b T) int, func(Value[T]) error) {
+	if o.comparator != nil {
+		fn, ok := o.comparator.(func(a, b T) int)
+		if !ok {
+			return nil, invalidRule[T]("%s has a comparator that cannot compare %s values", rule, reflect.TypeFor[T]())
+		}
+
+		return fn, nil
+	}
+
+	if _, ok := any(*new(T)).(interface{ Compare(T) int }); ok {
+		return func(a, b T) int {
+			return any(a).(interface{ Compare(T) int }).Compare(b)
+		}, nil
+	}
+
+	switch t := reflect.TypeFor[T](); t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Int(), reflect.ValueOf(b).Int())
+		}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Uint(), reflect.ValueOf(b).Uint())
+		}, nil
+	case reflect.Float32, reflect.Float64:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).Float(), reflect.ValueOf(b).Float())
+		}, nil
+	case reflect.String:
+		return func(a, b T) int {
+			return cmp.Compare(reflect.ValueOf(a).String(), reflect.ValueOf(b).String())
+		}, nil
+	default:
+		return nil, invalidRule[T]("%s cannot order %s values; use Comparator", rule, t)
+	}
+}
+
+// Positive returns a validation that ensures the value is
+// larger than zero.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(9.99).Validate(valtra.Positive[float64]())
+func Positive[T Ordered](opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if v.value <= 0 {
+			return newError(v, "positive", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// Negative returns a validation that ensures the value is
+// smaller than zero.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(-10).Validate(valtra.Negative[int]())
+func Negative[T Ordered](opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if v.value >= 0 {
+			return newError(v, "negative", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// NonNegative returns a validation that ensures the value is
+// zero or larger.
+//
+// Options such as WithMessage can be provided as the
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(0).Validate(valtra.NonNegative[int]())
+func NonNegative[T Ordered](opts ...Option) func(Value[T]) error {
+	return func(v Value[T]) error {
+		if v.value < 0 {
+			return newError(v, "non_negative", nil, opts)
+		}
+
+		return nil
+	}
+}
+
+// MultipleOf returns a validation that ensures the value is
+// a multiple of n.
+//
+// Works with all integer types defined by the Integer
+// constraint. A value of n of 0 never passes.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(15).Validate(valtra.MultipleOf(5))
+func MultipleOf[T Integer](n T, opts ...Option) func(Value[T]) error {
+	if n == 0 {
+		return invalidRule[T]("MultipleOf of zero")
+	}
+
+	return func(v Value[T]) error {
+		if v.value%n != 0 {
+			return newError(v, "multiple_of", map[string]any{"n": n}, opts)
+		}
+
+		return nil
+	}
+}
+
+// MaxDecimalPlaces returns a validation that ensures the
+// value has at most n digits after the decimal point, e.g.
+// for money amounts.
+//
+// Decimal places are counted on the shortest representation
+// that round-trips the value, so 0.1 has 1 decimal place.
+// NaN and infinite values never pass, and a negative n is an
+// invalid rule (see ErrInvalidRule).
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(19.99).Validate(valtra.MaxDecimalPlaces[float64](2))
+func MaxDecimalPlaces[T Float](n int, opts ...Option) func(Value[T]) error {
+	if n < 0 {
+		return invalidRule[T]("MaxDecimalPlaces has a negative number of places (%d)", n)
+	}
+
+	return func(v Value[T]) error {
+		f := float64(v.value)
+		if math.IsNaN(f) || math.IsInf(f, 0) || decimalPlaces(f, int(unsafe.Sizeof(v.value))*8) > n {
+			return newError(v, "max_decimal_places", map[string]any{"max": n}, opts)
+		}
+
+		return nil
+	}
+}
+
+// decimalPlaces returns the number of digits after the
+// decimal point in the shortest representation of f.
+func decimalPlaces(f float64, bitSize int) int {
+	s := strconv.FormatFloat(f, 'f', -1, bitSize)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+
+	return 0
+}
 
-			return fmt.Errorf("%s's length cannot be larger than %v", v.name, max)
+// MaxLength returns a validation that ensures the length of
+// a string (in bytes), slice, array or map does not exceed
+// the given maximum, whatever the value's type.
+//
+// Go cannot infer a type parameter from where the rule is
+// used, only from the rule's own arguments, so it must be
+// given, e.g. MaxLength[[]int](2), or a typed rule such as
+// MaxLengthString used instead. Given a type without a length,
+// such as MaxLength[int], or a negative maximum, the rule is
+// invalid and fails every value (see ErrInvalidRule), and
+// valtravet reports it before the code runs. Interface types
+// are checked against the values they hold. The same goes for
+// MinLength.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Tags).Validate(valtra.MaxLength[[]string](10))
+func MaxLength[T any](max int, opts ...Option) func(Value[T]) error {
+	if rule := negativeLength[T]("MaxLength", max); rule != nil {
+		return rule
+	}
+	if rule := lengthless[T]("MaxLength"); rule != nil {
+		return rule
+	}
+
+	return func(v Value[T]) error {
+		n, err := length(v)
+		if err != nil {
+			return err
+		}
+		if n > max {
+			return newError(v, "max_length", map[string]any{"max": max}, opts)
 		}
 
 		return nil
 	}
 }
 
+// MinLength returns a validation that ensures the length of
+// a string (in bytes), slice, array or map is at least the
+// given minimum, as with MaxLength.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Items).Validate(valtra.MinLength[[]Item](1))
+func MinLength[T any](min int, opts ...Option) func(Value[T]) error {
+	if rule := negativeLength[T]("MinLength", min); rule != nil {
+		return rule
+	}
+	if rule := lengthless[T]("MinLength"); rule != nil {
+		return rule
+	}
+
+	return func(v Value[T]) error {
+		n, err := length(v)
+		if err != nil {
+			return err
+		}
+		if n < min {
+			return newError(v, "min_length", map[string]any{"min": min}, opts)
+		}
+
+		return nil
+	}
+}
+
+// length returns the length of the value, for MinLength and
+// MaxLength. Strings and common slices are handled without
+// reflection.
+func length[T any](v Value[T]) (int, error) {
+	switch x := any(v.value).(type) {
+	case string:
+		return len(x), nil
+	case []byte:
+		return len(x), nil
+	case []string:
+		return len(x), nil
+	case []int:
+		return len(x), nil
+	}
+
+	switch rv := reflect.ValueOf(v.value); rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan:
+		return rv.Len(), nil
+	default:
+		return 0, fmt.Errorf("valtra: length of %s cannot be checked for %T values", v.name, v.value)
+	}
+}
+
+// lengthless returns an invalid rule if values of type T have
+// no length, for the named length rule, or nil otherwise.
+func lengthless[T any](rule string) func(Value[T]) error {
+	switch t := reflect.TypeFor[T](); t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map, reflect.Chan, reflect.Interface:
+		return nil
+	default:
+		return invalidRule[T]("%s cannot check the length of %s values", rule, t)
+	}
+}
+
+// MaxLengthString returns a validation that ensures the
+// length of a string does not exceed the given maximum.
+//
+// It is equivalent to MaxLength[string].
+//
+// Example:
+//
+//	valtra.Val("username").Validate(valtra.MaxLengthString(20))
+func MaxLengthString(max int, opts ...Option) func(Value[string]) error {
+	return MaxLength[string](max, opts...)
+}
+
+// MaxLengthSlice returns a validation that ensures the
+// length of a slice does not exceed the given maximum.
+//
+// It is equivalent to MaxLength[[]T].
+//
+// Example:
+//
+//	valtra.Val([]int{1}).Validate(valtra.MaxLengthSlice[int](2))
+func MaxLengthSlice[T any](max int, opts ...Option) func(Value[[]T]) error {
+	return MaxLength[[]T](max, opts...)
+}
+
+// MaxLengthMap returns a validation that ensures the
+// length of a map does not exceed the given maximum.
+//
+// It is equivalent to MaxLength[map[K]V].
+//
+// Example:
+//
+//	valtra.Val(map[string]int{"no": 1}).Validate(valtra.MaxLengthMap[string, int](2))
+func MaxLengthMap[K comparable, V any](max int, opts ...Option) func(Value[map[K]V]) error {
+	return MaxLength[map[K]V](max, opts...)
+}
+
 // MinLengthString returns a validation that ensures the
 // length of a string is at least the given minimum.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// It is equivalent to MinLength[string].
 //
 // Example:
 //
 //	valtra.Val("username").Validate(valtra.MinLengthString(5))
-func MinLengthString(min int, errMssg ...string) func(Value[string]) error {
+func MinLengthString(min int, opts ...Option) func(Value[string]) error {
+	return MinLength[string](min, opts...)
+}
+
+// MinLengthSlice returns a validation that ensures the
+// length of a slice is at least the given minimum.
+//
+// It is equivalent to MinLength[[]T].
+//
+// Example:
+//
+//	valtra.Val([]int{1}).Validate(valtra.MinLengthSlice[int](1))
+func MinLengthSlice[T any](min int, opts ...Option) func(Value[[]T]) error {
+	return MinLength[[]T](min, opts...)
+}
+
+// MinLengthMap returns a validation that ensures the
+// length of a map is at least the given minimum.
+//
+// It is equivalent to MinLength[map[K]V].
+//
+// Example:
+//
+//	valtra.Val(map[string]int{"no": 1}).Validate(valtra.MinLengthMap[string, int](1))
+func MinLengthMap[K comparable, V any](min int, opts ...Option) func(Value[map[K]V]) error {
+	return MinLength[map[K]V](min, opts...)
+}
+
+// MaxRunes returns a validation that ensures the number of
+// characters (runes) in a string does not exceed the given
+// maximum.
+//
+// Unlike MaxLengthString, which counts bytes, "héllo" counts
+// as 5 characters. User-facing length limits should usually
+// use MaxRunes or MaxGraphemes.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("héllo").Validate(valtra.MaxRunes(5))
+func MaxRunes(max int, opts ...Option) func(Value[string]) error {
+	if rule := negativeLength[string]("MaxRunes", max); rule != nil {
+		return rule
+	}
+
 	return func(v Value[string]) error {
-		if len(v.value) < min {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+		if utf8.RuneCountInString(v.value) > max {
+			return newError(v, "max_length", map[string]any{"max": max}, opts)
+		}
+
+		return nil
+	}
+}
 
-			return fmt.Errorf("%s's length cannot be smaller than %v", v.name, min)
+// MinRunes returns a validation that ensures the number of
+// characters (runes) in a string is at least the given
+// minimum.
+//
+// Unlike MinLengthString, which counts bytes, "héllo" counts
+// as 5 characters.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("héllo").Validate(valtra.MinRunes(5))
+func MinRunes(min int, opts ...Option) func(Value[string]) error {
+	if rule := negativeLength[string]("MinRunes", min); rule != nil {
+		return rule
+	}
+
+	return func(v Value[string]) error {
+		if utf8.RuneCountInString(v.value) < min {
+			return newError(v, "min_length", map[string]any{"min": min}, opts)
 		}
 
 		return nil
 	}
 }
 
-// MinLengthSlice returns a validation that ensures the
-// length of a slice is at least the given minimum.
+// MaxGraphemes returns a validation that ensures the number
+// of user-perceived characters in a string does not exceed
+// the given maximum.
+//
+// Combining marks, emoji modifiers and ZWJ sequences are
+// counted along with the character they attach to, so both
+// "e\u0301" and "👍🏽" count as 1. This is an approximation of
+// Unicode grapheme clusters that covers common text and emoji.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
-//	valtra.Val([]int{1}).Validate(valtra.MinLengthSlice(1))
-func MinLengthSlice[T any](min int, errMssg ...string) func(Value[[]T]) error {
-	return func(v Value[[]T]) error {
-		if len(v.value) < min {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+//	valtra.Val("👍🏽👍🏽").Validate(valtra.MaxGraphemes(2))
+func MaxGraphemes(max int, opts ...Option) func(Value[string]) error {
+	if rule := negativeLength[string]("MaxGraphemes", max); rule != nil {
+		return rule
+	}
 
-			return fmt.Errorf("%s's length cannot be smaller than %v", v.name, min)
+	return func(v Value[string]) error {
+		if graphemeCount(v.value) > max {
+			return newError(v, "max_length", map[string]any{"max": max}, opts)
 		}
 
 		return nil
 	}
 }
 
-// MinLengthMap returns a validation that ensures the
-// length of a map is at least the given minimum.
+// MinGraphemes returns a validation that ensures the number
+// of user-perceived characters in a string is at least the
+// given minimum.
+//
+// See MaxGraphemes for how characters are counted.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
-//	valtra.Val(map[string]int{"no": 1}).Validate(valtra.MinLengthMap(1))
-func MinLengthMap[K comparable, V any](min int, errMssg ...string) func(Value[map[K]V]) error {
-	return func(v Value[map[K]V]) error {
-		if len(v.value) < min {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
-			}
+//	valtra.Val("née").Validate(valtra.MinGraphemes(3))
+func MinGraphemes(min int, opts ...Option) func(Value[string]) error {
+	if rule := negativeLength[string]("MinGraphemes", min); rule != nil {
+		return rule
+	}
 
-			return fmt.Errorf("%s's length cannot be smaller than %v", v.name, min)
+	return func(v Value[string]) error {
+		if graphemeCount(v.value) < min {
+			return newError(v, "min_length", map[string]any{"min": min}, opts)
 		}
 
 		return nil
 	}
 }
 
+// graphemeCount approximates the number of grapheme clusters
+// in a string, by not counting runes that extend the previous
+// character.
+func graphemeCount(s string) int {
+	count := 0
+	joinNext := false
+	regionalIndicators := 0
+	for _, r := range s {
+		switch {
+		case joinNext:
+			joinNext = false
+		case r == '\u200d': // zero width joiner
+			joinNext = true
+		case unicode.In(r, unicode.Mn, unicode.Me, unicode.Mc),
+			r >= '\ufe00' && r <= '\ufe0f', // variation selectors
+			r >= 0x1f3fb && r <= 0x1f3ff:   // emoji skin tone modifiers
+		case r >= 0x1f1e6 && r <= 0x1f1ff: // regional indicators pair into flags
+			if regionalIndicators%2 == 0 {
+				count++
+			}
+			regionalIndicators++
+			continue
+		default:
+			count++
+		}
+
+		regionalIndicators = 0
+	}
+
+	return count
+}
+
 // emailRegex is a practical, internationally-aware email format.
 // Supports Unicode characters (accents, non-Latin scripts)
 // in email addresses.
@@ -251,24 +852,83 @@ var emailRegex = regexp.MustCompile(`^(?:"(?:[^"]|\\")*"|[\p{L}\p{N}\p{M}._%+-]+
 //
 // It uses a practical, internationally-aware pattern
 // that catches common errors, while remaining permissive.
+// StrictEmail parses addresses by RFC 5322 instead, and
+// DisposableDomains rejects addresses of throwaway
+// providers. EmailMX checks that the domain accepts email.
 //
 // For true validation, send a confirmation email.
 //
-// An optional custom error message can be provided as the
-// parameter.
+// Options such as WithMessage can be provided as the
+// parameters.
 //
 // Example:
 //
 //	valtra.Val("user@example.com").Validate(valtra.Email())
-func Email(errMssg ...string) func(Value[string]) error {
-	return func(v Value[string]) error {
-		if !emailRegex.MatchString(v.value) {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
+func Email(opts ...Option) func(Value[string]) error {
+	o := applyOptions(opts)
+
+	return withAutoFix(func(v Value[string]) error {
+		if !isEmail(v.value, o.strictEmail) {
+			return newError(v, "email", nil, opts)
+		}
+
+		if o.disposable != nil {
+			domain := emailDomain(v.value)
+			if o.disposable(domain) {
+				return newError(v, "disposable_email", map[string]any{"domain": domain}, opts)
 			}
+		}
+
+		return nil
+	}, repairEmail, opts)
+}
+
+// patternCache caches compiled patterns used by Match, keyed
+// by the pattern string.
+var patternCache sync.Map
+
+// Match returns a validation that ensures the value matches
+// the given regular expression pattern.
+//
+// Compiled patterns are cached, so Match can be safely called
+// inside hot request handlers without recompiling the pattern
+// each time. An invalid pattern causes the validation to
+// always fail with the compilation error.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val("ABC-123").Validate(valtra.Match(`^[A-Z]{3}-\d{3}$`))
+func Match(pattern string, opts ...Option) func(Value[string]) error {
+	re, ok := patternCache.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return invalidRule[string]("Match pattern %q is invalid: %w", pattern, err)
+		}
+
+		re, _ = patternCache.LoadOrStore(pattern, compiled)
+	}
+
+	return MatchRegexp(re.(*regexp.Regexp), opts...)
+}
 
-			return fmt.Errorf("%s must be in correct email format", v.name)
+// MatchRegexp returns a validation that ensures the value
+// matches the given compiled regular expression.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	var skuRegex = regexp.MustCompile(`^[A-Z]{3}-\d{3}$`)
+//	valtra.Val("ABC-123").Validate(valtra.MatchRegexp(skuRegex))
+func MatchRegexp(re *regexp.Regexp, opts ...Option) func(Value[string]) error {
+	return func(v Value[string]) error {
+		if !re.MatchString(v.value) {
+			return newError(v, "match", map[string]any{"pattern": re.String()}, opts)
 		}
 
 		return nil
@@ -278,45 +938,160 @@ func Email(errMssg ...string) func(Value[string]) error {
 // OneOf returns a validation that ensures the value matches
 // one of the provided allowed values.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// The default error message lists the allowed values, e.g.
+// "status must be one of: pending, approved, rejected". For
+// string values, a close match is added as the "suggestion"
+// parameter, e.g. "currency must be one of: EUR, USD (did you
+// mean EUR?)" for "EUT". Without any allowed values, nothing
+// could pass, so the validation fails with an error wrapping
+// ErrInvalidRule.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
 //	valtra.Val("pending").Validate(valtra.OneOf([]string{"pending", "approved", "rejected"}))
-func OneOf[T comparable](values []T, errMssg ...string) func(Value[T]) error {
+func OneOf[T comparable](values []T, opts ...Option) func(Value[T]) error {
+	if len(values) == 0 {
+		return invalidRule[T]("OneOf has no values to choose from")
+	}
+
 	return func(v Value[T]) error {
 		if !slices.Contains(values, v.value) {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
+			params := map[string]any{"values": values}
+			if s, ok := suggestValue(v.value, values); ok {
+				params["suggestion"] = s
 			}
 
-			return fmt.Errorf("%s must be one of: %v", v.name, values)
+			return newError(v, "one_of", params, opts)
 		}
 
 		return nil
 	}
 }
 
+// OneOfValues is OneOf with the allowed values given as
+// arguments.
+//
+// Example:
+//
+//	valtra.Val("pending").Validate(valtra.OneOfValues("pending", "approved", "rejected"))
+func OneOfValues[T comparable](values ...T) func(Value[T]) error {
+	return OneOf(values)
+}
+
 // NotIn returns a validation that ensures the value
 // does not match any of the provided forbidden values.
 //
-// An optional custom error message can be provided as the
-// last parameter.
+// Options such as WithMessage can be provided as the last
+// parameters.
 //
 // Example:
 //
 //	valtra.Val("john").Validate(valtra.NotIn([]string{"admin", "root", "system"}))
-func NotIn[T comparable](values []T, errMssg ...string) func(Value[T]) error {
+func NotIn[T comparable](values []T, opts ...Option) func(Value[T]) error {
 	return func(v Value[T]) error {
 		if slices.Contains(values, v.value) {
-			// Return custom error message, if provided
-			if len(errMssg) > 0 && errMssg[0] != "" {
-				return fmt.Errorf("%s", errMssg[0])
+			return newError(v, "not_in", map[string]any{"values": values}, opts)
+		}
+
+		return nil
+	}
+}
+
+// NotInValues is NotIn with the forbidden values given as
+// arguments.
+//
+// Example:
+//
+//	valtra.Val("john").Validate(valtra.NotInValues("admin", "root", "system"))
+func NotInValues[T comparable](values ...T) func(Value[T]) error {
+	return NotIn(values)
+}
+
+// AllowedKeys returns a validation that ensures the map has
+// no keys other than the provided ones, e.g. to reject
+// misspelt settings.
+//
+// The unknown keys are listed in the "keys" parameter. If
+// there is only one and the keys are strings, a close match
+// is added as the "suggestion" parameter, as with OneOf.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(settings, "settings").Validate(valtra.AllowedKeys[string, any]([]string{"theme", "language"}))
+func AllowedKeys[K comparable, V any](keys []K, opts ...Option) func(Value[map[K]V]) error {
+	return func(v Value[map[K]V]) error {
+		var unknown []K
+		for k := range v.value {
+			if !slices.Contains(keys, k) {
+				unknown = append(unknown, k)
+			}
+		}
+		if len(unknown) == 0 {
+			return nil
+		}
+
+		slices.SortFunc(unknown, func(a, b K) int {
+			return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+		})
+
+		params := map[string]any{"keys": unknown}
+		if len(unknown) == 1 {
+			if s, ok := suggestValue(unknown[0], keys); ok {
+				params["suggestion"] = s
 			}
+		}
+
+		return newError(v, "allowed_keys", params, opts)
+	}
+}
+
+// Unique returns a validation that ensures the elements of a
+// slice are all distinct.
+//
+// The first repeated element and its index are available as
+// the "duplicate" and "index" parameters. Only the index is
+// in the message, e.g. "tags contains a duplicate at index 2".
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(input.Tags, "tags").Validate(valtra.Unique[string]())
+func Unique[T comparable](opts ...Option) func(Value[[]T]) error {
+	return UniqueBy(func(e T) T { return e }, opts...)
+}
 
-			return fmt.Errorf("%s cannot be one of: %v", v.name, values)
+// UniqueBy returns a validation that ensures the elements of a
+// slice all have distinct keys, as returned by the key
+// function, e.g. so that no two line items share a SKU.
+//
+// The first repeated key and the index of its element are
+// available as the "duplicate" and "index" parameters.
+//
+// Options such as WithMessage can be provided as the last
+// parameters.
+//
+// Example:
+//
+//	valtra.Val(order.Items, "items").Validate(
+//	    valtra.UniqueBy(func(item Item) string { return item.SKU }),
+//	)
+func UniqueBy[T any, K comparable](key func(T) K, opts ...Option) func(Value[[]T]) error {
+	return func(v Value[[]T]) error {
+		seen := make(map[K]struct{}, len(v.value))
+		for i, e := range v.value {
+			k := key(e)
+			if _, ok := seen[k]; ok {
+				return newError(v, "unique", map[string]any{"duplicate": k, "index": i}, opts)
+			}
+			seen[k] = struct{}{}
 		}
 
 		return nil
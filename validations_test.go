@@ -1,7 +1,12 @@
 package valtra_test
 
 import (
+	"context"
+	"errors"
+	"math"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/bobch27/valtra-go"
 )
@@ -30,7 +35,7 @@ func TestRequired(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Custom required error"
-		v := valtra.Val("").Validate(valtra.Required[string](customMsg))
+		v := valtra.Val("").Validate(valtra.Required[string](valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -64,7 +69,7 @@ func TestMin(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Value too small"
-		v := valtra.Val(5).Validate(valtra.Min(10, customMsg))
+		v := valtra.Val(5).Validate(valtra.Min(10, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -74,6 +79,82 @@ func TestMin(t *testing.T) {
 	})
 }
 
+func TestNotZero(t *testing.T) {
+	type settings struct {
+		Tags []string
+	}
+
+	t.Run("set values pass", func(t *testing.T) {
+		v := valtra.Val(settings{Tags: []string{}}).Validate(valtra.NotZero[settings]())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("zero values fail", func(t *testing.T) {
+		v := valtra.Val(settings{}, "settings").Validate(valtra.NotZero[settings]())
+		if v.IsValid() || v.Errors()[0].Error() != "settings is required" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("nil functions fail", func(t *testing.T) {
+		var fn func()
+		v := valtra.Val(fn).Validate(valtra.NotZero[func()]())
+		if v.IsValid() {
+			t.Error("Expected validation to fail for nil function")
+		}
+	})
+}
+
+func TestIsZero(t *testing.T) {
+	t.Run("zero values pass", func(t *testing.T) {
+		v := valtra.Val([]string(nil)).Validate(valtra.IsZero[[]string]())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("set values fail", func(t *testing.T) {
+		v := valtra.Val("abc", "id").Validate(valtra.IsZero[string]())
+		if v.IsValid() || v.Errors()[0].Error() != "id must not be set" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestEquals(t *testing.T) {
+	t.Run("equal values pass", func(t *testing.T) {
+		v := valtra.Val("hunter2").Validate(valtra.Equals("hunter2"))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("different values fail without revealing the expected value", func(t *testing.T) {
+		v := valtra.Val("hunter3", "confirm_password").Validate(valtra.Equals("hunter2"))
+		if v.IsValid() || v.Errors()[0].Error() != "confirm_password does not match" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestNotEquals(t *testing.T) {
+	t.Run("different values pass", func(t *testing.T) {
+		v := valtra.Val(2).Validate(valtra.NotEquals(1))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("equal values fail", func(t *testing.T) {
+		v := valtra.Val("hunter2", "new_password").Validate(valtra.NotEquals("hunter2"))
+		if v.IsValid() || v.Errors()[0].Error() != "new_password must be different" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
+
 func TestMax(t *testing.T) {
 	t.Run("above max fails", func(t *testing.T) {
 		v := valtra.Val(15).Validate(valtra.Max(10))
@@ -91,7 +172,7 @@ func TestMax(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Value too large"
-		v := valtra.Val(15).Validate(valtra.Max(10, customMsg))
+		v := valtra.Val(15).Validate(valtra.Max(10, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -101,6 +182,122 @@ func TestMax(t *testing.T) {
 	})
 }
 
+func TestBetween(t *testing.T) {
+	t.Run("within range passes", func(t *testing.T) {
+		v := valtra.Val(8080).Validate(valtra.Between(1, 65535))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("at bounds passes", func(t *testing.T) {
+		v := valtra.Val(1).Validate(valtra.Between(1, 10))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("outside range fails", func(t *testing.T) {
+		v := valtra.Val(0).Validate(valtra.Between(1, 10))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for value outside range")
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "{name} must be from {min} to {max}"
+		v := valtra.Val(11, "port").Validate(valtra.Between(1, 10, valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != "port must be from 1 to 10" {
+			t.Errorf("Expected interpolated message, got %q", v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestSign(t *testing.T) {
+	t.Run("positive", func(t *testing.T) {
+		if !valtra.Val(1).Validate(valtra.Positive[int]()).IsValid() {
+			t.Error("Expected 1 to be positive")
+		}
+		if valtra.Val(0).Validate(valtra.Positive[int]()).IsValid() {
+			t.Error("Expected 0 not to be positive")
+		}
+	})
+
+	t.Run("negative", func(t *testing.T) {
+		if !valtra.Val(-0.5).Validate(valtra.Negative[float64]()).IsValid() {
+			t.Error("Expected -0.5 to be negative")
+		}
+		if valtra.Val(0.0).Validate(valtra.Negative[float64]()).IsValid() {
+			t.Error("Expected 0 not to be negative")
+		}
+	})
+
+	t.Run("non-negative", func(t *testing.T) {
+		if !valtra.Val(0).Validate(valtra.NonNegative[int]()).IsValid() {
+			t.Error("Expected 0 to be non-negative")
+		}
+		if valtra.Val(-1).Validate(valtra.NonNegative[int]()).IsValid() {
+			t.Error("Expected -1 not to be non-negative")
+		}
+	})
+}
+
+func TestMultipleOf(t *testing.T) {
+	t.Run("multiple passes", func(t *testing.T) {
+		v := valtra.Val(15).Validate(valtra.MultipleOf(5))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("non-multiple fails", func(t *testing.T) {
+		v := valtra.Val(16).Validate(valtra.MultipleOf(5))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-multiple")
+		}
+	})
+
+	t.Run("zero divisor fails without panicking", func(t *testing.T) {
+		v := valtra.Val(10).Validate(valtra.MultipleOf(0))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for zero divisor")
+		}
+	})
+}
+
+func TestMaxDecimalPlaces(t *testing.T) {
+	t.Run("within decimal places passes", func(t *testing.T) {
+		v := valtra.Val(19.99).Validate(valtra.MaxDecimalPlaces[float64](2))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("too many decimal places fails", func(t *testing.T) {
+		v := valtra.Val(19.999).Validate(valtra.MaxDecimalPlaces[float64](2))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for 3 decimal places")
+		}
+	})
+
+	t.Run("float32 is counted at its own precision", func(t *testing.T) {
+		v := valtra.Val(float32(0.1)).Validate(valtra.MaxDecimalPlaces[float32](1))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("NaN fails", func(t *testing.T) {
+		v := valtra.Val(math.NaN()).Validate(valtra.MaxDecimalPlaces[float64](2))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for NaN")
+		}
+	})
+}
+
 func TestMinLengthString(t *testing.T) {
 	t.Run("below min length fails", func(t *testing.T) {
 		v := valtra.Val("ab").Validate(valtra.MinLengthString(5))
@@ -118,7 +315,7 @@ func TestMinLengthString(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "String too short"
-		v := valtra.Val("ab").Validate(valtra.MinLengthString(5, customMsg))
+		v := valtra.Val("ab").Validate(valtra.MinLengthString(5, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -145,7 +342,7 @@ func TestMaxLengthString(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "String too long"
-		v := valtra.Val("hello").Validate(valtra.MaxLengthString(3, customMsg))
+		v := valtra.Val("hello").Validate(valtra.MaxLengthString(3, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -172,7 +369,7 @@ func TestMinLengthSlice(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Slice too short"
-		v := valtra.Val([]int{1}).Validate(valtra.MinLengthSlice[int](2, customMsg))
+		v := valtra.Val([]int{1}).Validate(valtra.MinLengthSlice[int](2, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -199,7 +396,7 @@ func TestMaxLengthSlice(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Slice too long"
-		v := valtra.Val([]int{1, 2, 3}).Validate(valtra.MaxLengthSlice[int](2, customMsg))
+		v := valtra.Val([]int{1, 2, 3}).Validate(valtra.MaxLengthSlice[int](2, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -226,7 +423,7 @@ func TestMinLengthMap(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Map too small"
-		v := valtra.Val(map[string]int{"a": 1}).Validate(valtra.MinLengthMap[string, int](2, customMsg))
+		v := valtra.Val(map[string]int{"a": 1}).Validate(valtra.MinLengthMap[string, int](2, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -253,7 +450,7 @@ func TestMaxLengthMap(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Map too large"
-		v := valtra.Val(map[string]int{"a": 1, "b": 2, "c": 3}).Validate(valtra.MaxLengthMap[string, int](2, customMsg))
+		v := valtra.Val(map[string]int{"a": 1, "b": 2, "c": 3}).Validate(valtra.MaxLengthMap[string, int](2, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -263,6 +460,101 @@ func TestMaxLengthMap(t *testing.T) {
 	})
 }
 
+func TestMinLengthMaxLength(t *testing.T) {
+	t.Run("lengths of all kinds are checked", func(t *testing.T) {
+		tests := []struct {
+			name  string
+			err   error
+			valid bool
+		}{
+			{"string", valtra.MinLength[string](3)(valtra.Val("abc")), true},
+			{"short string", valtra.MinLength[string](3)(valtra.Val("ab")), false},
+			{"slice", valtra.MaxLength[[]int](2)(valtra.Val([]int{1, 2})), true},
+			{"long slice", valtra.MaxLength[[]int](2)(valtra.Val([]int{1, 2, 3})), false},
+			{"slice of structs", valtra.MinLength[[]struct{}](1)(valtra.Val([]struct{}{{}})), true},
+			{"map", valtra.MaxLength[map[string]bool](1)(valtra.Val(map[string]bool{"a": true, "b": true})), false},
+			{"array", valtra.MinLength[[2]int](3)(valtra.Val([2]int{})), false},
+			{"named string", valtra.MaxLength[valtra.CardBrand](1)(valtra.Val(valtra.CardBrandVisa)), false},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				var ve *valtra.ValidationError
+				if valid := tt.err == nil; valid != tt.valid || !valid && !errors.As(tt.err, &ve) {
+					t.Errorf("Expected valid=%v, got %v", tt.valid, tt.err)
+				}
+			})
+		}
+	})
+
+	t.Run("errors match the typed rules", func(t *testing.T) {
+		v := valtra.Val([]int{1}, "items").Validate(valtra.MinLength[[]int](2))
+		w := valtra.Val([]int{1}, "items").Validate(valtra.MinLengthSlice[int](2))
+		if v.IsValid() || v.Errors()[0].Error() != w.Errors()[0].Error() {
+			t.Errorf("Expected %v, got %v", w.Errors(), v.Errors())
+		}
+	})
+
+	t.Run("types without a length are invalid rules", func(t *testing.T) {
+		err := valtra.MinLength[int](1)(valtra.Val(5))
+		if !errors.Is(err, valtra.ErrInvalidRule) {
+			t.Errorf("Expected ErrInvalidRule, got %v", err)
+		}
+	})
+
+	t.Run("values without a length fail", func(t *testing.T) {
+		err := valtra.MinLength[any](1)(valtra.Val[any](5))
+
+		var ve *valtra.ValidationError
+		if err == nil || errors.As(err, &ve) {
+			t.Errorf("Expected an error that the rule does not apply, got %v", err)
+		}
+	})
+}
+
+func TestRunes(t *testing.T) {
+	t.Run("characters are counted instead of bytes", func(t *testing.T) {
+		v := valtra.Val("héllo").Validate(valtra.MaxRunes(5), valtra.MinRunes(5))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("above max runes fails", func(t *testing.T) {
+		v := valtra.Val("héllo!").Validate(valtra.MaxRunes(5))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for 6 characters")
+		}
+	})
+
+	t.Run("below min runes fails", func(t *testing.T) {
+		v := valtra.Val("hé").Validate(valtra.MinRunes(3))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for 2 characters")
+		}
+	})
+}
+
+func TestGraphemes(t *testing.T) {
+	cases := map[string]int{
+		"hello":        5,
+		"e\u0301":      1,
+		"👍🏽":           1,
+		"👩‍💻":          1,
+		"🇧🇬🇬🇧":         2,
+		"ne\u0301e né": 6,
+	}
+
+	for s, n := range cases {
+		if !valtra.Val(s).Validate(valtra.MaxGraphemes(n), valtra.MinGraphemes(n)).IsValid() {
+			t.Errorf("Expected %q to have %d graphemes", s, n)
+		}
+		if valtra.Val(s).Validate(valtra.MaxGraphemes(n - 1)).IsValid() {
+			t.Errorf("Expected %q to exceed %d graphemes", s, n-1)
+		}
+	}
+}
+
 func TestEmail(t *testing.T) {
 	t.Run("valid email passes", func(t *testing.T) {
 		v := valtra.Val("test@example.com").Validate(valtra.Email())
@@ -287,7 +579,7 @@ func TestEmail(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Invalid email address"
-		v := valtra.Val("not-an-email").Validate(valtra.Email(customMsg))
+		v := valtra.Val("not-an-email").Validate(valtra.Email(valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -297,6 +589,58 @@ func TestEmail(t *testing.T) {
 	})
 }
 
+func TestMatch(t *testing.T) {
+	t.Run("matching value passes", func(t *testing.T) {
+		v := valtra.Val("ABC-123").Validate(valtra.Match(`^[A-Z]{3}-\d{3}$`))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("non-matching value fails", func(t *testing.T) {
+		v := valtra.Val("abc-123").Validate(valtra.Match(`^[A-Z]{3}-\d{3}$`))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-matching value")
+		}
+	})
+
+	t.Run("invalid pattern fails", func(t *testing.T) {
+		v := valtra.Val("abc").Validate(valtra.Match(`^[a-z`))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for invalid pattern")
+		}
+	})
+
+	t.Run("custom error message", func(t *testing.T) {
+		customMsg := "Invalid SKU"
+		v := valtra.Val("abc").Validate(valtra.Match(`^[A-Z]+$`, valtra.WithMessage(customMsg)))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if v.Errors()[0].Error() != customMsg {
+			t.Errorf("Expected %q, got %q", customMsg, v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestMatchRegexp(t *testing.T) {
+	re := regexp.MustCompile(`^\d+$`)
+
+	t.Run("matching value passes", func(t *testing.T) {
+		v := valtra.Val("123").Validate(valtra.MatchRegexp(re))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("non-matching value fails", func(t *testing.T) {
+		v := valtra.Val("12a").Validate(valtra.MatchRegexp(re))
+		if v.IsValid() {
+			t.Error("Expected validation to fail for non-matching value")
+		}
+	})
+}
+
 func TestOneOf(t *testing.T) {
 	t.Run("valid one of passes", func(t *testing.T) {
 		v := valtra.Val("delivered").Validate(valtra.OneOf([]string{"shipped", "delivered"}))
@@ -312,9 +656,44 @@ func TestOneOf(t *testing.T) {
 		}
 	})
 
+	t.Run("error lists allowed values", func(t *testing.T) {
+		v := valtra.Val("GBP", "currency").Validate(valtra.OneOf([]string{"EUR", "USD", "BGN"}))
+		if v.Errors()[0].Error() != "currency must be one of: EUR, USD, BGN" {
+			t.Errorf("Unexpected error message: %q", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("close matches are suggested", func(t *testing.T) {
+		v := valtra.Val("eut", "currency").Validate(valtra.OneOf([]string{"EUR", "USD", "BGN"}))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) || ve.Params["suggestion"] != "EUR" {
+			t.Fatalf("Expected suggestion EUR, got %v", v.Errors())
+		}
+		if ve.Error() != "currency must be one of: EUR, USD, BGN (did you mean EUR?)" {
+			t.Errorf("Unexpected error message: %q", ve.Error())
+		}
+	})
+
+	t.Run("distant values get no suggestion", func(t *testing.T) {
+		v := valtra.Val("pending", "status").Validate(valtra.OneOf([]string{"shipped", "returned"}))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Errors()[0], &ve) || ve.Params["suggestion"] != nil {
+			t.Errorf("Expected no suggestion, got %v", v.Errors())
+		}
+	})
+
+	t.Run("works with other comparable types", func(t *testing.T) {
+		v := valtra.Val(3).Validate(valtra.OneOf([]int{1, 2}))
+		if v.Errors()[0].Error() != "value must be one of: 1, 2" {
+			t.Errorf("Unexpected error message: %q", v.Errors()[0].Error())
+		}
+	})
+
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Value must be one of provided"
-		v := valtra.Val("delivered").Validate(valtra.OneOf([]string{"shipped", "returned"}, customMsg))
+		v := valtra.Val("delivered").Validate(valtra.OneOf([]string{"shipped", "returned"}, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -326,7 +705,7 @@ func TestOneOf(t *testing.T) {
 
 func TestForbidden(t *testing.T) {
 	t.Run("valid not in passes", func(t *testing.T) {
-		v := valtra.Val("delivered").Validate(valtra.NotIn([]string{"shipped", "returned"}))
+		v := valtra.Val("delivered").Validate(valtra.NotInValues("shipped", "returned"))
 		if !v.IsValid() {
 			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
 		}
@@ -341,7 +720,7 @@ func TestForbidden(t *testing.T) {
 
 	t.Run("custom error message", func(t *testing.T) {
 		customMsg := "Value cannot be one of provided"
-		v := valtra.Val("delivered").Validate(valtra.NotIn([]string{"shipped", "delivered"}, customMsg))
+		v := valtra.Val("delivered").Validate(valtra.NotIn([]string{"shipped", "delivered"}, valtra.WithMessage(customMsg)))
 		if v.IsValid() {
 			t.Error("Expected validation to fail")
 		}
@@ -351,6 +730,31 @@ func TestForbidden(t *testing.T) {
 	})
 }
 
+func TestAllowedKeys(t *testing.T) {
+	allowed := valtra.AllowedKeys[string, any]([]string{"theme", "language", "timezone"})
+
+	t.Run("known keys pass", func(t *testing.T) {
+		v := valtra.Val(map[string]any{"theme": "dark"}).Validate(allowed)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("unknown keys are listed", func(t *testing.T) {
+		v := valtra.Val(map[string]any{"color": 1, "font": 2}, "settings").Validate(allowed)
+		if v.IsValid() || v.Errors()[0].Error() != "settings contains unknown keys" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("misspelt key gets a suggestion", func(t *testing.T) {
+		v := valtra.Val(map[string]any{"langauge": "en"}, "settings").Validate(allowed)
+		if v.IsValid() || v.Errors()[0].Error() != "settings contains unknown keys (did you mean language?)" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
+
 func TestMultipleValidations(t *testing.T) {
 	t.Run("accumulates multiple errors", func(t *testing.T) {
 		v := valtra.Val("ab").Validate(
@@ -376,3 +780,185 @@ func TestMultipleValidations(t *testing.T) {
 		}
 	})
 }
+
+func TestUnique(t *testing.T) {
+	t.Run("distinct elements pass", func(t *testing.T) {
+		v := valtra.Val([]string{"go", "rust"}).Validate(valtra.Unique[string]())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("error names the duplicate and its index", func(t *testing.T) {
+		v := valtra.Val([]string{"go", "rust", "go", "rust"}, "tags").Validate(valtra.Unique[string]())
+		if v.IsValid() || v.Errors()[0].Error() != "tags contains a duplicate at index 2" {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("empty slices pass", func(t *testing.T) {
+		v := valtra.Val([]int(nil)).Validate(valtra.Unique[int]())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestUniqueBy(t *testing.T) {
+	type item struct {
+		SKU string
+		Qty int
+	}
+	bySKU := valtra.UniqueBy(func(i item) string { return i.SKU })
+
+	t.Run("distinct keys pass", func(t *testing.T) {
+		v := valtra.Val([]item{{"A1", 1}, {"B2", 1}}).Validate(bySKU)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("repeated keys fail", func(t *testing.T) {
+		v := valtra.Val([]item{{"A1", 1}, {"B2", 1}, {"A1", 3}}, "items").Validate(bySKU)
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.FirstError(), &ve) || ve.Params["duplicate"] != "A1" || ve.Params["index"] != 2 {
+			t.Errorf("Unexpected errors: %v", v.Errors())
+		}
+	})
+}
+
+func TestMinStringMaxString(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		valid bool
+	}{
+		{"above min", valtra.MinString("v2")(valtra.Val("v3")), true},
+		{"at min", valtra.MinString("v2")(valtra.Val("v2")), true},
+		{"below min", valtra.MinString("v2")(valtra.Val("v10")), false},
+		{"below max", valtra.MaxString("M")(valtra.Val("Alice")), true},
+		{"above max", valtra.MaxString("M")(valtra.Val("Zoe")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if valid := tt.err == nil; valid != tt.valid {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, tt.err)
+			}
+		})
+	}
+
+	t.Run("error message", func(t *testing.T) {
+		v := valtra.Val("A", "initial").Validate(valtra.MinString("B"))
+		if want := "initial must not sort before B"; v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %q", want, v.FirstError())
+		}
+	})
+}
+
+func TestGreaterThanLessThan(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	byLength := func(a, b string) int { return len(a) - len(b) }
+
+	tests := []struct {
+		name  string
+		err   error
+		valid bool
+	}{
+		{"greater int", valtra.GreaterThan(5)(valtra.Val(6)), true},
+		{"equal int", valtra.GreaterThan(5)(valtra.Val(5)), false},
+		{"less float", valtra.LessThan(1.5)(valtra.Val(1.25)), true},
+		{"greater string", valtra.GreaterThan("b")(valtra.Val("c")), true},
+		{"less string", valtra.LessThan("b")(valtra.Val("c")), false},
+		{"named type", valtra.LessThan(time.March)(valtra.Val(time.January)), true},
+		{"later time", valtra.GreaterThan(start)(valtra.Val(start.Add(time.Hour))), true},
+		{"same time", valtra.GreaterThan(start)(valtra.Val(start)), false},
+		{"earlier time", valtra.LessThan(start)(valtra.Val(start.Add(-time.Hour))), true},
+		{"comparator", valtra.LessThan("zz", valtra.Comparator(byLength))(valtra.Val("a")), true},
+		{"comparator fails", valtra.LessThan("zz", valtra.Comparator(byLength))(valtra.Val("aaa")), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ve *valtra.ValidationError
+			if valid := tt.err == nil; valid != tt.valid || !valid && !errors.As(tt.err, &ve) {
+				t.Errorf("Expected valid=%v, got %v", tt.valid, tt.err)
+			}
+		})
+	}
+
+	t.Run("error message", func(t *testing.T) {
+		v := valtra.Val(start, "ends_at").Validate(valtra.GreaterThan(start))
+		if want := "ends_at must be greater than 2025-01-01T00:00:00Z"; v.FirstError().Error() != want {
+			t.Errorf("Expected %q, got %q", want, v.FirstError())
+		}
+	})
+}
+
+func TestInvalidRule(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"between", valtra.Val(5).Validate(valtra.Between(10, 1)).FirstError()},
+		{"negative max length", valtra.Val("").Validate(valtra.MaxLengthString(-1)).FirstError()},
+		{"negative min length", valtra.Val([]int{1}).Validate(valtra.MinLengthSlice[int](-2)).FirstError()},
+		{"negative max runes", valtra.Val("").Validate(valtra.MaxRunes(-1)).FirstError()},
+		{"negative min graphemes", valtra.Val("").Validate(valtra.MinGraphemes(-1)).FirstError()},
+		{"negative decimal places", valtra.Val(1.5).Validate(valtra.MaxDecimalPlaces[float64](-1)).FirstError()},
+		{"empty one of", valtra.Val("a").Validate(valtra.OneOfValues[string]()).FirstError()},
+		{"between time", valtra.Val(time.Now()).Validate(valtra.BetweenTime(time.Now(), time.Now().Add(-time.Hour))).FirstError()},
+		{"duration between", valtra.Val("1m").Validate(valtra.DurationBetween(time.Hour, time.Second)).FirstError()},
+		{"quantity between", valtra.Val("1Gi").Validate(valtra.QuantityBetween("4Gi", "1Gi")).FirstError()},
+		{"quantity bound", valtra.Val("1Gi").Validate(valtra.QuantityBetween("lots", "")).FirstError()},
+		{"numeric string range", valtra.Val("5").Validate(valtra.NumericStringRange("10", "1")).FirstError()},
+		{"numeric string bound", valtra.Val("5").Validate(valtra.NumericStringRange("", "ten")).FirstError()},
+		{"multiple of zero", valtra.Val(4).Validate(valtra.MultipleOf(0)).FirstError()},
+		{"invalid pattern", valtra.Val("a").Validate(valtra.Match("(")).FirstError()},
+		{"invalid window start", valtra.Val(time.Now()).Validate(valtra.WithinDailyWindow("8am", "20:00", time.UTC)).FirstError()},
+		{"invalid window end", valtra.Val(time.Now()).Validate(valtra.WithinDailyWindow("08:00", "25:00", time.UTC)).FirstError()},
+		{"empty window", valtra.Val(time.Now()).Validate(valtra.WithinDailyWindow("08:00", "08:00:00", time.UTC)).FirstError()},
+		{"window without location", valtra.Val(time.Now()).Validate(valtra.WithinDailyWindow("08:00", "20:00", nil)).FirstError()},
+		{"no URL schemes", valtra.Val("https://example.com").Validate(valtra.URLWithSchemes(nil)).FirstError()},
+		{"no content types", valtra.Val([]byte("x")).Validate(valtra.DetectedContentType(nil)).FirstError()},
+		{"unsupported unit", valtra.Val(valtra.Measure{Amount: 1, Unit: "C"}).Validate(valtra.Measurement("furlong", 0, 1)).FirstError()},
+		{"measurement between", valtra.Val(valtra.Measure{Amount: 1, Unit: valtra.Celsius}).Validate(valtra.Measurement(valtra.Celsius, 42, 35)).FirstError()},
+		{"unsupported phone region", valtra.Val("+442071838750").Validate(valtra.PhoneNumberForRegion("XX")).FirstError()},
+		{"no card brands", valtra.Val("4111111111111111").Validate(valtra.CreditCardBrands(nil)).FirstError()},
+		{"UUID version", valtra.Val("f47ac10b-58cc-4372-a567-0e02b2c3d479").Validate(valtra.UUIDVersion(9)).FirstError()},
+		{"negative hex length", valtra.Val("9f").Validate(valtra.Hex(-1)).FirstError()},
+		{"no order", valtra.Val(struct{ n int }{2}).Validate(valtra.GreaterThan(struct{ n int }{1})).FirstError()},
+		{"mismatched comparator", valtra.Val(2).Validate(valtra.LessThan(1, valtra.Comparator(func(a, b string) int { return 0 }))).FirstError()},
+		{"unregistered rule", valtra.Val(1).Validate(valtra.Registered[int]("not-registered")).FirstError()},
+		{"negative retries", valtra.Val(1).ValidateCtx(context.Background(), valtra.Remote(func(context.Context, valtra.Value[int]) error { return nil }, valtra.WithRetry(-1, 0))).FirstError()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !errors.Is(tt.err, valtra.ErrInvalidRule) {
+				t.Errorf("Expected an error wrapping ErrInvalidRule, got %v", tt.err)
+			}
+
+			var ve *valtra.ValidationError
+			if errors.As(tt.err, &ve) {
+				t.Errorf("Expected an invalid rule not to blame the value, got %v", ve)
+			}
+		})
+	}
+
+	t.Run("error explains the rule", func(t *testing.T) {
+		err := valtra.Val(5).Validate(valtra.Between(10, 1)).FirstError()
+		want := "valtra: invalid rule: Between has a minimum (10) above its maximum (1)"
+		if err == nil || err.Error() != want {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	})
+
+	t.Run("equal bounds are valid", func(t *testing.T) {
+		v := valtra.Val(5).Validate(valtra.Between(5, 5), valtra.OneOfValues(5))
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+}
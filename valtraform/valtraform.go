@@ -0,0 +1,263 @@
+// Package valtraform binds HTML form submissions to structs
+// and validates them with valtra, for classic server-rendered
+// form flows.
+//
+// Binding yields a Form holding both the submitted values and
+// the per-field errors, ready to be rendered back into the
+// template when validation fails.
+package valtraform
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// maxMemory is the number of bytes of a multipart form kept
+// in memory, with the remainder stored on disk.
+const maxMemory = 32 << 20
+
+// timeType is the reflect.Type of time.Time.
+var timeType = reflect.TypeFor[time.Time]()
+
+// Form holds the outcome of binding a form submission.
+type Form struct {
+	// Values holds the submitted values, keyed by form field
+	// name, so they can be re-rendered into the form.
+	Values url.Values
+	// Errors holds the validation errors, keyed by form field
+	// name.
+	Errors map[string][]error
+}
+
+// Valid reports whether the submission passed validation.
+func (f *Form) Valid() bool {
+	return len(f.Errors) == 0
+}
+
+// Value returns the first submitted value of the field, or
+// an empty string if there is none.
+func (f *Form) Value(field string) string {
+	return f.Values.Get(field)
+}
+
+// Error returns the message of the field's first error, or an
+// empty string if the field is valid.
+//
+// Example:
+//
+//	<input name="email" value="{{.Value "email"}}">
+//	{{with .Error "email"}}<p class="error">{{.}}</p>{{end}}
+func (f *Form) Error(field string) string {
+	if errs := f.Errors[field]; len(errs) > 0 {
+		return errs[0].Error()
+	}
+
+	return ""
+}
+
+// Bind reads the form and query values of the request into
+// the struct pointed to by dst, and validates it with
+// valtra.ValidateStruct.
+//
+// Fields are bound from the value named by their `form` tag,
+// or by their Go name if they have none; fields tagged
+// `form:"-"` and unexported fields are skipped. Values are
+// converted to strings, booleans (including the "on" sent by
+// checkboxes), integers, floats, times and slices of those,
+// and pointers to them. Times are parsed with the layout in
+// the `layout` tag, "2006-01-02" by default. Empty values
+// leave the field unset, so they can be caught by the
+// "required" rule.
+//
+// Conversion failures and failed rules are both reported in
+// the returned Form, keyed by form field name. An error is
+// returned only if dst is not a pointer to a struct, or the
+// request body cannot be parsed.
+//
+// Example:
+//
+//	type Signup struct {
+//	    Email string `form:"email" valtra:"required,email"`
+//	    Age   int    `form:"age" valtra:"min=18"`
+//	}
+//
+//	var dto Signup
+//	form, err := valtraform.Bind(r, &dto)
+//	if err != nil {
+//	    http.Error(w, err.Error(), http.StatusBadRequest)
+//	    return
+//	}
+//	if !form.Valid() {
+//	    tmpl.Execute(w, form)
+//	    return
+//	}
+func Bind(r *http.Request, dst any) (*Form, error) {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("valtraform: Bind expects a pointer to a struct, got %T", dst)
+	}
+
+	err := r.ParseMultipartForm(maxMemory)
+	if err != nil && !errors.Is(err, http.ErrNotMultipart) {
+		return nil, fmt.Errorf("valtraform: parsing form: %w", err)
+	}
+
+	form := &Form{Values: r.Form, Errors: map[string][]error{}}
+
+	// fieldNames maps the names valtra uses for the fields to
+	// their form field names.
+	fieldNames := map[string]string{}
+
+	rv = rv.Elem()
+	rt := rv.Type()
+	for i := range rt.NumField() {
+		sf := rt.Field(i)
+		name := formFieldName(sf)
+		if !sf.IsExported() || name == "" {
+			continue
+		}
+		fieldNames[valtraFieldName(sf)] = name
+
+		values := r.Form[name]
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := bindField(rv.Field(i), sf, name, values); err != nil {
+			form.Errors[name] = append(form.Errors[name], err)
+		}
+	}
+
+	for field, errs := range valtra.ValidateStruct(dst).ErrorsByField() {
+		if name, ok := fieldNames[field]; ok {
+			field = name
+		}
+
+		// Rules are not reported for fields that failed to
+		// convert, as they only see the zero value.
+		if _, failed := form.Errors[field]; !failed {
+			form.Errors[field] = errs
+		}
+	}
+
+	return form, nil
+}
+
+// formFieldName returns the form field name bound to the
+// struct field, or an empty string if it is skipped.
+func formFieldName(sf reflect.StructField) string {
+	switch name, _, _ := strings.Cut(sf.Tag.Get("form"), ","); name {
+	case "-":
+		return ""
+	case "":
+		return sf.Name
+	default:
+		return name
+	}
+}
+
+// valtraFieldName returns the name valtra.ValidateStruct uses
+// for the struct field.
+func valtraFieldName(sf reflect.StructField) string {
+	if name, _, _ := strings.Cut(sf.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+
+	return sf.Name
+}
+
+// bindField converts the submitted values and stores them in
+// the field.
+func bindField(fv reflect.Value, sf reflect.StructField, name string, values []string) error {
+	if fv.Kind() == reflect.Slice && fv.Type().Elem().Kind() != reflect.Uint8 {
+		slice := reflect.MakeSlice(fv.Type(), 0, len(values))
+		for _, raw := range values {
+			if raw == "" {
+				continue
+			}
+
+			elem := reflect.New(fv.Type().Elem()).Elem()
+			if err := setValue(elem, sf, name, raw); err != nil {
+				return err
+			}
+			slice = reflect.Append(slice, elem)
+		}
+
+		fv.Set(slice)
+		return nil
+	}
+
+	if values[0] == "" {
+		return nil
+	}
+
+	return setValue(fv, sf, name, values[0])
+}
+
+// setValue converts the raw value to the type of fv and
+// stores it, allocating pointers as needed.
+func setValue(fv reflect.Value, sf reflect.StructField, name string, raw string) error {
+	if fv.Kind() == reflect.Pointer {
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setValue(ptr.Elem(), sf, name, raw); err != nil {
+			return err
+		}
+
+		fv.Set(ptr)
+		return nil
+	}
+
+	v := valtra.Val(raw, name)
+	if fv.Type() == timeType {
+		layout := sf.Tag.Get("layout")
+		if layout == "" {
+			layout = time.DateOnly
+		}
+
+		t := valtra.Convert(v, valtra.ParseTime(layout))
+		fv.Set(reflect.ValueOf(t.Value()))
+		return t.FirstError()
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(raw)
+		return nil
+	case reflect.Bool:
+		if strings.EqualFold(raw, "on") {
+			raw = "true"
+		}
+
+		b := valtra.Convert(valtra.Val(raw, name), valtra.ParseBool())
+		fv.SetBool(b.Value())
+		return b.FirstError()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		bits := fv.Type().Bits()
+		n := valtra.Convert(v, valtra.ParseInt()).Validate(valtra.Between(-1<<(bits-1), 1<<(bits-1)-1))
+		fv.SetInt(int64(n.Value()))
+		return n.FirstError()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		maxUint := math.MaxInt
+		if bits := fv.Type().Bits(); bits < 64 {
+			maxUint = 1<<bits - 1
+		}
+
+		n := valtra.Convert(v, valtra.ParseInt()).Validate(valtra.Between(0, maxUint))
+		fv.SetUint(uint64(n.Value()))
+		return n.FirstError()
+	case reflect.Float32, reflect.Float64:
+		f := valtra.Convert(v, valtra.ParseFloat())
+		fv.SetFloat(f.Value())
+		return f.FirstError()
+	}
+
+	return fmt.Errorf("valtraform: unsupported type %s for field %s", fv.Type(), name)
+}
@@ -0,0 +1,116 @@
+package valtraform_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go/valtraform"
+)
+
+type signup struct {
+	Email      string     `form:"email" valtra:"required,email"`
+	Age        int        `form:"age" valtra:"min=18"`
+	Newsletter bool       `form:"newsletter"`
+	Tags       []string   `form:"tag" valtra:"max=2"`
+	Birthday   *time.Time `form:"birthday"`
+	Ignored    string     `form:"-"`
+}
+
+func post(values url.Values) *http.Request {
+	r := httptest.NewRequest(http.MethodPost, "/signup?source=ad", strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func TestBind(t *testing.T) {
+	t.Run("valid submission is bound", func(t *testing.T) {
+		var dto signup
+		form, err := valtraform.Bind(post(url.Values{
+			"email":      {"bobby@example.com"},
+			"age":        {"30"},
+			"newsletter": {"on"},
+			"tag":        {"go", "forms"},
+			"birthday":   {"1995-04-01"},
+			"Ignored":    {"x"},
+		}), &dto)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if !form.Valid() {
+			t.Fatalf("Expected form to be valid, got errors: %v", form.Errors)
+		}
+
+		if dto.Email != "bobby@example.com" || dto.Age != 30 || !dto.Newsletter || len(dto.Tags) != 2 || dto.Ignored != "" {
+			t.Errorf("Unexpected bound values: %+v", dto)
+		}
+		if dto.Birthday == nil || dto.Birthday.Year() != 1995 {
+			t.Errorf("Expected birthday to be bound, got %v", dto.Birthday)
+		}
+		if form.Value("source") != "ad" {
+			t.Errorf("Expected query values to be kept, got %q", form.Value("source"))
+		}
+	})
+
+	t.Run("errors are keyed by form field name", func(t *testing.T) {
+		var dto signup
+		form, err := valtraform.Bind(post(url.Values{
+			"email": {"not-an-email"},
+			"age":   {"16"},
+			"tag":   {"a", "b", "c"},
+		}), &dto)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, field := range []string{"email", "age", "tag"} {
+			if form.Error(field) == "" {
+				t.Errorf("Expected an error for %s, got %v", field, form.Errors)
+			}
+		}
+		if form.Value("email") != "not-an-email" {
+			t.Errorf("Expected submitted value to be kept, got %q", form.Value("email"))
+		}
+	})
+
+	t.Run("conversion failures replace rule errors", func(t *testing.T) {
+		var dto signup
+		form, _ := valtraform.Bind(post(url.Values{"email": {"bobby@example.com"}, "age": {"thirty"}}), &dto)
+
+		if len(form.Errors["age"]) != 1 || form.Error("age") != "age must be a whole number" {
+			t.Errorf("Expected conversion error only, got %v", form.Errors["age"])
+		}
+	})
+
+	t.Run("out of range integers fail", func(t *testing.T) {
+		var dto struct {
+			Level int8 `form:"level"`
+		}
+		form, _ := valtraform.Bind(post(url.Values{"level": {"300"}}), &dto)
+		if form.Valid() {
+			t.Error("Expected form to be invalid for overflowing value")
+		}
+	})
+
+	t.Run("empty values leave fields unset", func(t *testing.T) {
+		var dto signup
+		form, _ := valtraform.Bind(post(url.Values{"email": {""}, "age": {""}}), &dto)
+
+		if form.Error("email") != "Email is required" {
+			t.Errorf("Expected required error, got %v", form.Errors)
+		}
+		if len(form.Errors["age"]) != 1 {
+			t.Errorf("Expected min error for age, got %v", form.Errors["age"])
+		}
+	})
+
+	t.Run("non-struct destination", func(t *testing.T) {
+		var s string
+		if _, err := valtraform.Bind(post(nil), &s); err == nil {
+			t.Error("Expected error for non-struct destination")
+		}
+	})
+}
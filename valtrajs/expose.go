@@ -0,0 +1,34 @@
+//go:build js && wasm
+
+package valtrajs
+
+import (
+	"encoding/json"
+	"syscall/js"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// Expose sets a global JavaScript function with the given name
+// that applies the schema to its argument, as Check does, and
+// returns the Result as an object.
+//
+// The function lives as long as the program, which must keep
+// running (e.g. by blocking in main) for it to be called.
+func Expose[T any](name string, schema valtra.Schema[T]) {
+	jsonObject := js.Global().Get("JSON")
+
+	js.Global().Set(name, js.FuncOf(func(this js.Value, args []js.Value) any {
+		input := "null"
+		if len(args) > 0 && !args[0].IsUndefined() {
+			input = jsonObject.Call("stringify", args[0]).String()
+		}
+
+		out, err := json.Marshal(Check(schema, []byte(input)))
+		if err != nil {
+			out, _ = json.Marshal(Result{Errors: []FieldError{{Message: err.Error()}}})
+		}
+
+		return jsonObject.Call("parse", string(out))
+	}))
+}
@@ -0,0 +1,93 @@
+// Package valtrajs shares valtra schemas with JavaScript, so
+// that a form can be validated in the browser with the same
+// rules as on the server.
+//
+// A program built for WebAssembly (GOOS=js GOARCH=wasm)
+// exposes its schemas as global JavaScript functions with
+// Expose. Each function takes a value, which is passed through
+// JSON to the schema's T, and returns a Result as a plain
+// object:
+//
+//	func main() {
+//	    valtrajs.Expose("validateSignup", signupSchema)
+//	    select {}
+//	}
+//
+// and in the browser:
+//
+//	const result = validateSignup({email: form.email.value, age: Number(form.age.value)})
+//	if (!result.valid) {
+//	    showErrors(result.errors)
+//	}
+//
+// Check applies a schema the same way on any platform.
+package valtrajs
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// FieldError describes a single failed validation in a Result.
+type FieldError struct {
+	Field   string `json:"field,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result is the outcome of applying a schema to a value.
+type Result struct {
+	// Valid reports whether the value passed the schema.
+	Valid bool `json:"valid"`
+	// Value holds the (possibly transformed) value, if the
+	// input could be decoded.
+	Value any `json:"value,omitempty"`
+	// Errors lists the failed validations.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+// Check decodes the JSON input into T and applies the schema
+// to it. Input that cannot be decoded into T fails with the
+// code "invalid_json".
+//
+// Example:
+//
+//	result := valtrajs.Check(signupSchema, []byte(`{"email":"bobby@example.com","age":16}`))
+//	result.Errors // [{age min age cannot be smaller than 18}]
+func Check[T any](schema valtra.Schema[T], input []byte) Result {
+	var value T
+	if err := json.Unmarshal(input, &value); err != nil {
+		return Result{Errors: []FieldError{{Code: "invalid_json", Message: "value must be valid JSON"}}}
+	}
+
+	v := schema.Apply(valtra.Val(value))
+	result := Result{Valid: v.IsValid(), Value: v.Value()}
+	for _, err := range v.Errors() {
+		result.Errors = append(result.Errors, fieldErrors(err)...)
+	}
+
+	return result
+}
+
+// fieldErrors flattens the given (possibly joined) error into
+// field errors. Errors other than validation errors are kept
+// with their message only.
+func fieldErrors(err error) []FieldError {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var fields []FieldError
+		for _, e := range joined.Unwrap() {
+			fields = append(fields, fieldErrors(e)...)
+		}
+
+		return fields
+	}
+
+	var ve *valtra.ValidationError
+	if !errors.As(err, &ve) {
+		return []FieldError{{Message: err.Error()}}
+	}
+
+	return []FieldError{{Field: ve.Field, Code: ve.Code, Message: ve.Message}}
+}
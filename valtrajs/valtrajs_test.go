@@ -0,0 +1,45 @@
+package valtrajs_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/valtrajs"
+)
+
+type signup struct {
+	Email string `json:"email"`
+	Age   int    `json:"age"`
+}
+
+var signupSchema = valtra.NewSchema[signup]().Validate(
+	valtra.Field("email", func(s signup) string { return s.Email }, valtra.Email()),
+	valtra.Field("age", func(s signup) int { return s.Age }, valtra.Min(18)),
+)
+
+func TestCheck(t *testing.T) {
+	t.Run("valid values pass", func(t *testing.T) {
+		result := valtrajs.Check(signupSchema, []byte(`{"email":"bobby@example.com","age":30}`))
+		if !result.Valid || len(result.Errors) != 0 || result.Value != (signup{"bobby@example.com", 30}) {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+
+	t.Run("failures are listed by field", func(t *testing.T) {
+		result := valtrajs.Check(signupSchema, []byte(`{"email":"nope","age":16}`))
+		if result.Valid || len(result.Errors) != 2 {
+			t.Fatalf("Unexpected result: %+v", result)
+		}
+
+		if e := result.Errors[1]; e.Field != "age" || e.Code != "min" || e.Message != "age cannot be smaller than 18" {
+			t.Errorf("Unexpected error: %+v", e)
+		}
+	})
+
+	t.Run("invalid JSON fails", func(t *testing.T) {
+		result := valtrajs.Check(valtra.NewSchema[int](), []byte(`"old"`))
+		if result.Valid || len(result.Errors) != 1 || result.Errors[0].Code != "invalid_json" {
+			t.Errorf("Unexpected result: %+v", result)
+		}
+	})
+}
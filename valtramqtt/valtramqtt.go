@@ -0,0 +1,145 @@
+// Package valtramqtt validates MQTT messages for IoT
+// ingestion services, checking their topics and validating
+// their payloads against valtra schemas registered per topic
+// filter.
+package valtramqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// ErrNoRoute is returned (wrapped) when no registered filter
+// matches a message's topic.
+var ErrNoRoute = errors.New("valtramqtt: no schema for topic")
+
+// ErrInvalidPayload is returned (wrapped) when a payload
+// cannot be decoded into its route's Go type.
+var ErrInvalidPayload = errors.New("valtramqtt: invalid payload")
+
+// route is a topic filter with its payload decoder.
+type route struct {
+	levels []string
+	decode func(payload []byte) (any, error)
+}
+
+// Router validates messages according to the schemas
+// registered for the topic filters matching their topics. It
+// is safe for concurrent use.
+type Router struct {
+	mu        sync.RWMutex
+	maxLevels int
+	routes    []route
+}
+
+// NewRouter creates and returns a Router without any routes.
+// A maxLevels larger than 0 limits the number of levels of
+// the topics it accepts.
+func NewRouter(maxLevels int) *Router {
+	return &Router{maxLevels: maxLevels}
+}
+
+// Handle registers the schema for the payloads of messages
+// whose topics match the filter, e.g. "sensors/+/temperature".
+// Topics are matched against filters in the order they were
+// registered.
+//
+// Payloads are decoded from JSON into a T, unless T is []byte,
+// in which case binary payloads are validated as is.
+//
+// It returns an error if the filter is not a valid MQTT topic
+// filter.
+//
+// Example:
+//
+//	r := valtramqtt.NewRouter(8)
+//	valtramqtt.Handle(r, "sensors/+/temperature", temperatureSchema)
+//	valtramqtt.Handle(r, "cameras/+/snapshot", snapshotSchema) // Schema[[]byte]
+func Handle[T any](r *Router, filter string, schema valtra.Schema[T]) error {
+	if err := valtra.Val(filter, "filter").Validate(valtra.MQTTTopicFilter(r.maxLevels)).Err(); err != nil {
+		return err
+	}
+
+	decode := func(payload []byte) (any, error) {
+		var value T
+		if raw, ok := any(&value).(*[]byte); ok {
+			*raw = payload
+		} else if err := json.Unmarshal(payload, &value); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidPayload, err)
+		}
+
+		return schema.Run(value, "payload")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = append(r.routes, route{levels: strings.Split(filter, "/"), decode: decode})
+	return nil
+}
+
+// Validate checks the topic of a message with
+// valtra.MQTTTopic, and validates its payload with the schema
+// of the first route whose filter matches the topic.
+//
+// It returns the decoded (and possibly transformed) payload,
+// of the Go type of the route, along with any errors joined
+// into a single error. Topics without a route return an error
+// wrapping ErrNoRoute, and payloads that cannot be decoded one
+// wrapping ErrInvalidPayload.
+//
+// Example:
+//
+//	payload, err := router.Validate(msg.Topic(), msg.Payload())
+//	if err != nil {
+//	    deadLetter(msg, err)
+//	    return
+//	}
+func (r *Router) Validate(topic string, payload []byte) (any, error) {
+	if err := valtra.Val(topic, "topic").Validate(valtra.MQTTTopic(r.maxLevels)).Err(); err != nil {
+		return nil, err
+	}
+
+	levels := strings.Split(topic, "/")
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, rt := range r.routes {
+		if matches(rt.levels, levels) {
+			return rt.decode(payload)
+		}
+	}
+
+	return nil, fmt.Errorf("%w %q", ErrNoRoute, topic)
+}
+
+// matches reports whether the levels of a topic match those
+// of a filter.
+//
+// As required by the MQTT specification, wildcards at the
+// first level do not match topics starting with "$", such as
+// "$SYS/broker/uptime".
+func matches(filter, topic []string) bool {
+	if strings.HasPrefix(topic[0], "$") && (filter[0] == "+" || filter[0] == "#") {
+		return false
+	}
+
+	for i, level := range filter {
+		switch {
+		case level == "#":
+			return true
+		case i >= len(topic):
+			return false
+		case level != "+" && level != topic[i]:
+			return false
+		}
+	}
+
+	return len(filter) == len(topic)
+}
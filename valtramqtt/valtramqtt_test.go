@@ -0,0 +1,92 @@
+package valtramqtt_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/valtramqtt"
+)
+
+type reading struct {
+	Celsius float64 `json:"celsius"`
+}
+
+func router(t *testing.T) *valtramqtt.Router {
+	r := valtramqtt.NewRouter(4)
+
+	temperature := valtra.NewSchema[reading]().Validate(
+		valtra.Field("celsius", func(r reading) float64 { return r.Celsius }, valtra.Between(-50.0, 150.0)),
+	)
+	snapshot := valtra.NewSchema[[]byte]().Validate(valtra.MaxLengthSlice[byte](8))
+
+	if err := valtramqtt.Handle(r, "sensors/+/temperature", temperature); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := valtramqtt.Handle(r, "cameras/#", snapshot); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	return r
+}
+
+func TestHandle(t *testing.T) {
+	r := valtramqtt.NewRouter(0)
+	if err := valtramqtt.Handle(r, "sensors/#/temperature", valtra.NewSchema[reading]()); err == nil {
+		t.Error("Expected error for invalid filter")
+	}
+}
+
+func TestRouterValidate(t *testing.T) {
+	r := router(t)
+
+	t.Run("JSON payload is decoded and validated", func(t *testing.T) {
+		payload, err := r.Validate("sensors/kitchen/temperature", []byte(`{"celsius":21.5}`))
+		if got, ok := payload.(reading); err != nil || !ok || got.Celsius != 21.5 {
+			t.Errorf("Unexpected payload %v (error: %v)", payload, err)
+		}
+	})
+
+	t.Run("binary payload is validated as is", func(t *testing.T) {
+		if _, err := r.Validate("cameras/door/snapshot", []byte("jpeg")); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		if _, err := r.Validate("cameras/door", []byte("far too large")); !errors.Is(err, &valtra.ValidationError{Code: "max_length"}) {
+			t.Errorf("Expected max_length error, got %v", err)
+		}
+	})
+
+	t.Run("invalid payload values fail", func(t *testing.T) {
+		_, err := r.Validate("sensors/kitchen/temperature", []byte(`{"celsius":400}`))
+		if !errors.Is(err, &valtra.ValidationError{Code: "between", Field: "celsius"}) {
+			t.Errorf("Expected between error, got %v", err)
+		}
+	})
+
+	t.Run("undecodable payload", func(t *testing.T) {
+		_, err := r.Validate("sensors/kitchen/temperature", []byte(`hot`))
+		if !errors.Is(err, valtramqtt.ErrInvalidPayload) {
+			t.Errorf("Expected ErrInvalidPayload, got %v", err)
+		}
+	})
+
+	t.Run("invalid topics", func(t *testing.T) {
+		for _, topic := range []string{"sensors/+/temperature", "a/b/c/d/e", ""} {
+			if _, err := r.Validate(topic, nil); !errors.Is(err, &valtra.ValidationError{Code: "mqtt_topic"}) {
+				t.Errorf("Expected mqtt_topic error for %q, got %v", topic, err)
+			}
+		}
+	})
+
+	t.Run("unmatched topics", func(t *testing.T) {
+		if err := valtramqtt.Handle(r, "+/broker/uptime", valtra.NewSchema[[]byte]()); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for _, topic := range []string{"sensors/kitchen/humidity", "sensors/kitchen", "$SYS/broker/uptime"} {
+			if _, err := r.Validate(topic, nil); !errors.Is(err, valtramqtt.ErrNoRoute) {
+				t.Errorf("Expected ErrNoRoute for %q, got %v", topic, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package valtratest
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// AssertValid fails the test if the result, such as a Value
+// or a Collector, has errors.
+//
+// Example:
+//
+//	valtratest.AssertValid(t, valtra.Val("bob@example.com").Validate(valtra.Email()))
+func AssertValid(t testing.TB, v valtra.Validated) {
+	t.Helper()
+
+	if !v.IsValid() {
+		t.Errorf("expected no errors, got %v", v.Errors())
+	}
+}
+
+// AssertFails fails the test unless the result, such as a
+// Value or a Collector, has a validation error with the given
+// code. Joined errors are searched too.
+//
+// Example:
+//
+//	valtratest.AssertFails(t, valtra.Val("").Validate(valtra.Required[string]()), "required")
+func AssertFails(t testing.TB, v valtra.Validated, code string) {
+	t.Helper()
+
+	if !hasCode(v.Errors(), code) {
+		t.Errorf("expected a %q error, got %v", code, v.Errors())
+	}
+}
+
+// AssertFieldError fails the test unless the collector has a
+// validation error with the given code for the named field.
+// Fields of prefixed collectors are named with their prefix,
+// e.g. "address.city".
+//
+// Example:
+//
+//	valtratest.AssertFieldError(t, c, "email", "email")
+func AssertFieldError(t testing.TB, c *valtra.Collector, field, code string) {
+	t.Helper()
+
+	if errs := c.ErrorsByField()[field]; !hasCode(errs, code) {
+		t.Errorf("expected a %q error for %s, got %v", code, field, errs)
+	}
+}
+
+// hasCode reports whether any of the errors is, or joins, a
+// validation error with the given code.
+func hasCode(errs []error, code string) bool {
+	for _, err := range errs {
+		if errors.Is(err, &valtra.ValidationError{Code: code}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Case is a case of a table-driven rule test run with
+// RunRule.
+type Case[T any] struct {
+	// Name names the subtest. The value is used if it is
+	// empty.
+	Name string
+	// Value is the value the rule is applied to.
+	Value T
+	// Code is the code of the error the rule must fail with,
+	// or empty if the value must pass.
+	Code string
+}
+
+// RunRule runs the rule on each case in its own subtest,
+// checking that it passes or fails with the expected code.
+//
+// Example:
+//
+//	valtratest.RunRule(t, valtra.Between(1, 10), []valtratest.Case[int]{
+//	    {Value: 5},
+//	    {Value: 0, Code: "between"},
+//	    {Name: "above range", Value: 11, Code: "between"},
+//	})
+func RunRule[T any](t *testing.T, rule func(valtra.Value[T]) error, cases []Case[T]) {
+	t.Helper()
+
+	for _, tc := range cases {
+		name := tc.Name
+		if name == "" {
+			name = fmt.Sprintf("%v", tc.Value)
+		}
+
+		t.Run(name, func(t *testing.T) {
+			t.Helper()
+
+			v := valtra.Val(tc.Value).Validate(rule)
+			if tc.Code == "" {
+				AssertValid(t, v)
+			} else {
+				AssertFails(t, v, tc.Code)
+			}
+		})
+	}
+}
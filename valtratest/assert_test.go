@@ -0,0 +1,63 @@
+package valtratest_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/valtratest"
+)
+
+func TestAssertValid(t *testing.T) {
+	valtratest.AssertValid(t, valtra.Val("bob@example.com").Validate(valtra.Email()))
+
+	r := &recorder{}
+	valtratest.AssertValid(r, valtra.Val("").Validate(valtra.Required[string]()))
+	if len(r.errors) != 1 {
+		t.Errorf("Expected a failure for an invalid value, got %v", r.errors)
+	}
+}
+
+func TestAssertFails(t *testing.T) {
+	t.Run("matching codes pass", func(t *testing.T) {
+		valtratest.AssertFails(t, valtra.Val("").Validate(valtra.Required[string]()), "required")
+		valtratest.AssertFails(t, valtra.Val([]int{}).Validate(valtra.Unique[int](), valtra.MinLengthSlice[int](1)), "min_length")
+	})
+
+	t.Run("joined errors are searched", func(t *testing.T) {
+		v := valtra.Val(0).Validate(valtra.And(valtra.Min(5), valtra.Max(-1)))
+		valtratest.AssertFails(t, v, "max")
+	})
+
+	t.Run("other codes and valid values fail the test", func(t *testing.T) {
+		r := &recorder{}
+		valtratest.AssertFails(r, valtra.Val("").Validate(valtra.Required[string]()), "email")
+		valtratest.AssertFails(r, valtra.Val("bob").Validate(valtra.Required[string]()), "required")
+		if len(r.errors) != 2 {
+			t.Errorf("Expected two failures, got %v", r.errors)
+		}
+	})
+}
+
+func TestAssertFieldError(t *testing.T) {
+	c := valtra.NewCollector()
+	valtra.Val("bob", "email").Validate(valtra.Email()).Collect(c)
+	valtra.Val("", "city").Validate(valtra.Required[string]()).Collect(c.WithPrefix("address"))
+
+	valtratest.AssertFieldError(t, c, "email", "email")
+	valtratest.AssertFieldError(t, c, "address.city", "required")
+
+	r := &recorder{}
+	valtratest.AssertFieldError(r, c, "email", "required")
+	valtratest.AssertFieldError(r, c, "name", "required")
+	if len(r.errors) != 2 {
+		t.Errorf("Expected two failures, got %v", r.errors)
+	}
+}
+
+func TestRunRule(t *testing.T) {
+	valtratest.RunRule(t, valtra.Between(1, 10), []valtratest.Case[int]{
+		{Value: 5},
+		{Value: 0, Code: "between"},
+		{Name: "above range", Value: 11, Code: "between"},
+	})
+}
@@ -0,0 +1,51 @@
+package valtratest
+
+import (
+	"strings"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// SnapshotCodes returns a stable fingerprint of the schema's
+// described rules (see Schema.Describe), one rule per line in
+// the order they are applied, with the field, name and
+// parameters of each, and when and where it applies, if
+// limited.
+//
+// Comparing it with a snapshot kept in the test fails the test
+// whenever a rule or its code changes, so validation changes
+// are always deliberate.
+//
+// Example:
+//
+//	const want = `email: required
+//	email: email
+//	age: between(max=130, min=18)`
+//
+//	if got := valtratest.SnapshotCodes(signupSchema); got != want {
+//	    t.Errorf("signup rules changed:\n%s", got)
+//	}
+func SnapshotCodes[T any](schema valtra.Schema[T]) string {
+	var b strings.Builder
+	for i, ri := range schema.Describe() {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		if ri.Field != "" {
+			b.WriteString(ri.Field + ": ")
+		}
+		b.WriteString(ri.String())
+		if !ri.EffectiveFrom.IsZero() {
+			b.WriteString(" from " + ri.EffectiveFrom.UTC().Format(time.RFC3339))
+		}
+		if !ri.Until.IsZero() {
+			b.WriteString(" until " + ri.Until.UTC().Format(time.RFC3339))
+		}
+		if ri.Country != "" {
+			b.WriteString(" in " + ri.Country)
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,43 @@
+package valtratest_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/valtratest"
+)
+
+func TestSnapshotCodes(t *testing.T) {
+	type signup struct {
+		Email string
+		Age   int
+	}
+
+	schema := func(min int) valtra.Schema[signup] {
+		return valtra.NewSchema[signup]().Rules(
+			valtra.FieldRule("email", func(s signup) string { return s.Email },
+				valtra.Describe("required", nil, valtra.Required[string]()),
+			),
+			valtra.FieldRule("age", func(s signup) int { return s.Age },
+				valtra.Describe("between", map[string]any{"min": min, "max": 130}, valtra.Between(min, 130)),
+			),
+			valtra.Describe("terms", nil, func(valtra.Value[signup]) error { return nil }).
+				EffectiveFrom(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		)
+	}
+
+	want := "email: required\n" +
+		"age: between(max=130, min=18)\n" +
+		"terms from 2026-01-01T00:00:00Z"
+	if got := valtratest.SnapshotCodes(schema(18)); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if valtratest.SnapshotCodes(schema(18)) != valtratest.SnapshotCodes(schema(18)) {
+		t.Error("Expected the snapshot to be stable")
+	}
+	if valtratest.SnapshotCodes(schema(21)) == want {
+		t.Error("Expected a changed rule to change the snapshot")
+	}
+}
@@ -0,0 +1,108 @@
+// Package valtratest provides utilities for testing valtra
+// rules and schemas: assertions on validation results, a
+// runner for table-driven rule tests, and equivalence checks
+// between schemas.
+package valtratest
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// Config configures the values generated by Equivalent. A
+// nil *Config uses the defaults.
+type Config[T any] struct {
+	// Iterations is the number of values to generate. The
+	// default is 1000.
+	Iterations int
+	// Rand is the source of randomness. The default is seeded
+	// randomly, and the seed is logged on failure.
+	Rand *rand.Rand
+	// Generate generates values. The default generates
+	// arbitrary values with testing/quick.
+	Generate func(*rand.Rand) T
+	// Seeds are values that are always checked, in addition to
+	// the generated ones. They are useful for boundary cases
+	// that random generation is unlikely to hit.
+	Seeds []T
+}
+
+// Equivalent checks that schemas a and b agree on the
+// validity of generated values, failing the test with a
+// counterexample if they do not.
+//
+// It de-risks rewriting a schema (e.g. migrating closure-based
+// rules to registered or tag-based ones) by checking the new
+// schema against the original on many inputs.
+//
+// Example:
+//
+//	valtratest.Equivalent(t, originalSchema, refactoredSchema, &valtratest.Config[string]{
+//	    Seeds: []string{"", "a", strings.Repeat("a", 20)},
+//	})
+func Equivalent[T any](t testing.TB, a, b valtra.Schema[T], cfg *Config[T]) {
+	t.Helper()
+
+	if cfg == nil {
+		cfg = &Config[T]{}
+	}
+
+	iterations := cfg.Iterations
+	if iterations <= 0 {
+		iterations = 1000
+	}
+
+	r := cfg.Rand
+	var seed int64
+	if r == nil {
+		seed = rand.Int63()
+		r = rand.New(rand.NewSource(seed))
+	}
+
+	generate := cfg.Generate
+	if generate == nil {
+		generate = arbitrary[T]
+	}
+
+	check := func(value T) bool {
+		_, errA := a.Run(value)
+		_, errB := b.Run(value)
+		if (errA == nil) == (errB == nil) {
+			return true
+		}
+
+		t.Errorf("schemas disagree on %#v:\n\ta: %v\n\tb: %v", value, errA, errB)
+		if cfg.Rand == nil {
+			t.Logf("random seed: %d", seed)
+		}
+		return false
+	}
+
+	for _, value := range cfg.Seeds {
+		if !check(value) {
+			return
+		}
+	}
+	for range iterations {
+		if !check(generate(r)) {
+			return
+		}
+	}
+}
+
+// arbitrary generates an arbitrary value of type T with
+// testing/quick, or the zero value for types it cannot
+// generate.
+func arbitrary[T any](r *rand.Rand) T {
+	var zero T
+	rv, ok := quick.Value(reflect.TypeFor[T](), r)
+	if !ok {
+		return zero
+	}
+
+	return rv.Interface().(T)
+}
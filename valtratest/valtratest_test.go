@@ -0,0 +1,59 @@
+package valtratest_test
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/valtratest"
+)
+
+// recorder records failures instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func (r *recorder) Logf(format string, args ...any) {}
+
+func TestEquivalent(t *testing.T) {
+	t.Run("equivalent schemas pass", func(t *testing.T) {
+		a := valtra.NewSchema[int]().Validate(valtra.Between(1, 10))
+		b := valtra.NewSchema[int]().Validate(valtra.Min(1), valtra.Max(10))
+
+		valtratest.Equivalent(t, a, b, &valtratest.Config[int]{
+			Generate: func(r *rand.Rand) int { return r.Intn(30) - 10 },
+			Seeds:    []int{0, 1, 10, 11},
+		})
+	})
+
+	t.Run("disagreement is reported", func(t *testing.T) {
+		a := valtra.NewSchema[string]().Validate(valtra.MaxLengthString(5))
+		b := valtra.NewSchema[string]().Validate(valtra.MaxRunes(5))
+
+		r := &recorder{TB: t}
+		valtratest.Equivalent(r, a, b, &valtratest.Config[string]{
+			Iterations: 1,
+			Seeds:      []string{"Дончо"},
+		})
+
+		if len(r.errors) != 1 || !strings.Contains(r.errors[0], `"Дончо"`) {
+			t.Errorf("Expected counterexample to be reported, got %v", r.errors)
+		}
+	})
+
+	t.Run("default generator", func(t *testing.T) {
+		a := valtra.NewSchema[int]().Validate(valtra.NonNegative[int]())
+		b := valtra.NewSchema[int]().Validate(valtra.Min(0))
+
+		valtratest.Equivalent(t, a, b, nil)
+	})
+}
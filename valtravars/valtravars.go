@@ -0,0 +1,236 @@
+// Package valtravars validates variable files, such as
+// .env files or HCL-style .tfvars files with flat string,
+// number and boolean values, against valtra rules declared
+// per variable.
+//
+// Errors carry the file name and line of the offending
+// variable, so deployment tooling can point users at the
+// exact place to fix.
+package valtravars
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// Var is a variable read from a variable file.
+type Var struct {
+	Key   string
+	Value string
+	// Line is the 1-based line the variable is defined on.
+	Line int
+}
+
+// Error describes a problem with a variable file, at the
+// given position.
+type Error struct {
+	File string
+	// Line is the 1-based line of the problem, or 0 if it
+	// concerns the whole file, such as a missing variable.
+	Line int
+	// Key is the variable concerned, if any.
+	Key string
+	// Err is the underlying error, such as a
+	// *valtra.ValidationError.
+	Err error
+}
+
+// Error returns the error message prefixed with its
+// position, e.g. "prod.env:3: port must be a whole number".
+func (e *Error) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("%s: %v", e.File, e.Err)
+	}
+
+	return fmt.Sprintf("%s:%d: %v", e.File, e.Line, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Parse reads the variables of a variable file.
+//
+// Each non-blank line holds a KEY=value or key = value
+// assignment, optionally preceded by "export". Values can be
+// bare, double-quoted (with Go escape sequences) or
+// single-quoted (verbatim). Lines starting with "#" or "//"
+// are comments, as is anything following " #" after a bare
+// value.
+//
+// Malformed lines are returned as an *Error, using file as the
+// file name.
+func Parse(file string, r io.Reader) ([]Var, error) {
+	var vars []Var
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "//") {
+			continue
+		}
+
+		key, value, ok := strings.Cut(strings.TrimPrefix(text, "export "), "=")
+		key = strings.TrimSpace(key)
+		if !ok || !isKey(key) {
+			return nil, &Error{File: file, Line: line, Err: errors.New("expected an assignment, e.g. KEY=value")}
+		}
+
+		value, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, &Error{File: file, Line: line, Key: key, Err: err}
+		}
+
+		vars = append(vars, Var{Key: key, Value: value, Line: line})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("valtravars: reading %s: %w", file, err)
+	}
+
+	return vars, nil
+}
+
+// isKey reports whether the given string is a valid variable
+// name: letters, digits and underscores, not starting with a
+// digit.
+func isKey(key string) bool {
+	for i, r := range key {
+		if r != '_' && (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+
+	return key != ""
+}
+
+// parseValue parses the value of an assignment, unquoting it
+// and stripping trailing comments.
+func parseValue(value string) (string, error) {
+	switch {
+	case strings.HasPrefix(value, `"`):
+		end := 1
+		for end < len(value) && value[end] != '"' {
+			if value[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(value) || !isComment(value[end+1:]) {
+			return "", errors.New("unterminated or malformed double-quoted value")
+		}
+
+		unquoted, err := strconv.Unquote(value[:end+1])
+		if err != nil {
+			return "", errors.New("invalid escape sequence in double-quoted value")
+		}
+		return unquoted, nil
+	case strings.HasPrefix(value, "'"):
+		end := strings.IndexByte(value[1:], '\'') + 1
+		if end == 0 || !isComment(value[end+1:]) {
+			return "", errors.New("unterminated or malformed single-quoted value")
+		}
+		return value[1:end], nil
+	}
+
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// isComment reports whether the rest of a line holds nothing
+// but an optional comment.
+func isComment(rest string) bool {
+	rest = strings.TrimSpace(rest)
+	return rest == "" || strings.HasPrefix(rest, "#") || strings.HasPrefix(rest, "//")
+}
+
+// Schema declares the variables of a variable file, along
+// with the rules their values must satisfy.
+type Schema struct {
+	// Vars maps the names of the declared variables to their
+	// rules, which apply to the variables defined in a file.
+	Vars map[string][]func(valtra.Value[string]) error
+	// Required lists the variables that a file must define.
+	Required []string
+	// AllowUnknown accepts variables that are not declared,
+	// which are reported as errors otherwise.
+	AllowUnknown bool
+}
+
+// Validate parses the variable file read from r with Parse,
+// and validates its variables, using file as the file name in
+// errors.
+//
+// It returns the variables along with every problem found,
+// each an *Error, joined into a single error: failed rules,
+// missing required variables, unknown variables and variables
+// defined more than once.
+//
+// Example:
+//
+//	schema := valtravars.Schema{
+//	    Vars: map[string][]func(valtra.Value[string]) error{
+//	        "PORT":         {valtra.NumericString()},
+//	        "DATABASE_URL": {valtra.URI()},
+//	    },
+//	    Required: []string{"DATABASE_URL"},
+//	}
+//	if _, err := schema.Validate("prod.env", f); err != nil {
+//	    log.Fatal(err) // prod.env:3: PORT must contain only digits
+//	}
+func (s Schema) Validate(file string, r io.Reader) ([]Var, error) {
+	vars, err := Parse(file, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var errs []error
+	defined := map[string]int{}
+	for _, v := range vars {
+		if first, ok := defined[v.Key]; ok {
+			errs = append(errs, &Error{File: file, Line: v.Line, Key: v.Key, Err: fmt.Errorf("%s is already defined on line %d", v.Key, first)})
+			continue
+		}
+		defined[v.Key] = v.Line
+
+		rules, declared := s.Vars[v.Key]
+		if !declared && !s.AllowUnknown {
+			errs = append(errs, &Error{File: file, Line: v.Line, Key: v.Key, Err: fmt.Errorf("%s is not a known variable", v.Key)})
+			continue
+		}
+
+		for _, err := range valtra.Val(v.Value, v.Key).Validate(rules...).Errors() {
+			errs = append(errs, &Error{File: file, Line: v.Line, Key: v.Key, Err: err})
+		}
+	}
+
+	for _, key := range s.Required {
+		if _, ok := defined[key]; !ok {
+			err := valtra.Val("", key).Validate(valtra.Required[string]()).FirstError()
+			errs = append(errs, &Error{File: file, Key: key, Err: err})
+		}
+	}
+
+	return vars, errors.Join(errs...)
+}
+
+// ValidateFile opens the named file and validates it with
+// Validate.
+func (s Schema) ValidateFile(name string) ([]Var, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("valtravars: %w", err)
+	}
+	defer f.Close()
+
+	return s.Validate(name, f)
+}
@@ -0,0 +1,122 @@
+package valtravars_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/valtravars"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("assignments are parsed", func(t *testing.T) {
+		vars, err := valtravars.Parse("vars", strings.NewReader(`
+# database
+export DATABASE_URL=postgres://db/app # primary
+region = "eu-west-1"
+// quoted
+greeting = "hello\tworld" # comment
+pattern='a#b\n'
+empty=
+`))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		want := []valtravars.Var{
+			{Key: "DATABASE_URL", Value: "postgres://db/app", Line: 3},
+			{Key: "region", Value: "eu-west-1", Line: 4},
+			{Key: "greeting", Value: "hello\tworld", Line: 6},
+			{Key: "pattern", Value: `a#b\n`, Line: 7},
+			{Key: "empty", Value: "", Line: 8},
+		}
+		if len(vars) != len(want) {
+			t.Fatalf("Expected %d variables, got %v", len(want), vars)
+		}
+		for i := range want {
+			if vars[i] != want[i] {
+				t.Errorf("Expected %+v, got %+v", want[i], vars[i])
+			}
+		}
+	})
+
+	t.Run("malformed lines report their position", func(t *testing.T) {
+		for input, line := range map[string]string{
+			"A=1\nnot an assignment": "vars:2:",
+			"1KEY=value":             "vars:1:",
+			"A=1\nB=\"unterminated":  "vars:2:",
+			"A='x' trailing":         "vars:1:",
+			`A="bad \q escape"`:      "vars:1:",
+		} {
+			_, err := valtravars.Parse("vars", strings.NewReader(input))
+
+			var ve *valtravars.Error
+			if !errors.As(err, &ve) || !strings.HasPrefix(err.Error(), line) {
+				t.Errorf("Expected error at %s for %q, got %v", line, input, err)
+			}
+		}
+	})
+}
+
+var schema = valtravars.Schema{
+	Vars: map[string][]func(valtra.Value[string]) error{
+		"PORT":         {valtra.NumericString()},
+		"DATABASE_URL": {valtra.URI()},
+		"LOG_LEVEL":    {valtra.OneOfValues("debug", "info", "warn")},
+	},
+	Required: []string{"PORT", "DATABASE_URL"},
+}
+
+func TestSchemaValidate(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		vars, err := schema.Validate("prod.env", strings.NewReader("PORT=8080\nDATABASE_URL=postgres://db/app\nLOG_LEVEL=info\n"))
+		if err != nil || len(vars) != 3 {
+			t.Errorf("Unexpected result %v (error: %v)", vars, err)
+		}
+	})
+
+	t.Run("errors carry file and line", func(t *testing.T) {
+		_, err := schema.Validate("prod.env", strings.NewReader("LOG_LEVEL=info\nPORT=http\nPORT=80\nDEBUG=1\n"))
+		if err == nil {
+			t.Fatal("Expected validation to fail")
+		}
+
+		want := "prod.env:2: PORT must contain only digits\n" +
+			"prod.env:3: PORT is already defined on line 2\n" +
+			"prod.env:4: DEBUG is not a known variable\n" +
+			"prod.env: DATABASE_URL is required"
+		if err.Error() != want {
+			t.Errorf("Unexpected errors:\n%s", err)
+		}
+		if !errors.Is(err, &valtra.ValidationError{Code: "numeric", Field: "PORT"}) {
+			t.Error("Expected validation errors to be wrapped")
+		}
+	})
+
+	t.Run("unknown variables can be allowed", func(t *testing.T) {
+		s := schema
+		s.AllowUnknown = true
+		if _, err := s.Validate("prod.env", strings.NewReader("PORT=80\nDATABASE_URL=postgres://db\nDEBUG=1\n")); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	})
+}
+
+func TestSchemaValidateFile(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "staging.env")
+	if err := os.WriteFile(name, []byte("PORT=80\nDATABASE_URL=nope\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := schema.ValidateFile(name)
+	if err == nil || err.Error() != name+":2: DATABASE_URL must be a valid URI" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if _, err := schema.ValidateFile(filepath.Join(t.TempDir(), "missing.env")); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
@@ -0,0 +1,15 @@
+// Command valtravet reports misused valtra rules, using the
+// valtravet analyzer. It can be run directly on packages, or as
+// a go vet tool:
+//
+//	go vet -vettool=$(which valtravet) ./...
+package main
+
+import (
+	"github.com/bobch27/valtra-go/valtravet"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(valtravet.Analyzer)
+}
@@ -0,0 +1,35 @@
+package a
+
+import "github.com/bobch27/valtra-go"
+
+const limit = 10
+
+func bounds(age, port int, name string, tags []string) {
+	valtra.Val(age).Validate(valtra.Min(18), valtra.Max(65))
+	valtra.Val(age).Validate(valtra.Min(65), valtra.Max(18))                                  // want `Min\(65\) is above Max\(18\): no value can pass`
+	valtra.Val(name).Validate(valtra.MinLengthString(limit+1), valtra.MaxLengthString(limit)) // want `MinLengthString\(11\) is above MaxLengthString\(10\)`
+	valtra.Val(name).Validate(valtra.MinString("b"), valtra.MaxString("a"))                   // want `MinString\("b"\) is above MaxString\("a"\)`
+	valtra.Val(tags).Validate(valtra.MinLengthSlice[string](3), valtra.MaxLengthSlice[string](5))
+	valtra.Val(port).Validate(valtra.Min(port), valtra.Max(1))
+	valtra.Val(port).Validate(valtra.Between(1, 65535))
+	valtra.Val(port).Validate(valtra.Between(65535, 1)) // want `Between with a minimum \(65535\) above its maximum \(1\)`
+}
+
+func lengths(name string) {
+	valtra.Val(name).Validate(valtra.MaxLengthString(-1)) // want `MaxLengthString with a negative length \(-1\)`
+	valtra.Val(name).Validate(valtra.Hex(-2))             // want `Hex with a negative length \(-2\)`
+	valtra.Val(name).Validate(valtra.MinRunes(0))
+	valtra.Val(5).Validate(valtra.MinLength[int](1)) // want `MinLength\[int\]: int values have no length`
+	valtra.Val(name).Validate(valtra.MaxLength[string](10))
+	valtra.Val([]int{1}).Validate(valtra.MaxLength[[]int](10))
+}
+
+func required(nickname *string, email string) {
+	valtra.OptionalVal(nickname).Validate(valtra.Required[string]()) // want `Required on a value from OptionalVal does not fail when it is missing`
+	valtra.OptionalVal(nickname).Validate(valtra.MinLengthString(3))
+	valtra.Val(email).Strict().Validate(valtra.Required[string](), valtra.Email())
+	valtra.Val(email).Strict().Validate(valtra.Email(), valtra.Required[string]()) // want `Required after other rules on a strict value is skipped once they fail`
+	valtra.Val(email).Strict().Transform(valtra.TrimSpace()).Validate(valtra.Required[string]())
+	valtra.Val(email).Strict().Validate(valtra.Email()).Validate(valtra.Required[string]()) // want `Required after other rules on a strict value`
+	valtra.Val(email).Validate(valtra.Email(), valtra.Required[string]())
+}
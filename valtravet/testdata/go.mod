@@ -0,0 +1,7 @@
+module example.com/testdata
+
+go 1.25.1
+
+require github.com/bobch27/valtra-go v0.0.0
+
+replace github.com/bobch27/valtra-go => ../..
@@ -0,0 +1,281 @@
+// Package valtravet provides an analyzer that reports obvious
+// misuse of valtra rules, such as a minimum above its maximum,
+// so configuration bugs are caught by go vet rather than by
+// users whose input can never pass.
+//
+// It reports:
+//
+//   - Min, MinLength, MinRunes and the other minimums paired
+//     with a lower maximum in the same rule list, and Between
+//     with its bounds the wrong way round
+//   - negative lengths, such as MaxLengthString(-1)
+//   - MinLength and MaxLength given a type without a length,
+//     such as MinLength[int], which fails every value
+//   - Required on values created by OptionalVal or OptionalEnv,
+//     which does not fail for missing values, as they skip
+//     all rules
+//   - Required after other rules on a strict value, where it
+//     is skipped once they fail, so never reports an empty
+//     value
+//
+// Only constant arguments are checked. The analyzer can be run
+// with the valtravet command, e.g. through go vet:
+//
+//	go install github.com/bobch27/valtra-go/valtravet/cmd/valtravet@latest
+//	go vet -vettool=$(which valtravet) ./...
+package valtravet
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+	"golang.org/x/tools/go/types/typeutil"
+)
+
+// valtraPath is the import path of the valtra package.
+const valtraPath = "github.com/bobch27/valtra-go"
+
+// Analyzer reports obvious misuse of valtra rules.
+var Analyzer = &analysis.Analyzer{
+	Name:     "valtravet",
+	Doc:      "report misused valtra rules, such as a Min above its Max",
+	URL:      "https://pkg.go.dev/github.com/bobch27/valtra-go/valtravet",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// bounds maps each minimum rule to the maximum rule it pairs
+// with.
+var bounds = map[string]string{
+	"Min":             "Max",
+	"MinString":       "MaxString",
+	"MinLength":       "MaxLength",
+	"MinLengthString": "MaxLengthString",
+	"MinLengthSlice":  "MaxLengthSlice",
+	"MinLengthMap":    "MaxLengthMap",
+	"MinRunes":        "MaxRunes",
+	"MinGraphemes":    "MaxGraphemes",
+}
+
+// lengths lists the rules whose first argument is a length or
+// count, which cannot be negative.
+var lengths = map[string]bool{
+	"MaxLength":        true,
+	"MinLength":        true,
+	"MaxLengthString":  true,
+	"MinLengthString":  true,
+	"MaxLengthSlice":   true,
+	"MinLengthSlice":   true,
+	"MaxLengthMap":     true,
+	"MinLengthMap":     true,
+	"MaxRunes":         true,
+	"MinRunes":         true,
+	"MaxGraphemes":     true,
+	"MinGraphemes":     true,
+	"MaxDecimalPlaces": true,
+	"Hex":              true,
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	insp.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		switch name := valtraFunc(pass, call); {
+		case lengths[name]:
+			if c := constArg(pass, call, 0); c != nil && constant.Sign(c) < 0 {
+				pass.Reportf(call.Pos(), "%s with a negative length (%s)", name, c)
+			}
+			if name == "MinLength" || name == "MaxLength" {
+				checkLength(pass, call, name)
+			}
+		case name == "Between":
+			lo, hi := constArg(pass, call, 0), constArg(pass, call, 1)
+			if greater(lo, hi) {
+				pass.Reportf(call.Pos(), "Between with a minimum (%s) above its maximum (%s): no value can pass", lo, hi)
+			}
+		}
+
+		checkBounds(pass, call.Args)
+		if method := valueMethod(pass, call); method == "Validate" || method == "ValidateCtx" {
+			checkRequired(pass, call)
+		}
+	})
+
+	return nil, nil
+}
+
+// checkBounds reports minimums above their maximum among the
+// rules of a rule list, such as the arguments of Validate.
+func checkBounds(pass *analysis.Pass, rules []ast.Expr) {
+	maxima := map[string]constant.Value{}
+	for _, rule := range rules {
+		if call, ok := ast.Unparen(rule).(*ast.CallExpr); ok {
+			if c := constArg(pass, call, 0); c != nil {
+				maxima[valtraFunc(pass, call)] = c
+			}
+		}
+	}
+
+	for _, rule := range rules {
+		call, ok := ast.Unparen(rule).(*ast.CallExpr)
+		if !ok {
+			continue
+		}
+
+		name := valtraFunc(pass, call)
+		maxName, ok := bounds[name]
+		if !ok {
+			continue
+		}
+		lo, hi := constArg(pass, call, 0), maxima[maxName]
+		if greater(lo, hi) {
+			pass.Reportf(call.Pos(), "%s(%s) is above %s(%s): no value can pass", name, lo, maxName, hi)
+		}
+	}
+}
+
+// checkLength reports MinLength and MaxLength calls whose type
+// argument has no length.
+func checkLength(pass *analysis.Pass, call *ast.CallExpr, name string) {
+	fun := ast.Unparen(call.Fun)
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun = index.X
+	}
+	var id *ast.Ident
+	switch f := fun.(type) {
+	case *ast.Ident:
+		id = f
+	case *ast.SelectorExpr:
+		id = f.Sel
+	}
+
+	inst, ok := pass.TypesInfo.Instances[id]
+	if !ok || inst.TypeArgs.Len() != 1 {
+		return
+	}
+	t := inst.TypeArgs.At(0)
+	if types.IsInterface(t) {
+		return
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Slice, *types.Array, *types.Map, *types.Chan:
+		return
+	case *types.Basic:
+		if u.Info()&types.IsString != 0 {
+			return
+		}
+	}
+
+	typ := types.TypeString(t, types.RelativeTo(pass.Pkg))
+	pass.Reportf(call.Pos(), "%s[%s]: %s values have no length, so every value fails", name, typ, typ)
+}
+
+// checkRequired reports Required rules given to a Validate
+// call that cannot report an empty value, because the value
+// is optional, or strict with other rules running first.
+func checkRequired(pass *analysis.Pass, call *ast.CallExpr) {
+	// Walk the method chain back to the call creating the
+	// value. Any failed rule before this call stops a strict
+	// value, while transformations such as TrimSpace rarely fail.
+	var strict, earlier bool
+	var root string
+	recv := call.Fun.(*ast.SelectorExpr).X
+	for {
+		inner, ok := ast.Unparen(recv).(*ast.CallExpr)
+		if !ok {
+			break
+		}
+
+		method := valueMethod(pass, inner)
+		switch method {
+		case "":
+			root = valtraFunc(pass, inner)
+		case "Strict":
+			strict = true
+		case "Validate", "ValidateCtx":
+			earlier = true
+		}
+		sel, ok := ast.Unparen(inner.Fun).(*ast.SelectorExpr)
+		if method == "" || !ok {
+			break
+		}
+		recv = sel.X
+	}
+
+	for i, rule := range call.Args {
+		ruleCall, ok := ast.Unparen(rule).(*ast.CallExpr)
+		if !ok || valtraFunc(pass, ruleCall) != "Required" {
+			continue
+		}
+
+		switch {
+		case root == "OptionalVal" || root == "OptionalEnv":
+			pass.Reportf(ruleCall.Pos(), "Required on a value from %s does not fail when it is missing, as missing values skip all rules; use Val to require it", root)
+		case strict && (i > 0 || earlier):
+			pass.Reportf(ruleCall.Pos(), "Required after other rules on a strict value is skipped once they fail; put it first")
+		}
+	}
+}
+
+// valtraFunc returns the name of the valtra function called,
+// or "" if the call is not to a valtra package-level function.
+func valtraFunc(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != valtraPath || fn.Signature().Recv() != nil {
+		return ""
+	}
+
+	return fn.Name()
+}
+
+// valueMethod returns the name of the valtra.Value method
+// called, or "" if the call is not to a method of Value.
+func valueMethod(pass *analysis.Pass, call *ast.CallExpr) string {
+	fn, ok := typeutil.Callee(pass.TypesInfo, call).(*types.Func)
+	if !ok || fn.Pkg() == nil || fn.Pkg().Path() != valtraPath {
+		return ""
+	}
+
+	recv := fn.Signature().Recv()
+	if recv == nil {
+		return ""
+	}
+	named, ok := types.Unalias(recv.Type()).(*types.Named)
+	if !ok || named.Obj().Name() != "Value" {
+		return ""
+	}
+
+	return fn.Name()
+}
+
+// constArg returns the constant value of the call's argument
+// at index i, or nil if it is missing or not constant.
+func constArg(pass *analysis.Pass, call *ast.CallExpr, i int) constant.Value {
+	if i >= len(call.Args) {
+		return nil
+	}
+
+	c := pass.TypesInfo.Types[call.Args[i]].Value
+	if c == nil || c.Kind() == constant.Unknown || c.Kind() == constant.Bool {
+		return nil
+	}
+
+	return c
+}
+
+// greater reports whether a and b are both known, of
+// comparable kinds, and a is greater than b.
+func greater(a, b constant.Value) bool {
+	if a == nil || b == nil || (a.Kind() == constant.String) != (b.Kind() == constant.String) {
+		return false
+	}
+
+	return constant.Compare(a, token.GTR, b)
+}
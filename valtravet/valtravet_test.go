@@ -0,0 +1,12 @@
+package valtravet_test
+
+import (
+	"testing"
+
+	"github.com/bobch27/valtra-go/valtravet"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, "testdata", valtravet.Analyzer, "./a")
+}
@@ -0,0 +1,172 @@
+// Package valtraws validates inbound JSON messages of
+// realtime connections, such as WebSockets, against
+// per-message-type valtra schemas.
+//
+// Messages are JSON objects whose "type" property selects the
+// schema, e.g. {"type": "chat", "text": "hi"}. The package
+// does not depend on any WebSocket library: Router works on
+// raw frames, and Conn wraps any connection with a
+// ReadMessage method, such as a *websocket.Conn from
+// gorilla/websocket.
+package valtraws
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/bobch27/valtra-go"
+)
+
+// ErrInvalidFrame is returned (wrapped) when a frame is not a
+// JSON object with a string "type" property, or cannot be
+// decoded into its type's Go type.
+var ErrInvalidFrame = errors.New("valtraws: invalid frame")
+
+// ErrUnknownType is returned (wrapped) when a frame's type has
+// no registered schema.
+var ErrUnknownType = errors.New("valtraws: unknown message type")
+
+// Message is a decoded inbound message.
+type Message struct {
+	// Type is the message's type.
+	Type string
+	// Value is the decoded (and possibly transformed) message,
+	// of the Go type registered for Type.
+	Value any
+}
+
+// Router decodes and validates frames according to the schemas
+// registered for their types. It is safe for concurrent use.
+type Router struct {
+	mu       sync.RWMutex
+	decoders map[string]func(frame []byte) (any, error)
+}
+
+// NewRouter creates and returns a Router without any message
+// types.
+func NewRouter() *Router {
+	return &Router{decoders: map[string]func([]byte) (any, error){}}
+}
+
+// Handle registers the schema for messages of the given type,
+// which are decoded into a T. Registering a type again
+// replaces its schema.
+//
+// Example:
+//
+//	r := valtraws.NewRouter()
+//	valtraws.Handle(r, "chat", chatSchema)
+//	valtraws.Handle(r, "typing", typingSchema)
+func Handle[T any](r *Router, msgType string, schema valtra.Schema[T]) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.decoders[msgType] = func(frame []byte) (any, error) {
+		var value T
+		if err := json.Unmarshal(frame, &value); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidFrame, err)
+		}
+
+		return schema.Run(value, msgType)
+	}
+}
+
+// Decode decodes the frame into the Go type registered for its
+// type, and validates it.
+//
+// If validation fails, the message is returned along with the
+// schema's errors joined into a single error, so the sender
+// can be told what was wrong. Frames that cannot be decoded
+// return an error wrapping ErrInvalidFrame, and frames of
+// unregistered types one wrapping ErrUnknownType.
+func (r *Router) Decode(frame []byte) (Message, error) {
+	var envelope struct {
+		Type *string `json:"type"`
+	}
+	if err := json.Unmarshal(frame, &envelope); err != nil {
+		return Message{}, fmt.Errorf("%w: %w", ErrInvalidFrame, err)
+	}
+	if envelope.Type == nil {
+		return Message{}, fmt.Errorf("%w: missing type", ErrInvalidFrame)
+	}
+
+	msg := Message{Type: *envelope.Type}
+
+	r.mu.RLock()
+	decode, ok := r.decoders[msg.Type]
+	r.mu.RUnlock()
+	if !ok {
+		return msg, fmt.Errorf("%w %q", ErrUnknownType, msg.Type)
+	}
+
+	value, err := decode(frame)
+	if errors.Is(err, ErrInvalidFrame) {
+		return msg, err
+	}
+
+	msg.Value = value
+	return msg, err
+}
+
+// As returns the message's value as a T, and whether it holds
+// one.
+//
+// Example:
+//
+//	if chat, ok := valtraws.As[ChatMessage](msg); ok {
+//	    broadcast(chat)
+//	}
+func As[T any](msg Message) (T, bool) {
+	value, ok := msg.Value.(T)
+	return value, ok
+}
+
+// FrameReader reads frames from a connection. It is
+// implemented by *websocket.Conn from gorilla/websocket, for
+// example.
+type FrameReader interface {
+	ReadMessage() (messageType int, p []byte, err error)
+}
+
+// Conn wraps a connection, decoding and validating every
+// frame read from it.
+type Conn struct {
+	conn   FrameReader
+	router *Router
+}
+
+// NewConn returns a Conn reading frames from conn and
+// validating them with the router.
+func NewConn(conn FrameReader, router *Router) *Conn {
+	return &Conn{conn: conn, router: router}
+}
+
+// Read reads the next frame and decodes it as Router.Decode
+// does. Errors reading from the connection are returned as is,
+// so the caller can tell them apart from invalid messages and
+// close the connection.
+//
+// Example:
+//
+//	for {
+//	    msg, err := conn.Read()
+//	    var ve *valtra.ValidationError
+//	    switch {
+//	    case errors.Is(err, valtraws.ErrInvalidFrame), errors.Is(err, valtraws.ErrUnknownType), errors.As(err, &ve):
+//	        reply(ws, err)
+//	        continue
+//	    case err != nil:
+//	        return err // connection closed
+//	    }
+//	    dispatch(msg)
+//	}
+func (c *Conn) Read() (Message, error) {
+	_, frame, err := c.conn.ReadMessage()
+	if err != nil {
+		return Message{}, err
+	}
+
+	return c.router.Decode(frame)
+}
@@ -0,0 +1,97 @@
+package valtraws_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/bobch27/valtra-go"
+	"github.com/bobch27/valtra-go/valtraws"
+)
+
+type chat struct {
+	Text string `json:"text"`
+}
+
+type typing struct {
+	Active bool `json:"active"`
+}
+
+func router() *valtraws.Router {
+	r := valtraws.NewRouter()
+	valtraws.Handle(r, "chat", valtra.NewSchema[chat]().
+		Transform(func(v valtra.Value[chat]) (chat, error) {
+			return chat{Text: strings.TrimSpace(v.Value().Text)}, nil
+		}).
+		Validate(valtra.Field("text", func(c chat) string { return c.Text }, valtra.Required[string](), valtra.MaxLengthString(10))))
+	valtraws.Handle(r, "typing", valtra.NewSchema[typing]())
+	return r
+}
+
+func TestRouterDecode(t *testing.T) {
+	r := router()
+
+	t.Run("valid messages decode to their type", func(t *testing.T) {
+		msg, err := r.Decode([]byte(`{"type":"chat","text":"  hi  "}`))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if c, ok := valtraws.As[chat](msg); !ok || c.Text != "hi" {
+			t.Errorf("Unexpected message: %+v", msg)
+		}
+
+		msg, err = r.Decode([]byte(`{"type":"typing","active":true}`))
+		if tp, ok := valtraws.As[typing](msg); err != nil || !ok || !tp.Active {
+			t.Errorf("Unexpected message: %+v (error: %v)", msg, err)
+		}
+	})
+
+	t.Run("invalid messages return their errors", func(t *testing.T) {
+		msg, err := r.Decode([]byte(`{"type":"chat","text":"far too long for chat"}`))
+		if !errors.Is(err, &valtra.ValidationError{Code: "max_length", Field: "text"}) {
+			t.Errorf("Expected max_length error, got %v", err)
+		}
+		if _, ok := valtraws.As[chat](msg); !ok {
+			t.Error("Expected the message to be returned with its errors")
+		}
+	})
+
+	t.Run("malformed frames", func(t *testing.T) {
+		for _, frame := range []string{`not json`, `{"text":"hi"}`, `{"type":1}`, `{"type":"typing","active":"yes"}`} {
+			if _, err := r.Decode([]byte(frame)); !errors.Is(err, valtraws.ErrInvalidFrame) {
+				t.Errorf("Expected ErrInvalidFrame for %s, got %v", frame, err)
+			}
+		}
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		msg, err := r.Decode([]byte(`{"type":"ping"}`))
+		if !errors.Is(err, valtraws.ErrUnknownType) || msg.Type != "ping" {
+			t.Errorf("Expected ErrUnknownType, got %v", err)
+		}
+	})
+}
+
+type frames [][]byte
+
+func (f *frames) ReadMessage() (int, []byte, error) {
+	if len(*f) == 0 {
+		return 0, nil, io.EOF
+	}
+
+	frame := (*f)[0]
+	*f = (*f)[1:]
+	return 1, frame, nil
+}
+
+func TestConn(t *testing.T) {
+	conn := valtraws.NewConn(&frames{[]byte(`{"type":"chat","text":"hi"}`)}, router())
+
+	if msg, err := conn.Read(); err != nil || msg.Type != "chat" {
+		t.Errorf("Unexpected message %+v (error: %v)", msg, err)
+	}
+	if _, err := conn.Read(); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected connection error, got %v", err)
+	}
+}
@@ -1,12 +1,29 @@
 package valtra
 
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
 // Value holds a value to be validated/transformed, along
 // with its name and any errors that occur during
 // validation/transformation.
 type Value[T any] struct {
-	value T
-	name  string
-	errs  []error
+	value    T
+	name     string
+	errs     []error
+	strict   bool
+	absent   bool
+	pos      Position
+	warnings []error
+	class    Classification
+	masked   bool
+	scratch  *scratch
+	budget   *budget
+	meta     *meta
+	lineage  *provenance
 }
 
 // Val creates a new Value[T] that wraps a value.
@@ -39,6 +56,102 @@ func Val[T any](value T, name ...string) Value[T] {
 	}
 }
 
+// OptionalVal creates a new Value[T] for an optional input,
+// such as a pointer field of a PATCH request body.
+//
+// If ptr is nil, the value is absent: all validations and
+// transformations are skipped, and the value holds T's zero
+// value. Otherwise the value pointed to is wrapped, as with
+// Val. Use CollectOptional to get the result back as a
+// pointer.
+//
+// Example:
+//
+//	c := valtra.NewCollector()
+//	patch := UserPatch{
+//	    Nickname: valtra.OptionalVal(input.Nickname, "nickname").
+//	        Validate(valtra.MinLengthString(3)).
+//	        CollectOptional(c),
+//	}
+func OptionalVal[T any](ptr *T, name ...string) Value[T] {
+	if ptr == nil {
+		v := Val(*new(T), name...)
+		v.absent = true
+		return v
+	}
+
+	return Val(*ptr, name...)
+}
+
+// Check validates a value with the provided validation
+// functions, returning the value along with all errors
+// joined into a single error (or nil if validation passed).
+//
+// It suits call sites that only need the idiomatic
+// "v, err :=" result and not the Value itself. To apply
+// transformations as well, use Val.
+//
+// Example:
+//
+//	email, err := valtra.Check(input.Email, valtra.Required[string](), valtra.Email())
+//	if err != nil {
+//	    return err
+//	}
+func Check[T any](value T, validations ...func(Value[T]) error) (T, error) {
+	v := Val(value).Validate(validations...)
+	return v.value, errors.Join(v.errs...)
+}
+
+// Strict returns a copy of the value in strict mode, where
+// the first failed validation or transformation stops all
+// subsequent ones.
+//
+// It avoids redundant errors, such as an empty field failing
+// both Required and Email. Once the value has an error, any
+// further Validate, ValidateCtx and Transform calls are
+// no-ops.
+//
+// Example:
+//
+//	v := valtra.Val(input.Email, "email").Strict().Validate(
+//	    valtra.Required[string](),
+//	    valtra.Email(), // skipped if the email is empty
+//	)
+func (v Value[T]) Strict() Value[T] {
+	v.strict = true
+	return v
+}
+
+// At returns a copy of the value located at the given
+// position of its source file, which is attached to the
+// errors of the value's validations and transformations.
+//
+// It lets tools validating files, such as configuration
+// files or CSV imports, point users at the offending input.
+//
+// Example:
+//
+//	valtra.Val(record[2], "email").At(valtra.Position{File: "users.csv", Line: line, Column: 3}).Validate(valtra.Email())
+//	// users.csv:7:3: email must be in correct email format
+func (v Value[T]) At(pos Position) Value[T] {
+	v.pos = pos
+	return v
+}
+
+// Present reports whether the value is present, which is
+// false only for values created by OptionalVal from a nil
+// pointer.
+func (v Value[T]) Present() bool {
+	return !v.absent
+}
+
+// stopped reports whether validations and transformations
+// are skipped, because the value is absent or it is in strict
+// mode and has already failed.
+func (v Value[T]) stopped() bool {
+	return v.absent || (v.strict && len(v.errs) > 0)
+}
+
 // Value returns the value being validated/transformed.
 func (v Value[T]) Value() T {
 	return v.value
@@ -56,6 +169,40 @@ func (v Value[T]) Errors() []error {
 	return v.errs
 }
 
+// Warnings returns the warnings recorded for the value, such
+// as the failures of rules applied with Warn and the repairs
+// made by rules with the AutoFix option. Warnings do not make
+// the value invalid.
+func (v Value[T]) Warnings() []error {
+	return v.warnings
+}
+
+// FirstError returns the first error that occurred, or nil
+// if validation/transformation passed.
+func (v Value[T]) FirstError() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs[0]
+}
+
+// Err returns an error joining all errors that have occurred,
+// or nil if validation/transformation passed.
+//
+// The result implements Unwrap() []error, so errors.As and
+// errors.Is search every error, including those of nested
+// fields checked by Field.
+//
+// Example:
+//
+//	if err := valtra.Val(input.Age).Validate(valtra.Min(18)).Err(); err != nil {
+//	    return fmt.Errorf("invalid signup: %w", err)
+//	}
+func (v Value[T]) Err() error {
+	return errors.Join(v.errs...)
+}
+
 // IsValid returns true if there are no errors,
 // false otherwise.
 //
@@ -73,11 +220,56 @@ func (v Value[T]) IsValid() bool {
 	return len(v.errs) == 0
 }
 
+// Get returns the value along with an error joining all
+// errors that have occurred, or nil if validation/
+// transformation passed.
+//
+// Example:
+//
+//	age, err := valtra.Map(valtra.Val(r.FormValue("age"), "age"), strconv.Atoi).Validate(valtra.Min(18)).Get()
+func (v Value[T]) Get() (T, error) {
+	return v.value, v.Err()
+}
+
+// MustValue returns the value, panicking with the joined
+// errors if validation/transformation failed.
+//
+// It is meant for fail-fast call sites, such as tests and
+// loading configuration at startup, where an invalid value is
+// a bug rather than bad input.
+//
+// Example:
+//
+//	port := valtra.Map(valtra.Val(os.Getenv("PORT"), "PORT"), strconv.Atoi).Validate(valtra.Min(1)).MustValue()
+func (v Value[T]) MustValue() T {
+	if err := v.Err(); err != nil {
+		panic(fmt.Errorf("valtra: invalid value: %w", err))
+	}
+
+	return v.value
+}
+
+// Or returns the value if validation/transformation passed,
+// or else the fallback.
+//
+// Example:
+//
+//	pageSize := valtra.Map(valtra.Val(r.FormValue("size")), strconv.Atoi).Validate(valtra.Between(1, 100)).Or(20)
+func (v Value[T]) Or(fallback T) T {
+	if !v.IsValid() {
+		return fallback
+	}
+
+	return v.value
+}
+
 // Validate applies all provided validation functions for
 // the given value.
 //
 // Each validation function that returns an
 // error will add that error to the value's error list.
+// In strict mode (see Strict), validation stops at the first
+// error.
 //
 // Example:
 //
@@ -88,10 +280,132 @@ func (v Value[T]) IsValid() bool {
 //	)
 func (v Value[T]) Validate(validations ...func(Value[T]) error) Value[T] {
 	for _, fn := range validations {
+		if v.stopped() || !v.spend() {
+			break
+		}
+
 		err := fn(v)
+		if fix, ok := err.(*fixError[T]); ok {
+			v.value = fix.value
+			v.warnings = append(v.warnings, fix.warning)
+		} else {
+			v = v.record(err)
+		}
+	}
+
+	return v
+}
+
+// Warn applies all provided validation functions for the
+// given value, like Validate, but records their failures as
+// warnings (see Warnings) rather than errors, so the value
+// stays valid.
+//
+// It suits flagging inputs that are accepted but deserve
+// attention, such as deprecated or suspicious values. The
+// failures' Severity is set to SeverityWarning, and rules with
+// the AutoFix option do not repair the value.
+//
+// Example:
+//
+//	v := valtra.Val(input.Bio, "bio").
+//	    Validate(valtra.MaxLengthString(1000)).
+//	    Warn(valtra.MaxLengthString(100, valtra.WithMessage("{name} is long and may be truncated")))
+func (v Value[T]) Warn(validations ...func(Value[T]) error) Value[T] {
+	for _, fn := range validations {
+		if v.stopped() || !v.spend() {
+			break
+		}
+
+		err, warnings := SplitWarnings(unfixed(v, fn))
+		v.warnings = append(v.warnings, warnings...)
 		if err != nil {
+			v.warnings = append(v.warnings, asWarning(err))
+		}
+	}
+
+	return v
+}
+
+// asWarning returns the error with its severity set to
+// SeverityWarning, if it is a *ValidationError. The error is
+// copied, so that the rule's result is left unchanged.
+func asWarning(err error) error {
+	ve, ok := err.(*ValidationError)
+	if !ok || ve.Severity == SeverityWarning {
+		return err
+	}
+
+	warning := *ve
+	warning.Severity = SeverityWarning
+	return &warning
+}
+
+// Also calls the given observers with the value as it is at
+// this point of the chain, and returns the value unchanged.
+//
+// It suits side effects such as logging, tagging metrics or
+// capturing a normalised value, without breaking the chain.
+// Observers are called even if the value has failed or is
+// absent, so they can report on either.
+//
+// Example:
+//
+//	email := valtra.Val(input.Email, "email").
+//	    Transform(valtra.TrimSpace(), valtra.Lowercase()).
+//	    Also(func(v valtra.Value[string]) { slog.Debug("normalised email", "email", v.Value()) }).
+//	    Validate(valtra.Email()).
+//	    Collect(c)
+func (v Value[T]) Also(observers ...func(Value[T])) Value[T] {
+	for _, observe := range observers {
+		observe(v)
+	}
+
+	return v
+}
+
+// ValidateCtx applies all provided context-aware validation
+// functions for the given value.
+//
+// It is useful for validations that depend on I/O, such as
+// database lookups or calls to external services, as each
+// validation receives the context and can honour its
+// cancellation and deadline.
+//
+// Each validation function that returns an
+// error will add that error to the value's error list.
+// If the context is done before a validation runs, the
+// context's error is added and the remaining validations
+// are skipped.
+//
+// Example:
+//
+//	v := valtra.Val("bobby", "username").ValidateCtx(ctx, usernameNotTaken)
+func (v Value[T]) ValidateCtx(ctx context.Context, validations ...func(context.Context, Value[T]) error) Value[T] {
+	for _, fn := range validations {
+		if v.stopped() || !v.spend() {
+			break
+		}
+
+		if err := ctx.Err(); err != nil {
 			v.errs = append(v.errs, err)
+			break
 		}
+
+		v = v.record(fn(ctx, v))
+	}
+
+	return v
+}
+
+// record adds the rule's failure, if any, to the value's
+// errors, and the warnings it carries (see warningError) to
+// its warnings.
+func (v Value[T]) record(err error) Value[T] {
+	err, warnings := SplitWarnings(err)
+	v.warnings = append(v.warnings, warnings...)
+	if err != nil {
+		v.errs = append(v.errs, err)
 	}
 
 	return v
@@ -108,17 +422,95 @@ func (v Value[T]) Validate(validations ...func(Value[T]) error) Value[T] {
 //	v := valtra.Val("hello").Transform(valtra.Uppercase())
 func (v Value[T]) Transform(transformations ...func(Value[T]) (T, error)) Value[T] {
 	for _, fn := range transformations {
+		if v.stopped() || !v.spend() {
+			break
+		}
+
 		newVal, err := fn(v)
 		if err != nil {
 			v.errs = append(v.errs, err)
 		} else {
 			v.value = newVal
+			v.lineage = v.lineage.then(fn)
 		}
 	}
 
 	return v
 }
 
+// Map converts the value to another type with fn, returning
+// a Value[U] that keeps the original value's name, errors and
+// mode.
+//
+// It is the cross-type counterpart of Transform, for parsing
+// inputs into the types they represent. If fn returns an
+// error, it is added to the error list and the new value
+// holds U's zero value. In strict mode, fn is not called if
+// the value has already failed.
+//
+// Example:
+//
+//	age := valtra.Map(valtra.Val(r.FormValue("age"), "age"), strconv.Atoi).Validate(valtra.Min(18))
+func Map[T, U any](v Value[T], fn func(T) (U, error)) Value[U] {
+	return convert(v, func(v Value[T]) (U, error) { return fn(v.value) }, fn)
+}
+
+// Convert converts the value to another type with the given
+// conversion, returning a Value[U] that keeps the original
+// value's name, errors and mode.
+//
+// It works like Map, but the conversion receives the whole
+// Value, so it can produce errors that reference the value's
+// name, as the built-in conversions such as ToQuantity do.
+//
+// Example:
+//
+//	cpu := valtra.Convert(valtra.Val(input.CPU, "cpu"), valtra.ToQuantity())
+func Convert[T, U any](v Value[T], conversion func(Value[T]) (U, error)) Value[U] {
+	return convert(v, conversion, conversion)
+}
+
+// rebind returns a Value[U] holding the given value, with
+// everything else, such as the name, errors and mode, kept
+// from v.
+func rebind[T, U any](v Value[T], value U) Value[U] {
+	return Value[U]{
+		value:    value,
+		name:     v.name,
+		errs:     slices.Clip(v.errs),
+		strict:   v.strict,
+		absent:   v.absent,
+		pos:      v.pos,
+		warnings: slices.Clip(v.warnings),
+		class:    v.class,
+		masked:   v.masked,
+		scratch:  v.scratch,
+		budget:   v.budget,
+		meta:     v.meta,
+		lineage:  v.lineage,
+	}
+}
+
+// convert converts the value as Convert does, recording the
+// given function as the step in the value's provenance.
+func convert[T, U any](v Value[T], conversion func(Value[T]) (U, error), step any) Value[U] {
+	var zero U
+	converted := rebind(v, zero)
+	if v.stopped() {
+		return converted
+	}
+
+	newVal, err := conversion(v)
+	if err != nil {
+		converted.errs = append(converted.errs, err)
+	} else {
+		converted.value = newVal
+		converted.lineage = v.lineage.then(step)
+	}
+
+	return converted
+}
+
 // Collect appends all errors from the Value
 // into the provided Collector and returns the underlying
 // validated and transformed value.
@@ -137,6 +529,87 @@ func (v Value[T]) Transform(transformations ...func(Value[T]) (T, error)) Value[
 //	    return c.Errors()
 //	}
 func (v Value[T]) Collect(c *Collector) T {
-	c.errs = append(c.errs, v.errs...)
+	c.add(v.name, v.errs...)
+	if len(v.warnings) > 0 {
+		c.warn(v.warnings...)
+	}
+	if v.class != ClassPublic {
+		c.enforce(Handling{Field: v.name, Class: v.class, Masked: v.masked})
+	}
+	if c.capturing() {
+		c.record(v.name, v.value, v.class)
+	}
+	if v.lineage != nil {
+		c.trace(v.name, v.lineage.resolve())
+	}
+
 	return v.value
 }
+
+// CollectOptional appends all errors from the Value into the
+// provided Collector, like Collect, and returns a pointer to
+// the underlying value, or nil if the value is absent (see
+// OptionalVal).
+func (v Value[T]) CollectOptional(c *Collector) *T {
+	value := v.Collect(c)
+	if v.absent {
+		return nil
+	}
+
+	return &value
+}
+
+// Validated is implemented by anything that reports the
+// outcome of validation/transformation, such as Value and
+// Collector.
+//
+// It allows values of different types to be checked together.
+type Validated interface {
+	Errors() []error
+	IsValid() bool
+}
+
+// All returns true if all provided values are valid, or
+// false otherwise.
+//
+// Example:
+//
+//	name := valtra.Val(input.Name).Validate(valtra.Required[string]())
+//	age := valtra.Val(input.Age).Validate(valtra.Min(18))
+//	if !valtra.All(name, age) {
+//	    // reject request
+//	}
+func All(values ...Validated) bool {
+	for _, v := range values {
+		if !v.IsValid() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// AnyInvalid returns true if at least one of the provided
+// values is invalid, or false otherwise.
+//
+// This is a convenience function equivalent to !All(values...).
+func AnyInvalid(values ...Validated) bool {
+	return !All(values...)
+}
+
+// Join returns an error joining the errors of all provided
+// values, or nil if all of them are valid.
+//
+// Example:
+//
+//	if err := valtra.Join(name, age); err != nil {
+//	    return err
+//	}
+func Join(values ...Validated) error {
+	var errs []error
+	for _, v := range values {
+		errs = append(errs, v.Errors()...)
+	}
+
+	return errors.Join(errs...)
+}
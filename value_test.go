@@ -1,6 +1,9 @@
 package valtra_test
 
 import (
+	"context"
+	"errors"
+	"strconv"
 	"testing"
 
 	"github.com/bobch27/valtra-go"
@@ -48,4 +51,392 @@ func TestValueMethods(t *testing.T) {
 			t.Errorf("Expected invalid value, got %t", v.IsValid())
 		}
 	})
+
+	t.Run("Err() returns nil when valid", func(t *testing.T) {
+		v := valtra.Val(10).Validate(valtra.Min(5))
+		if v.Err() != nil {
+			t.Errorf("Expected nil, got %v", v.Err())
+		}
+	})
+
+	t.Run("Err() joins all errors", func(t *testing.T) {
+		v := valtra.Val(1, "age").Validate(valtra.Min(5), valtra.Max(0))
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Err(), &ve) || ve.Code != "min" {
+			t.Errorf("Expected min error via errors.As, got %v", v.Err())
+		}
+		if !errors.Is(v.Err(), &valtra.ValidationError{Code: "max", Field: "age"}) {
+			t.Errorf("Expected max error via errors.Is, got %v", v.Err())
+		}
+	})
+
+	t.Run("FirstError() returns nil when valid", func(t *testing.T) {
+		v := valtra.Val(10).Validate(valtra.Min(5))
+		if v.FirstError() != nil {
+			t.Errorf("Expected nil, got %v", v.FirstError())
+		}
+	})
+
+	t.Run("FirstError() returns the first error", func(t *testing.T) {
+		v := valtra.Val(1).Validate(valtra.Min(5), valtra.Max(0))
+		if v.FirstError() != v.Errors()[0] {
+			t.Errorf("Expected %v, got %v", v.Errors()[0], v.FirstError())
+		}
+	})
+
+	t.Run("Get() returns the value and joined errors", func(t *testing.T) {
+		value, err := valtra.Val(10).Validate(valtra.Min(5)).Get()
+		if value != 10 || err != nil {
+			t.Errorf("Expected 10 and nil, got %d and %v", value, err)
+		}
+
+		value, err = valtra.Val(1, "age").Validate(valtra.Min(5)).Get()
+		if value != 1 || !errors.Is(err, &valtra.ValidationError{Code: "min", Field: "age"}) {
+			t.Errorf("Expected 1 and a min error, got %d and %v", value, err)
+		}
+	})
+
+	t.Run("MustValue() returns the value when valid", func(t *testing.T) {
+		if got := valtra.Val(10).Validate(valtra.Min(5)).MustValue(); got != 10 {
+			t.Errorf("Expected 10, got %d", got)
+		}
+	})
+
+	t.Run("MustValue() panics with the errors when invalid", func(t *testing.T) {
+		defer func() {
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, &valtra.ValidationError{Code: "min", Field: "age"}) {
+				t.Errorf("Expected a panic with the min error, got %v", err)
+			}
+		}()
+
+		valtra.Val(1, "age").Validate(valtra.Min(5)).MustValue()
+	})
+
+	t.Run("Or() returns the value or the fallback", func(t *testing.T) {
+		if got := valtra.Val(10).Validate(valtra.Min(5)).Or(20); got != 10 {
+			t.Errorf("Expected 10, got %d", got)
+		}
+		if got := valtra.Val(1).Validate(valtra.Min(5)).Or(20); got != 20 {
+			t.Errorf("Expected 20, got %d", got)
+		}
+	})
+}
+
+func TestValidateCtx(t *testing.T) {
+	taken := func(ctx context.Context, v valtra.Value[string]) error {
+		if v.Value() == "admin" {
+			return errors.New("username is taken")
+		}
+		return nil
+	}
+
+	t.Run("context validation passes", func(t *testing.T) {
+		v := valtra.Val("bobby").ValidateCtx(context.Background(), taken)
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("context validation fails", func(t *testing.T) {
+		v := valtra.Val("admin").ValidateCtx(context.Background(), taken)
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+	})
+
+	t.Run("cancelled context stops validations", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		called := false
+		v := valtra.Val("bobby").ValidateCtx(ctx, func(ctx context.Context, v valtra.Value[string]) error {
+			called = true
+			return nil
+		})
+
+		if called {
+			t.Error("Expected validation not to run on cancelled context")
+		}
+		if len(v.Errors()) != 1 || !errors.Is(v.Errors()[0], context.Canceled) {
+			t.Errorf("Expected context.Canceled error, got %v", v.Errors())
+		}
+	})
+}
+
+func TestAll(t *testing.T) {
+	t.Run("all valid values", func(t *testing.T) {
+		name := valtra.Val("bobby").Validate(valtra.Required[string]())
+		age := valtra.Val(28).Validate(valtra.Min(18))
+		if !valtra.All(name, age) {
+			t.Error("Expected all values to be valid")
+		}
+		if valtra.AnyInvalid(name, age) {
+			t.Error("Expected no invalid values")
+		}
+		if err := valtra.Join(name, age); err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+	})
+
+	t.Run("one invalid value", func(t *testing.T) {
+		name := valtra.Val("").Validate(valtra.Required[string]())
+		age := valtra.Val(15).Validate(valtra.Min(18))
+		if valtra.All(name, age) {
+			t.Error("Expected values not to be all valid")
+		}
+		if !valtra.AnyInvalid(name, age) {
+			t.Error("Expected an invalid value")
+		}
+
+		err := valtra.Join(name, age)
+		if err == nil {
+			t.Fatal("Expected joined error")
+		}
+		if !errors.Is(err, name.Errors()[0]) || !errors.Is(err, age.Errors()[0]) {
+			t.Errorf("Expected joined error to contain both errors, got %v", err)
+		}
+	})
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("valid value returns nil error", func(t *testing.T) {
+		email, err := valtra.Check("test@example.com", valtra.Required[string](), valtra.Email())
+		if err != nil {
+			t.Errorf("Expected nil error, got %v", err)
+		}
+		if email != "test@example.com" {
+			t.Errorf("Expected value to be returned, got %q", email)
+		}
+	})
+
+	t.Run("invalid value returns joined error", func(t *testing.T) {
+		_, err := valtra.Check("", valtra.Required[string](), valtra.Email())
+		if err == nil {
+			t.Fatal("Expected error for invalid value")
+		}
+
+		var joined interface{ Unwrap() []error }
+		if !errors.As(err, &joined) || len(joined.Unwrap()) != 2 {
+			t.Errorf("Expected 2 joined errors, got %v", err)
+		}
+	})
+}
+
+func TestAlso(t *testing.T) {
+	t.Run("observers see the value mid-chain", func(t *testing.T) {
+		var seen []string
+		v := valtra.Val("  Bobby ").
+			Transform(valtra.TrimSpace()).
+			Also(func(v valtra.Value[string]) { seen = append(seen, v.Value()) }).
+			Transform(valtra.Lowercase()).
+			Also(func(v valtra.Value[string]) { seen = append(seen, v.Value()) })
+
+		if v.Value() != "bobby" || len(seen) != 2 || seen[0] != "Bobby" || seen[1] != "bobby" {
+			t.Errorf("Expected observers to see Bobby then bobby, got %q", seen)
+		}
+	})
+
+	t.Run("observers see failed values", func(t *testing.T) {
+		var errs int
+		valtra.Val("").Strict().Validate(valtra.Required[string]()).Also(func(v valtra.Value[string]) { errs = len(v.Errors()) })
+		if errs != 1 {
+			t.Errorf("Expected the observer to see 1 error, got %d", errs)
+		}
+	})
+}
+
+func TestWarn(t *testing.T) {
+	t.Run("failures are warnings", func(t *testing.T) {
+		v := valtra.Val("a long biography", "bio").Warn(valtra.MaxLengthString(5))
+		if !v.IsValid() || len(v.Warnings()) != 1 {
+			t.Fatalf("Expected 1 warning and no errors, got %v, %v", v.Errors(), v.Warnings())
+		}
+
+		var ve *valtra.ValidationError
+		if !errors.As(v.Warnings()[0], &ve) || ve.Code != "max_length" || ve.Severity != valtra.SeverityWarning {
+			t.Errorf("Unexpected warning: %v", v.Warnings()[0])
+		}
+	})
+
+	t.Run("passing rules add no warnings", func(t *testing.T) {
+		v := valtra.Val("bio", "bio").Warn(valtra.MaxLengthString(5))
+		if !v.IsValid() || len(v.Warnings()) != 0 {
+			t.Errorf("Expected no warnings, got %v", v.Warnings())
+		}
+	})
+
+	t.Run("fixes are not applied", func(t *testing.T) {
+		v := valtra.Val(" bobby@example.com").Warn(valtra.Email(valtra.AutoFix()))
+		if v.Value() != " bobby@example.com" || len(v.Warnings()) != 1 {
+			t.Errorf("Unexpected value or warnings: %q, %v", v.Value(), v.Warnings())
+		}
+	})
+}
+
+func TestStrict(t *testing.T) {
+	t.Run("first failure stops subsequent validations", func(t *testing.T) {
+		v := valtra.Val("", "email").Strict().Validate(valtra.Required[string](), valtra.Email())
+		if len(v.Errors()) != 1 {
+			t.Fatalf("Expected 1 error, got %d: %v", len(v.Errors()), v.Errors())
+		}
+		if v.Errors()[0].Error() != "email is required" {
+			t.Errorf("Expected %q, got %q", "email is required", v.Errors()[0].Error())
+		}
+	})
+
+	t.Run("failure stops later calls", func(t *testing.T) {
+		v := valtra.Val("").Strict().
+			Validate(valtra.Required[string]()).
+			Transform(valtra.Uppercase()).
+			ValidateCtx(context.Background(), func(ctx context.Context, v valtra.Value[string]) error {
+				t.Error("Expected context-aware validation to be skipped")
+				return nil
+			}).
+			Validate(valtra.MinLengthString(3))
+		if len(v.Errors()) != 1 {
+			t.Errorf("Expected 1 error, got %d: %v", len(v.Errors()), v.Errors())
+		}
+	})
+
+	t.Run("passing values run all steps", func(t *testing.T) {
+		v := valtra.Val("bobby@example.com").Strict().
+			Validate(valtra.Required[string](), valtra.Email()).
+			Transform(valtra.Uppercase())
+		if !v.IsValid() || v.Value() != "BOBBY@EXAMPLE.COM" {
+			t.Errorf("Expected valid uppercased value, got %q with errors: %v", v.Value(), v.Errors())
+		}
+	})
+
+	t.Run("default mode reports all failures", func(t *testing.T) {
+		v := valtra.Val("").Validate(valtra.Required[string](), valtra.Email())
+		if len(v.Errors()) != 2 {
+			t.Errorf("Expected 2 errors, got %d: %v", len(v.Errors()), v.Errors())
+		}
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Run("value is converted", func(t *testing.T) {
+		v := valtra.Map(valtra.Val("42", "age"), strconv.Atoi).Validate(valtra.Min(18))
+		if !v.IsValid() || v.Value() != 42 {
+			t.Errorf("Expected valid 42, got %d with errors: %v", v.Value(), v.Errors())
+		}
+		if v.Name() != "age" {
+			t.Errorf("Expected name %q, got %q", "age", v.Name())
+		}
+	})
+
+	t.Run("conversion error is added", func(t *testing.T) {
+		v := valtra.Map(valtra.Val("forty-two"), strconv.Atoi)
+		if v.IsValid() || v.Value() != 0 {
+			t.Errorf("Expected conversion to fail with zero value, got %d", v.Value())
+		}
+	})
+
+	t.Run("earlier errors are kept", func(t *testing.T) {
+		v := valtra.Val("", "age").Validate(valtra.Required[string]())
+		converted := valtra.Map(v, strconv.Atoi)
+		if len(converted.Errors()) != 2 {
+			t.Errorf("Expected 2 errors, got %d: %v", len(converted.Errors()), converted.Errors())
+		}
+	})
+
+	t.Run("strict mode skips conversion after failure", func(t *testing.T) {
+		v := valtra.Val("", "age").Strict().Validate(valtra.Required[string]())
+		converted := valtra.Map(v, strconv.Atoi).Validate(valtra.Min(18))
+		if len(converted.Errors()) != 1 {
+			t.Errorf("Expected 1 error, got %d: %v", len(converted.Errors()), converted.Errors())
+		}
+	})
+}
+
+func TestConvert(t *testing.T) {
+	t.Run("conversion receives the value", func(t *testing.T) {
+		v := valtra.Convert(valtra.Val("lots", "cpu"), valtra.ToQuantity())
+		if v.IsValid() {
+			t.Fatal("Expected conversion to fail")
+		}
+		if v.Errors()[0].Error() != "cpu must be a valid quantity" {
+			t.Errorf("Expected %q, got %q", "cpu must be a valid quantity", v.Errors()[0].Error())
+		}
+	})
+}
+
+func TestOptionalVal(t *testing.T) {
+	t.Run("nil pointer skips all rules", func(t *testing.T) {
+		v := valtra.OptionalVal[string](nil, "nickname").
+			Validate(valtra.Required[string](), valtra.MinLengthString(3)).
+			Transform(valtra.Uppercase())
+		if !v.IsValid() {
+			t.Errorf("Expected validation to pass, got errors: %v", v.Errors())
+		}
+		if v.Present() {
+			t.Error("Expected value to be absent")
+		}
+	})
+
+	t.Run("present value is validated", func(t *testing.T) {
+		nickname := "bo"
+		v := valtra.OptionalVal(&nickname, "nickname").Validate(valtra.MinLengthString(3))
+		if v.IsValid() {
+			t.Error("Expected validation to fail")
+		}
+		if !v.Present() {
+			t.Error("Expected value to be present")
+		}
+	})
+
+	t.Run("absence is kept by conversions", func(t *testing.T) {
+		v := valtra.Convert(valtra.OptionalVal[string](nil, "age"), valtra.ParseInt()).Validate(valtra.Min(18))
+		if !v.IsValid() || v.Present() {
+			t.Errorf("Expected absent valid value, got errors: %v", v.Errors())
+		}
+	})
+
+	t.Run("collected as pointer", func(t *testing.T) {
+		c := valtra.NewCollector()
+
+		if p := valtra.OptionalVal[string](nil).CollectOptional(c); p != nil {
+			t.Errorf("Expected nil, got %q", *p)
+		}
+
+		nickname := " bobby "
+		p := valtra.OptionalVal(&nickname).Transform(valtra.TrimSpace()).CollectOptional(c)
+		if p == nil || *p != "bobby" {
+			t.Errorf("Expected pointer to %q, got %v", "bobby", p)
+		}
+		if nickname != " bobby " {
+			t.Errorf("Expected input to be unchanged, got %q", nickname)
+		}
+	})
+}
+
+// usernameRules are typical rules for a form field, used by
+// the benchmarks.
+var usernameRules = []func(valtra.Value[string]) error{
+	valtra.Required[string](),
+	valtra.MinLengthString(3),
+	valtra.MaxLengthString(50),
+}
+
+func BenchmarkValidate(b *testing.B) {
+	b.Run("valid", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if !valtra.Val("bobby", "username").Transform(valtra.TrimSpace()).Validate(usernameRules...).IsValid() {
+				b.Fatal("Expected the value to be valid")
+			}
+		}
+	})
+
+	b.Run("invalid", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if valtra.Val("", "username").Validate(usernameRules...).IsValid() {
+				b.Fatal("Expected the value to be invalid")
+			}
+		}
+	})
 }